@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -18,10 +22,16 @@ import (
 	"github.com/qdm12/ddns-updater/internal/data"
 	"github.com/qdm12/ddns-updater/internal/health"
 	"github.com/qdm12/ddns-updater/internal/healthchecksio"
+	"github.com/qdm12/ddns-updater/internal/history"
+	"github.com/qdm12/ddns-updater/internal/metrics"
 	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/notify"
 	jsonparams "github.com/qdm12/ddns-updater/internal/params"
 	persistence "github.com/qdm12/ddns-updater/internal/persistence/json"
+	"github.com/qdm12/ddns-updater/internal/persistence/sqlite"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
 	recordslib "github.com/qdm12/ddns-updater/internal/records"
+	"github.com/qdm12/ddns-updater/internal/reload"
 	"github.com/qdm12/ddns-updater/internal/resolver"
 	"github.com/qdm12/ddns-updater/internal/server"
 	"github.com/qdm12/ddns-updater/internal/shoutrrr"
@@ -29,6 +39,8 @@ import (
 	"github.com/qdm12/ddns-updater/pkg/publicip"
 	"github.com/qdm12/gosettings/reader"
 	"github.com/qdm12/goshutdown"
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/order"
 	"github.com/qdm12/gosplash"
 	"github.com/qdm12/log"
 )
@@ -55,6 +67,20 @@ func main() {
 		},
 	})
 
+	// The shutdown settings are read here to bound the wait for _main
+	// to return on shutdown, and default values are used if they are
+	// not valid, since errors are reported when _main reads all the settings.
+	var shutdownSettings config.Shutdown
+	err := shutdownSettings.Read(reader)
+	if err == nil {
+		shutdownSettings.SetDefaults()
+		err = shutdownSettings.Validate()
+	}
+	if err != nil {
+		shutdownSettings = config.Shutdown{}
+		shutdownSettings.SetDefaults()
+	}
+
 	ctx := context.Background()
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	ctx, cancel := context.WithCancel(ctx)
@@ -78,8 +104,7 @@ func main() {
 		cancel()
 	}
 
-	const shutdownGracePeriod = 5 * time.Second
-	timer := time.NewTimer(shutdownGracePeriod)
+	timer := time.NewTimer(*shutdownSettings.Timeout)
 	select {
 	case err := <-errorCh:
 		if !timer.Stop() {
@@ -118,6 +143,14 @@ func _main(ctx context.Context, reader *reader.Reader, args []string, logger log
 
 			client := health.NewClient()
 			return client.Query(ctx, *healthSettings.ServerAddress)
+		case "validate", "-validate", "--validate":
+			// Validating the program settings and the JSON records settings,
+			// in an ephemeral fashion, without starting the update loop.
+			return validateConfig(reader, logger)
+		case "export":
+			// Exporting the update history stored in the SQLite history
+			// store to stdout, in an ephemeral fashion.
+			return exportHistory(ctx, reader, args[2:])
 		}
 	}
 
@@ -174,7 +207,7 @@ func _main(ctx context.Context, reader *reader.Reader, args []string, logger log
 	}
 
 	jsonReader := jsonparams.NewReader(logger)
-	jsonFilepath := filepath.Join(*config.Paths.DataDir, "config.json")
+	jsonFilepath := jsonparams.ConfigFilePath(*config.Paths.DataDir, os.Stat)
 	providers, warnings, err := jsonReader.JSONProviders(jsonFilepath)
 	for _, w := range warnings {
 		logger.Warn(w)
@@ -195,25 +228,112 @@ func _main(ctx context.Context, reader *reader.Reader, args []string, logger log
 		logger.Info("Found " + fmt.Sprint(len(providers)) + " settings to update records")
 	}
 
-	client := &http.Client{Timeout: config.Client.Timeout}
+	headers.OverrideUserAgent(config.Client.UserAgent)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.Proxy = config.Client.Proxy()
+	if config.Client.ResolverAddress != "" {
+		httpResolver, err := resolver.New(resolver.Settings{
+			Address: &config.Client.ResolverAddress,
+			Timeout: config.Resolver.Timeout,
+		})
+		if err != nil {
+			return fmt.Errorf("creating HTTP client resolver: %w", err)
+		}
+		const dialTimeout, keepAlive = 30 * time.Second, 30 * time.Second
+		dialer := &net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: keepAlive,
+			Resolver:  httpResolver,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	client := &http.Client{
+		Timeout:   config.Client.Timeout,
+		Transport: transport,
+	}
+
+	customTLSConfig, err := config.Client.CustomTLSConfig()
+	if err != nil {
+		return fmt.Errorf("creating custom endpoints TLS configuration: %w", err)
+	}
+	if customTLSConfig.InsecureSkipVerify {
+		logger.Warn("TLS certificate verification is disabled for custom endpoints")
+	}
+	customTransport := transport.Clone()
+	customTransport.TLSClientConfig = customTLSConfig
+	customClient := &http.Client{
+		Timeout:   config.Client.Timeout,
+		Transport: customTransport,
+	}
+	defer customClient.CloseIdleConnections()
 
 	err = health.CheckHTTP(ctx, client)
 	if err != nil {
 		logger.Warn(err.Error())
 	}
 
-	records := make([]recordslib.Record, len(providers))
-	for i, provider := range providers {
+	makeRecord := func(providerSettings jsonparams.ProviderSettings) (
+		record recordslib.Record, err error) {
+		provider := providerSettings.Provider
 		logger.Info("Reading history from database: domain " +
 			provider.Domain() + " host " + provider.Host() +
 			" " + provider.IPVersion().String())
 		events, err := persistentDB.GetEvents(provider.Domain(),
 			provider.Host(), provider.IPVersion())
+		if err != nil {
+			return record, err
+		}
+		httpTimeout := *config.Update.HTTPTimeout
+		if providerSettings.HTTPTimeout != nil {
+			httpTimeout = *providerSettings.HTTPTimeout
+		}
+		interval := config.Update.Period
+		if providerSettings.Interval != nil {
+			interval = *providerSettings.Interval
+		}
+		dryRun := *config.Update.DryRun
+		if providerSettings.DryRun != nil {
+			dryRun = *providerSettings.DryRun
+		}
+		skipUnchanged := *config.Update.SkipUnchanged
+		if providerSettings.SkipUnchanged != nil {
+			skipUnchanged = *providerSettings.SkipUnchanged
+		}
+		forceInterval := *config.Update.ForceInterval
+		if providerSettings.ForceInterval != nil {
+			forceInterval = *providerSettings.ForceInterval
+		}
+		updateOnStart := *config.Update.OnStart
+		if providerSettings.UpdateOnStart != nil {
+			updateOnStart = *providerSettings.UpdateOnStart
+		}
+		settings := models.RecordSettings{
+			ProviderName:   providerSettings.Name,
+			Interval:       interval,
+			Cron:           providerSettings.Cron,
+			Retry:          config.Update.Retry.OverrideWith(providerSettings.Retry).ToSettings(),
+			RateLimit:      providerSettings.RateLimit,
+			HealthcheckURL: providerSettings.HealthcheckURL,
+			HTTPTimeout:    httpTimeout,
+			ProxyURL:       providerSettings.ProxyURL,
+			DryRun:         dryRun,
+			SkipUnchanged:  skipUnchanged,
+			ForceInterval:  forceInterval,
+			UpdateOnStart:  updateOnStart,
+			RecordType:     providerSettings.RecordType,
+			Content:        providerSettings.Content,
+		}
+		return recordslib.New(provider, settings, events), nil
+	}
+
+	records := make([]recordslib.Record, len(providers))
+	for i, providerSettings := range providers {
+		records[i], err = makeRecord(providerSettings)
 		if err != nil {
 			shoutrrrClient.Notify(err.Error())
 			return err
 		}
-		records[i] = recordslib.New(provider, events)
 	}
 
 	defer client.CloseIdleConnections()
@@ -225,17 +345,33 @@ func _main(ctx context.Context, reader *reader.Reader, args []string, logger log
 		}
 	}()
 
-	httpSettings := publicip.HTTPSettings{
-		Enabled: *config.PubIP.HTTPEnabled,
-		Client:  client,
-		Options: config.PubIP.ToHTTPOptions(),
-	}
-	dnsSettings := publicip.DNSSettings{
-		Enabled: *config.PubIP.DNSEnabled,
-		Options: config.PubIP.ToDNSPOptions(),
+	publicIPSettings := publicip.Settings{
+		Interface: publicip.InterfaceSettings{
+			Enabled: *config.PubIP.InterfaceEnabled,
+			Options: config.PubIP.ToInterfaceOptions(),
+		},
+		STUN: publicip.STUNSettings{
+			Enabled: *config.PubIP.STUNEnabled,
+			Options: config.PubIP.ToSTUNOptions(),
+		},
+		DNS: publicip.DNSSettings{
+			Enabled: *config.PubIP.DNSEnabled,
+			Options: config.PubIP.ToDNSPOptions(),
+		},
+		HTTP: publicip.HTTPSettings{
+			Enabled:          *config.PubIP.HTTPEnabled,
+			Client:           client,
+			CustomURLsClient: customClient,
+			Options:          config.PubIP.ToHTTPOptions(),
+		},
+		Strategy: publicip.Strategy(config.PubIP.Strategy),
+		IPv6Prefix: publicip.InterfaceSettings{
+			Enabled: config.PubIP.IPv6PrefixInterface != "",
+			Options: config.PubIP.ToIPv6PrefixOptions(),
+		},
 	}
 
-	ipGetter, err := publicip.NewFetcher(dnsSettings, httpSettings)
+	ipGetter, err := publicip.NewFetcher(publicIPSettings)
 	if err != nil {
 		return err
 	}
@@ -252,16 +388,96 @@ func _main(ctx context.Context, reader *reader.Reader, args []string, logger log
 	hioClient := healthchecksio.New(client, config.Health.HealthchecksioBaseURL,
 		*config.Health.HealthchecksioUUID)
 
-	updater := update.NewUpdater(db, client, shoutrrrClient, logger, timeNow)
-	runner := update.NewRunner(db, updater, ipGetter, config.Update.Period,
-		config.Update.Cooldown, logger, resolver, timeNow, hioClient)
+	var notifiers []notify.Notifier
+	if len(config.Shoutrrr.Addresses) > 0 {
+		notifiers = append(notifiers, notify.NewShoutrrr(shoutrrrClient))
+	}
+	if *config.Webhook.URL != "" {
+		webhook := notify.NewWebhook(client, *config.Webhook.URL, config.Webhook.HeadersMap())
+		notifiers = append(notifiers, webhook)
+	}
+	if config.Telegram.Enabled() {
+		telegram := notify.NewTelegram(client, *config.Telegram.BotToken, *config.Telegram.ChatID)
+		notifiers = append(notifiers, telegram)
+	}
+	if *config.Discord.WebhookURL != "" {
+		discord := notify.NewDiscord(client, *config.Discord.WebhookURL)
+		notifiers = append(notifiers, discord)
+	}
+	if config.SMTP.Enabled() {
+		smtpSettings := notify.SMTPSettings{
+			Host:            *config.SMTP.Host,
+			Port:            *config.SMTP.Port,
+			Username:        *config.SMTP.Username,
+			Password:        *config.SMTP.Password,
+			From:            *config.SMTP.From,
+			To:              config.SMTP.To,
+			ImplicitTLS:     config.SMTP.ImplicitTLS(),
+			NotifyFailures:  *config.SMTP.NotifyFailures,
+			SubjectTemplate: config.SMTP.SubjectTemplate,
+			BodyTemplate:    config.SMTP.BodyTemplate,
+			Timeout:         config.SMTP.Timeout,
+		}
+		smtpNotifier, err := notify.NewSMTP(smtpSettings)
+		if err != nil {
+			return fmt.Errorf("setting up SMTP notifications: %w", err)
+		}
+		notifiers = append(notifiers, smtpNotifier)
+	}
+	if *config.Gotify.URL != "" {
+		gotifyURL, err := url.Parse(*config.Gotify.URL)
+		if err != nil {
+			return fmt.Errorf("parsing gotify URL: %w", err)
+		}
+		gotify := notify.NewGotify(client, gotifyURL, *config.Gotify.Token, *config.Gotify.Priority)
+		notifiers = append(notifiers, gotify)
+	}
+	dispatcher := notify.NewDispatcher(notifiers, logger.New(log.SetComponent("notify")))
+	dispatcherHandler, dispatcherCtx, dispatcherDone := goshutdown.NewGoRoutineHandler("notify")
+	go dispatcher.Run(dispatcherCtx, dispatcherDone)
+
+	var historyStore history.Store = history.Noop{}
+	if *config.History.SQLitePath != "" {
+		historyStore, err = sqlite.New(ctx, *config.History.SQLitePath,
+			config.History.ToRetention(), timeNow)
+		if err != nil {
+			return fmt.Errorf("creating history store: %w", err)
+		}
+	}
+	defer func() {
+		err := historyStore.Close()
+		if err != nil {
+			logger.Error(err.Error())
+		}
+	}()
 
-	runnerHandler, runnerCtx, runnerDone := goshutdown.NewGoRoutineHandler("runner")
+	metricsRecorder := metrics.New(db, timeNow)
+	instrumentedIPGetter := metrics.NewInstrumentedFetcher(ipGetter, metricsRecorder)
+	cachedIPGetter := publicip.NewCache(instrumentedIPGetter, *config.PubIP.CacheTTL, timeNow)
+
+	updater := update.NewUpdater(db, client, customClient, shoutrrrClient, dispatcher, historyStore,
+		metricsRecorder, logger, timeNow)
+	runner := update.NewRunner(db, updater, cachedIPGetter, config.Update.Period,
+		config.Update.Cooldown, config.Update.Jitter, *config.Shutdown.GracePeriod,
+		config.Update.FailureBackoff.ToSettings(),
+		logger, resolver, timeNow, hioClient)
+
+	// The runner is given the grace period for in-flight updates to complete,
+	// and some more time to store their results once canceled.
+	const runnerShutdownMargin = 2 * time.Second
+	runnerHandler, runnerCtx, runnerDone := goshutdown.NewGoRoutineHandler("runner",
+		goroutine.OptionTimeout(*config.Shutdown.GracePeriod+runnerShutdownMargin))
 	go runner.Run(runnerCtx, runnerDone)
 
 	// note: errors are logged within the goroutine,
 	// no need to collect the resulting errors.
-	go runner.ForceUpdate(ctx)
+	go runner.UpdateOnStart(ctx)
+
+	reloadHandler, reloadCtx, reloadDone := goshutdown.NewGoRoutineHandler("config reload")
+	reloadLogger := logger.New(log.SetComponent("config reload"))
+	reloader := reload.New(jsonFilepath, *config.Update.ConfigReloadPeriod, providers,
+		jsonReader, makeRecord, runner, reloadLogger)
+	go reloader.Run(reloadCtx, reloadDone)
 
 	isHealthy := health.MakeIsHealthy(db, resolver)
 	healthLogger := logger.New(log.SetComponent("healthcheck server"))
@@ -272,22 +488,33 @@ func _main(ctx context.Context, reader *reader.Reader, args []string, logger log
 
 	serverLogger := logger.New(log.SetComponent("http server"))
 	server := server.New(ctx, config.Server.ListeningAddress, config.Server.RootURL,
-		db, serverLogger, runner)
+		db, serverLogger, runner, metricsRecorder.Handler())
 	serverHandler, serverCtx, serverDone := goshutdown.NewGoRoutineHandler("server")
 	go server.Run(serverCtx, serverDone)
 	shoutrrrClient.Notify("Launched with " + strconv.Itoa(len(records)) + " records to watch")
 
 	backupHandler, backupCtx, backupDone := goshutdown.NewGoRoutineHandler("backup")
 	backupLogger := logger.New(log.SetComponent("backup"))
-	go backupRunLoop(backupCtx, backupDone, *config.Backup.Period, *config.Paths.DataDir,
+	go backupRunLoop(backupCtx, backupDone, *config.Backup.Period, *config.Paths.DataDir, jsonFilepath,
 		*config.Backup.Directory, backupLogger, timeNow)
 
-	shutdownGroup := goshutdown.NewGroupHandler("")
-	shutdownGroup.Add(runnerHandler, healthServerHandler, serverHandler, backupHandler)
+	pruneHandler, pruneCtx, pruneDone := goshutdown.NewGoRoutineHandler("history pruning")
+	pruneLogger := logger.New(log.SetComponent("history pruning"))
+	go pruneRunLoop(pruneCtx, pruneDone, historyStore, config.History.PrunePeriod, pruneLogger)
+
+	// Goroutines starting updates are shut down first, so in-flight updates
+	// can still notify and store their history before the others stop.
+	updatesGroup := goshutdown.NewGroupHandler("updates")
+	updatesGroup.Add(runnerHandler, reloadHandler, serverHandler)
+	otherGroup := goshutdown.NewGroupHandler("others")
+	otherGroup.Add(healthServerHandler, backupHandler, dispatcherHandler, pruneHandler)
+	shutdownOrder := goshutdown.NewOrderHandler("",
+		order.OptionTimeout(*config.Shutdown.Timeout))
+	shutdownOrder.Append(updatesGroup, otherGroup)
 
 	<-ctx.Done()
 
-	err = shutdownGroup.Shutdown(context.Background())
+	err = shutdownOrder.Shutdown(context.Background())
 	if err != nil {
 		exitHealthchecksio(hioClient, logger, healthchecksio.Exit1)
 		shoutrrrClient.Notify(err.Error())
@@ -304,7 +531,7 @@ type InfoErroer interface {
 }
 
 func backupRunLoop(ctx context.Context, done chan<- struct{}, backupPeriod time.Duration,
-	dataDir, outputDir string, logger InfoErroer, timeNow func() time.Time) {
+	dataDir, configFilepath, outputDir string, logger InfoErroer, timeNow func() time.Time) {
 	defer close(done)
 	if backupPeriod == 0 {
 		logger.Info("disabled")
@@ -320,7 +547,7 @@ func backupRunLoop(ctx context.Context, done chan<- struct{}, backupPeriod time.
 		err := ziper.ZipFiles(
 			zipFilepath,
 			filepath.Join(dataDir, "updates.json"),
-			filepath.Join(dataDir, "config.json"),
+			configFilepath,
 		)
 		if err != nil {
 			logger.Error(err.Error())
@@ -335,6 +562,24 @@ func backupRunLoop(ctx context.Context, done chan<- struct{}, backupPeriod time.
 	}
 }
 
+func pruneRunLoop(ctx context.Context, done chan<- struct{}, store history.Store,
+	period time.Duration, logger InfoErroer) {
+	defer close(done)
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := store.Prune(ctx)
+			if err != nil {
+				logger.Error(err.Error())
+			}
+		}
+	}
+}
+
 func exitHealthchecksio(hioClient *healthchecksio.Client,
 	logger log.LoggerInterface, state healthchecksio.State) {
 	err := hioClient.Ping(context.Background(), state)
@@ -342,3 +587,97 @@ func exitHealthchecksio(hioClient *healthchecksio.Client,
 		logger.Error(err.Error())
 	}
 }
+
+var ErrExportFormatNotValid = errors.New("export format is not valid")
+
+var errRecordsNotValid = errors.New("records settings are not valid")
+
+func validateConfig(reader *reader.Reader, logger log.LoggerInterface) (err error) {
+	var settings config.Config
+	err = settings.Read(reader, logger)
+	if err != nil {
+		return fmt.Errorf("reading settings: %w", err)
+	}
+	settings.SetDefaults()
+	err = settings.Validate()
+	if err != nil {
+		return fmt.Errorf("settings validation: %w", err)
+	}
+
+	jsonReader := jsonparams.NewReader(logger)
+	jsonFilepath := jsonparams.ConfigFilePath(*settings.Paths.DataDir, os.Stat)
+	providers, warnings, recordErrors, err := jsonReader.ValidateJSON(jsonFilepath)
+	for _, w := range warnings {
+		logger.Warn(w)
+	}
+	if err != nil {
+		return fmt.Errorf("validating JSON settings: %w", err)
+	}
+
+	for _, recordError := range recordErrors {
+		logger.Error(recordError.Error())
+	}
+	if len(recordErrors) > 0 {
+		return fmt.Errorf("%w: %d invalid record settings found", errRecordsNotValid, len(recordErrors))
+	}
+
+	logger.Info("Configuration is valid with " + strconv.Itoa(len(providers)) + " records")
+	return nil
+}
+
+func exportHistory(ctx context.Context, reader *reader.Reader, args []string) (err error) {
+	flagSet := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flagSet.String("format", "csv", "export format, which can be csv or json")
+	domain := flagSet.String("domain", "", "domain to export entries for, all domains if empty")
+	host := flagSet.String("host", "", "host to export entries for, all hosts if empty")
+	since := flagSet.String("since", "", "RFC3339 inclusive start time of entries to export")
+	until := flagSet.String("until", "", "RFC3339 exclusive end time of entries to export")
+	err = flagSet.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing flags: %w", err)
+	}
+
+	filter := history.Filter{
+		Domain: *domain,
+		Host:   *host,
+	}
+	if *since != "" {
+		filter.Since, err = time.Parse(time.RFC3339, *since)
+		if err != nil {
+			return fmt.Errorf("parsing since time: %w", err)
+		}
+	}
+	if *until != "" {
+		filter.Until, err = time.Parse(time.RFC3339, *until)
+		if err != nil {
+			return fmt.Errorf("parsing until time: %w", err)
+		}
+	}
+
+	var historySettings config.History
+	err = historySettings.Read(reader)
+	if err != nil {
+		return fmt.Errorf("reading history settings: %w", err)
+	}
+	historySettings.SetDefaults()
+	if *historySettings.SQLitePath == "" {
+		return fmt.Errorf("%w", config.ErrHistorySQLitePathNotSet)
+	}
+
+	store, err := sqlite.New(ctx, *historySettings.SQLitePath,
+		history.Retention{}, time.Now)
+	if err != nil {
+		return fmt.Errorf("opening history store: %w", err)
+	}
+	defer store.Close()
+
+	exporter := history.NewExporter(store)
+	switch *format {
+	case "csv":
+		return exporter.ExportCSV(ctx, os.Stdout, filter)
+	case "json":
+		return exporter.ExportJSON(ctx, os.Stdout, filter)
+	default:
+		return fmt.Errorf("%w: %s", ErrExportFormatNotValid, *format)
+	}
+}
@@ -1,6 +1,14 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
 	"time"
 
 	"github.com/qdm12/gosettings"
@@ -10,14 +18,58 @@ import (
 
 type Client struct {
 	Timeout time.Duration
+	// ProxyURL is the HTTP, HTTPS or SOCKS5 proxy URL to use for all
+	// HTTP requests. It defaults to the empty string, in which case the
+	// standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables are used.
+	ProxyURL string
+	// ResolverAddress is the plaintext DNS server address to resolve
+	// hostnames of all HTTP requests. It defaults to the empty string,
+	// in which case the system resolver is used.
+	ResolverAddress string
+	// CustomCAFile is the path to a PEM encoded CA certificates file
+	// trusted in addition to the system ones, for custom endpoints only.
+	// It defaults to the empty string to only trust the system ones.
+	CustomCAFile string
+	// CustomInsecureSkipVerify disables TLS certificate verification
+	// for custom endpoints only. It cannot be nil in the internal state.
+	CustomInsecureSkipVerify *bool
+	// UserAgent overrides the default User-Agent header value sent to
+	// DNS providers. It defaults to the empty string to keep the default.
+	UserAgent string
 }
 
 func (c *Client) setDefaults() {
 	const defaultTimeout = 20 * time.Second
 	c.Timeout = gosettings.DefaultComparable(c.Timeout, defaultTimeout)
+	c.CustomInsecureSkipVerify = gosettings.DefaultPointer(c.CustomInsecureSkipVerify, false)
 }
 
 func (c Client) Validate() (err error) {
+	if c.ProxyURL != "" {
+		_, err = ParseProxyURL(c.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("proxy URL: %w", err)
+		}
+	}
+
+	if c.CustomCAFile != "" {
+		_, err = c.CustomTLSConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.ResolverAddress != "" {
+		host, port, err := net.SplitHostPort(c.ResolverAddress)
+		switch {
+		case err != nil:
+			return fmt.Errorf("resolver address: splitting host and port: %w", err)
+		case host == "":
+			return fmt.Errorf("resolver address: %w: in %s", ErrAddressHostEmpty, c.ResolverAddress)
+		case port == "":
+			return fmt.Errorf("resolver address: %w: in %s", ErrAddressPortEmpty, c.ResolverAddress)
+		}
+	}
 	return nil
 }
 
@@ -28,6 +80,23 @@ func (c Client) String() string {
 func (c Client) toLinesNode() *gotree.Node {
 	node := gotree.New("HTTP client")
 	node.Appendf("Timeout: %s", c.Timeout)
+	if c.ProxyURL != "" {
+		proxyURL, _ := ParseProxyURL(c.ProxyURL)
+		node.Appendf("Proxy: %s", proxyURL.Redacted())
+	}
+	if c.ResolverAddress != "" {
+		node.Appendf("Resolver: %s", c.ResolverAddress)
+	}
+	if c.UserAgent != "" {
+		node.Appendf("User agent: %s", c.UserAgent)
+	}
+	if c.CustomCAFile != "" || *c.CustomInsecureSkipVerify {
+		childNode := node.Appendf("Custom endpoints TLS")
+		if c.CustomCAFile != "" {
+			childNode.Appendf("CA file: %s", c.CustomCAFile)
+		}
+		childNode.Appendf("Insecure skip verify: %s", gosettings.BoolToYesNo(c.CustomInsecureSkipVerify))
+	}
 	return node
 }
 
@@ -37,5 +106,85 @@ func (c *Client) read(reader *reader.Reader) (err error) {
 		return err
 	}
 
+	c.ProxyURL = reader.String("PROXY_URL")
+
+	c.UserAgent = reader.String("USER_AGENT")
+
+	c.CustomCAFile = reader.String("CUSTOM_ENDPOINTS_CA_FILE")
+
+	c.CustomInsecureSkipVerify, err = reader.BoolPtr("CUSTOM_ENDPOINTS_INSECURE_SKIP_VERIFY")
+	if err != nil {
+		return err
+	}
+
+	c.ResolverAddress = reader.String("HTTP_RESOLVER_ADDRESS")
+	if c.ResolverAddress != "" { // conveniently add port 53 if not specified
+		_, _, err := net.SplitHostPort(c.ResolverAddress)
+		if err != nil {
+			c.ResolverAddress = net.JoinHostPort(c.ResolverAddress, "53")
+		}
+	}
+
 	return nil
 }
+
+// Proxy returns the proxy function to use for the HTTP transport.
+// It assumes the settings have been validated.
+func (c Client) Proxy() func(*http.Request) (*url.URL, error) {
+	if c.ProxyURL == "" {
+		return http.ProxyFromEnvironment
+	}
+	proxyURL, _ := ParseProxyURL(c.ProxyURL)
+	return http.ProxyURL(proxyURL)
+}
+
+var ErrCustomCAFileNoCertificate = errors.New("no certificate found in custom CA file")
+
+// CustomTLSConfig returns the TLS configuration to use for custom
+// endpoints, such as custom public IP echo URLs and the custom provider.
+func (c Client) CustomTLSConfig() (tlsConfig *tls.Config, err error) {
+	tlsConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: *c.CustomInsecureSkipVerify, //nolint:gosec
+	}
+
+	if c.CustomCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("loading system certificates: %w", err)
+	}
+
+	pemData, err := os.ReadFile(c.CustomCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading custom CA file: %w", err)
+	}
+
+	ok := rootCAs.AppendCertsFromPEM(pemData)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCustomCAFileNoCertificate, c.CustomCAFile)
+	}
+	tlsConfig.RootCAs = rootCAs
+
+	return tlsConfig, nil
+}
+
+var ErrProxySchemeNotValid = errors.New("proxy URL scheme is not valid")
+
+// ParseProxyURL parses and validates an HTTP, HTTPS or SOCKS5 proxy URL.
+func ParseProxyURL(s string) (proxyURL *url.URL, err error) {
+	proxyURL, err = url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return proxyURL, nil
+	default:
+		return nil, fmt.Errorf("%w: %q must be one of http, https, socks5 or socks5h",
+			ErrProxySchemeNotValid, proxyURL.Scheme)
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gotree"
+)
+
+type Discord struct {
+	WebhookURL *string
+}
+
+func (d *Discord) setDefaults() {
+	d.WebhookURL = gosettings.DefaultPointer(d.WebhookURL, "")
+}
+
+func (d Discord) Validate() (err error) {
+	if *d.WebhookURL == "" {
+		return nil
+	}
+
+	_, err = url.Parse(*d.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("discord webhook URL: %w", err)
+	}
+	return nil
+}
+
+func (d Discord) String() string {
+	return d.toLinesNode().String()
+}
+
+func (d Discord) toLinesNode() *gotree.Node {
+	if *d.WebhookURL == "" {
+		return nil // no webhook URL means discord is disabled
+	}
+
+	node := gotree.New("Discord")
+	node.Appendf("Webhook URL: [set]")
+	return node
+}
+
+func (d *Discord) read(r *reader.Reader) {
+	d.WebhookURL = r.Get("DISCORD_WEBHOOK_URL", reader.ForceLowercase(false))
+}
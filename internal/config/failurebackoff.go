@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gotree"
+)
+
+// FailureBackoff contains the settings to increase the interval
+// between update checks of a record failing repeatedly. It is
+// separate from the retries within an update check.
+type FailureBackoff struct {
+	// Failures is the number of consecutive failed updates of a record
+	// after which its interval is increased, and is zero to disable it.
+	Failures *uint
+	// Multiplier is the factor the interval is multiplied with
+	// for each consecutive failure from Failures.
+	Multiplier *float64
+	// MaxInterval is the maximum interval of a failing record.
+	MaxInterval *time.Duration
+}
+
+func (f *FailureBackoff) setDefaults() {
+	const defaultFailures = 3
+	f.Failures = gosettings.DefaultPointer(f.Failures, defaultFailures)
+	const defaultMultiplier = 2
+	f.Multiplier = gosettings.DefaultPointer(f.Multiplier, defaultMultiplier)
+	const defaultMaxInterval = 6 * time.Hour
+	f.MaxInterval = gosettings.DefaultPointer(f.MaxInterval, defaultMaxInterval)
+}
+
+var (
+	ErrFailureBackoffMultiplierLow     = errors.New("failure backoff multiplier cannot be lower than 1")
+	ErrFailureBackoffMaxIntervalNotSet = errors.New("failure backoff maximum interval must be positive")
+)
+
+func (f FailureBackoff) Validate() (err error) {
+	switch {
+	case *f.Multiplier < 1:
+		return fmt.Errorf("%w: %g", ErrFailureBackoffMultiplierLow, *f.Multiplier)
+	case *f.MaxInterval <= 0:
+		return fmt.Errorf("%w: %s", ErrFailureBackoffMaxIntervalNotSet, *f.MaxInterval)
+	}
+	return nil
+}
+
+// ToSettings returns the failure backoff settings to use for the
+// update runner. It must be called on defaulted settings.
+func (f FailureBackoff) ToSettings() models.FailureBackoffSettings {
+	return models.FailureBackoffSettings{
+		Failures:    *f.Failures,
+		Multiplier:  *f.Multiplier,
+		MaxInterval: *f.MaxInterval,
+	}
+}
+
+func (f FailureBackoff) String() string {
+	return f.toLinesNode().String()
+}
+
+func (f FailureBackoff) toLinesNode() *gotree.Node {
+	if *f.Failures == 0 {
+		return gotree.New("Failure backoff: disabled")
+	}
+	node := gotree.New("Failure backoff")
+	node.Appendf("Consecutive failures: %d", *f.Failures)
+	node.Appendf("Multiplier: %g", *f.Multiplier)
+	node.Appendf("Maximum interval: %s", *f.MaxInterval)
+	return node
+}
+
+func (f *FailureBackoff) read(reader *reader.Reader) (err error) {
+	f.Failures, err = reader.UintPtr("UPDATE_FAILURE_BACKOFF_FAILURES")
+	if err != nil {
+		return err
+	}
+
+	f.Multiplier, err = reader.Float64Ptr("UPDATE_FAILURE_BACKOFF_MULTIPLIER")
+	if err != nil {
+		return err
+	}
+
+	f.MaxInterval, err = reader.DurationPtr("UPDATE_FAILURE_BACKOFF_MAX_INTERVAL")
+	return err
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gotree"
+)
+
+type Gotify struct {
+	URL      *string
+	Token    *string
+	Priority *int
+}
+
+func (g *Gotify) setDefaults() {
+	g.URL = gosettings.DefaultPointer(g.URL, "")
+	g.Token = gosettings.DefaultPointer(g.Token, "")
+	const defaultPriority = 5
+	g.Priority = gosettings.DefaultPointer(g.Priority, defaultPriority)
+}
+
+var ErrGotifyTokenNotSet = errors.New("gotify token is not set")
+
+func (g Gotify) Validate() (err error) {
+	if *g.URL == "" {
+		return nil
+	}
+
+	_, err = url.Parse(*g.URL)
+	if err != nil {
+		return fmt.Errorf("gotify URL: %w", err)
+	}
+
+	if *g.Token == "" {
+		return fmt.Errorf("%w", ErrGotifyTokenNotSet)
+	}
+
+	return nil
+}
+
+func (g Gotify) String() string {
+	return g.toLinesNode().String()
+}
+
+func (g Gotify) toLinesNode() *gotree.Node {
+	if *g.URL == "" {
+		return nil // no URL means gotify is disabled
+	}
+
+	node := gotree.New("Gotify")
+	node.Appendf("URL: %s", *g.URL)
+	node.Appendf("Token: [set]")
+	node.Appendf("Priority: %d", *g.Priority)
+	return node
+}
+
+func (g *Gotify) read(r *reader.Reader) (err error) {
+	g.URL = r.Get("GOTIFY_URL", reader.ForceLowercase(false))
+	g.Token = r.Get("GOTIFY_TOKEN", reader.ForceLowercase(false))
+	g.Priority, err = r.IntPtr("GOTIFY_PRIORITY")
+	return err
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/history"
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gotree"
+)
+
+type History struct {
+	// SQLitePath is the file path of the SQLite database
+	// storing the update attempts history. It is disabled
+	// if empty.
+	SQLitePath *string
+	// MaxAge is the maximum age of history entries to keep,
+	// and a zero value keeps entries regardless of their age.
+	MaxAge *time.Duration
+	// MaxCount is the maximum number of history entries to keep
+	// per record, and a zero value disables this limit.
+	MaxCount *uint
+	// PrunePeriod is the period to prune history entries at.
+	PrunePeriod time.Duration
+}
+
+func (h *History) SetDefaults() {
+	h.SQLitePath = gosettings.DefaultPointer(h.SQLitePath, "")
+	h.MaxAge = gosettings.DefaultPointer(h.MaxAge, 0)
+	h.MaxCount = gosettings.DefaultPointer(h.MaxCount, 0)
+	h.PrunePeriod = gosettings.DefaultComparable(h.PrunePeriod, time.Hour)
+}
+
+var (
+	ErrHistorySQLitePathNotSet = errors.New("history SQLite path is not set")
+	ErrHistoryMaxAgeNegative   = errors.New("history maximum age is negative")
+	ErrHistoryPrunePeriodLow   = errors.New("history prune period is too low")
+)
+
+func (h History) Validate() (err error) {
+	if *h.MaxAge < 0 {
+		return fmt.Errorf("%w: %s", ErrHistoryMaxAgeNegative, *h.MaxAge)
+	}
+
+	if h.PrunePeriod <= 0 {
+		return fmt.Errorf("%w: %s", ErrHistoryPrunePeriodLow, h.PrunePeriod)
+	}
+
+	return nil
+}
+
+// ToRetention returns the retention of the history store.
+func (h History) ToRetention() history.Retention {
+	return history.Retention{
+		MaxAge:   *h.MaxAge,
+		MaxCount: *h.MaxCount,
+	}
+}
+
+func (h History) String() string {
+	return h.toLinesNode().String()
+}
+
+func (h History) toLinesNode() *gotree.Node {
+	if *h.SQLitePath == "" {
+		return gotree.New("History store: disabled")
+	}
+	node := gotree.New("History store")
+	node.Appendf("SQLite database path: %s", *h.SQLitePath)
+	if *h.MaxAge == 0 && *h.MaxCount == 0 {
+		node.Appendf("Retention: unlimited")
+		return node
+	}
+	retentionNode := node.Appendf("Retention")
+	if *h.MaxAge > 0 {
+		retentionNode.Appendf("Maximum age: %s", *h.MaxAge)
+	}
+	if *h.MaxCount > 0 {
+		retentionNode.Appendf("Maximum entries per record: %d", *h.MaxCount)
+	}
+	retentionNode.Appendf("Prune period: %s", h.PrunePeriod)
+	return node
+}
+
+func (h *History) Read(r *reader.Reader) (err error) {
+	h.SQLitePath = r.Get("HISTORY_SQLITE_PATH", reader.ForceLowercase(false))
+
+	h.MaxAge, err = r.DurationPtr("HISTORY_MAX_AGE")
+	if err != nil {
+		return err
+	}
+
+	h.MaxCount, err = r.UintPtr("HISTORY_MAX_COUNT")
+	if err != nil {
+		return err
+	}
+
+	h.PrunePeriod, err = r.Duration("HISTORY_PRUNE_PERIOD")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,7 +2,11 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/logging"
 
 	"github.com/qdm12/gosettings"
 	"github.com/qdm12/gosettings/reader"
@@ -14,11 +18,15 @@ import (
 type Logger struct {
 	Level  string
 	Caller string
+	// Format is the log lines format and can be "text"
+	// or "json" for one JSON object per line.
+	Format string
 }
 
 func (l *Logger) setDefaults() {
 	l.Level = gosettings.DefaultComparable(l.Level, log.LevelInfo.String())
 	l.Caller = gosettings.DefaultComparable(l.Caller, "hidden")
+	l.Format = gosettings.DefaultComparable(l.Format, "text")
 }
 
 func (l Logger) Validate() (err error) {
@@ -32,6 +40,11 @@ func (l Logger) Validate() (err error) {
 		return fmt.Errorf("log caller: %w", err)
 	}
 
+	err = validate.IsOneOf(l.Format, "text", "json")
+	if err != nil {
+		return fmt.Errorf("log format: %w", err)
+	}
+
 	return nil
 }
 
@@ -41,6 +54,11 @@ func (l Logger) ToOptions() (options []log.Option) {
 	if l.Caller == "short" {
 		options = append(options, log.SetCallerFile(true), log.SetCallerLine(true))
 	}
+	if l.Format == "json" {
+		// The JSON writer sets the time of each line itself.
+		options = append(options, log.SetTimeFormat(""),
+			log.SetWriters(logging.NewJSONWriter(os.Stdout, time.Now)))
+	}
 	return options
 }
 
@@ -52,6 +70,7 @@ func (l Logger) toLinesNode() *gotree.Node {
 	node := gotree.New("Logger")
 	node.Appendf("Level: %s", l.Level)
 	node.Appendf("Caller: %s", l.Caller)
+	node.Appendf("Format: %s", l.Format)
 	return node
 }
 
@@ -62,4 +81,5 @@ func (l *Logger) read(reader *reader.Reader) {
 		l.Level = "warn"
 	}
 	l.Caller = reader.String("LOG_CALLER")
+	l.Format = strings.ToLower(reader.String("LOG_FORMAT"))
 }
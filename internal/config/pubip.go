@@ -3,13 +3,17 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/qdm12/ddns-updater/pkg/publicip"
 	"github.com/qdm12/ddns-updater/pkg/publicip/dns"
 	"github.com/qdm12/ddns-updater/pkg/publicip/http"
+	"github.com/qdm12/ddns-updater/pkg/publicip/iface"
 	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/qdm12/ddns-updater/pkg/publicip/stun"
 	"github.com/qdm12/gosettings"
 	"github.com/qdm12/gosettings/reader"
 	"github.com/qdm12/gosettings/validate"
@@ -17,27 +21,78 @@ import (
 )
 
 type PubIP struct {
-	HTTPEnabled       *bool
-	HTTPIPProviders   []string
-	HTTPIPv4Providers []string
-	HTTPIPv6Providers []string
-	DNSEnabled        *bool
-	DNSProviders      []string
-	DNSTimeout        time.Duration
+	Strategy           string
+	HTTPEnabled        *bool
+	HTTPIPProviders    []string
+	HTTPIPv4Providers  []string
+	HTTPIPv6Providers  []string
+	HTTPOrder          string
+	DNSEnabled         *bool
+	DNSProviders       []string
+	DNSTimeout         time.Duration
+	CacheTTL           *time.Duration
+	InterfaceEnabled   *bool
+	InterfaceName      string
+	InterfaceIPVersion string
+	// IPv6PrefixInterface is the network interface to read the
+	// IPv6 address from, and is disabled if empty.
+	IPv6PrefixInterface string
+	STUNEnabled         *bool
+	STUNServers         []string
 }
 
 func (p *PubIP) setDefaults() {
+	p.Strategy = gosettings.DefaultComparable(p.Strategy, string(publicip.StrategyCycle))
+	p.InterfaceEnabled = gosettings.DefaultPointer(p.InterfaceEnabled, false)
+	p.InterfaceIPVersion = gosettings.DefaultComparable(p.InterfaceIPVersion, ipversion.IP4or6.String())
+	p.STUNEnabled = gosettings.DefaultPointer(p.STUNEnabled, false)
+	p.STUNServers = gosettings.DefaultSlice(p.STUNServers, []string{"stun.l.google.com:19302"})
 	p.HTTPEnabled = gosettings.DefaultPointer(p.HTTPEnabled, true)
 	p.HTTPIPProviders = gosettings.DefaultSlice(p.HTTPIPProviders, []string{all})
 	p.HTTPIPv4Providers = gosettings.DefaultSlice(p.HTTPIPv4Providers, []string{all})
 	p.HTTPIPv6Providers = gosettings.DefaultSlice(p.HTTPIPv6Providers, []string{all})
+	p.HTTPOrder = gosettings.DefaultComparable(p.HTTPOrder, string(http.OrderCycle))
 	p.DNSEnabled = gosettings.DefaultPointer(p.DNSEnabled, true)
 	p.DNSProviders = gosettings.DefaultSlice(p.DNSProviders, []string{all})
 	const defaultDNSTimeout = 3 * time.Second
 	p.DNSTimeout = gosettings.DefaultComparable(p.DNSTimeout, defaultDNSTimeout)
+	const defaultCacheTTL = 5 * time.Minute
+	p.CacheTTL = gosettings.DefaultPointer(p.CacheTTL, defaultCacheTTL)
 }
 
+var (
+	ErrStrategyNeedsTwoFetchers   = errors.New("strategy needs at least two fetchers enabled")
+	ErrInterfaceNameNotSet        = errors.New("interface name is not set")
+	ErrInterfaceIPVersionNotValid = errors.New("interface IP version is not valid")
+)
+
 func (p PubIP) Validate() (err error) {
+	err = publicip.ValidateStrategy(publicip.Strategy(p.Strategy))
+	if err != nil {
+		return fmt.Errorf("strategy: %w", err)
+	}
+
+	enabledFetchers := 0
+	for _, enabled := range []bool{*p.InterfaceEnabled, *p.STUNEnabled, *p.HTTPEnabled, *p.DNSEnabled} {
+		if enabled {
+			enabledFetchers++
+		}
+	}
+	const minAllAgreeFetchers = 2
+	if p.Strategy == string(publicip.StrategyAllAgree) && enabledFetchers < minAllAgreeFetchers {
+		return fmt.Errorf("%w: %s", ErrStrategyNeedsTwoFetchers, p.Strategy)
+	}
+
+	err = p.validateInterface()
+	if err != nil {
+		return fmt.Errorf("interface: %w", err)
+	}
+
+	err = p.validateSTUNServers()
+	if err != nil {
+		return fmt.Errorf("STUN servers: %w", err)
+	}
+
 	err = p.validateHTTPIPProviders()
 	if err != nil {
 		return fmt.Errorf("HTTP IP providers: %w", err)
@@ -53,6 +108,11 @@ func (p PubIP) Validate() (err error) {
 		return fmt.Errorf("HTTP IPv6 providers: %w", err)
 	}
 
+	err = http.ValidateOrder(http.Order(p.HTTPOrder))
+	if err != nil {
+		return fmt.Errorf("HTTP order: %w", err)
+	}
+
 	err = p.validateDNSProviders()
 	if err != nil {
 		return fmt.Errorf("DNS providers: %w", err)
@@ -68,6 +128,32 @@ func (p *PubIP) String() string {
 func (p *PubIP) toLinesNode() (node *gotree.Node) {
 	node = gotree.New("Public IP fetching")
 
+	node.Appendf("Strategy: %s", p.Strategy)
+
+	if *p.CacheTTL == 0 {
+		node.Appendf("Cache: disabled")
+	} else {
+		node.Appendf("Cache TTL: %s", *p.CacheTTL)
+	}
+
+	node.Appendf("Interface enabled: %s", gosettings.BoolToYesNo(p.InterfaceEnabled))
+	if *p.InterfaceEnabled {
+		node.Appendf("Interface name: %s", p.InterfaceName)
+		node.Appendf("Interface IP version: %s", p.InterfaceIPVersion)
+	}
+
+	if p.IPv6PrefixInterface != "" {
+		node.Appendf("IPv6 prefix interface: %s", p.IPv6PrefixInterface)
+	}
+
+	node.Appendf("STUN enabled: %s", gosettings.BoolToYesNo(p.STUNEnabled))
+	if *p.STUNEnabled {
+		childNode := node.Appendf("STUN servers")
+		for _, server := range p.STUNServers {
+			childNode.Appendf(server)
+		}
+	}
+
 	node.Appendf("HTTP enabled: %s", gosettings.BoolToYesNo(p.HTTPEnabled))
 	if *p.HTTPEnabled {
 		childNode := node.Appendf("HTTP IP providers")
@@ -84,6 +170,8 @@ func (p *PubIP) toLinesNode() (node *gotree.Node) {
 		for _, provider := range p.HTTPIPv6Providers {
 			childNode.Appendf(provider)
 		}
+
+		node.Appendf("HTTP providers order: %s", p.HTTPOrder)
 	}
 
 	node.Appendf("DNS enabled: %s", gosettings.BoolToYesNo(p.DNSEnabled))
@@ -98,6 +186,29 @@ func (p *PubIP) toLinesNode() (node *gotree.Node) {
 	return node
 }
 
+// ToInterfaceOptions assumes the settings have been validated.
+func (p *PubIP) ToInterfaceOptions() (options []iface.Option) {
+	ipVersion, _ := ipversion.Parse(p.InterfaceIPVersion)
+	return []iface.Option{
+		iface.SetName(p.InterfaceName),
+		iface.SetIPVersion(ipVersion),
+	}
+}
+
+// ToIPv6PrefixOptions assumes the settings have been validated.
+func (p *PubIP) ToIPv6PrefixOptions() (options []iface.Option) {
+	return []iface.Option{
+		iface.SetName(p.IPv6PrefixInterface),
+	}
+}
+
+// ToSTUNOptions assumes the settings have been validated.
+func (p *PubIP) ToSTUNOptions() (options []stun.Option) {
+	return []stun.Option{
+		stun.SetServers(p.STUNServers[0], p.STUNServers[1:]...),
+	}
+}
+
 // ToHTTPOptions assumes the settings have been validated.
 func (p *PubIP) ToHTTPOptions() (options []http.Option) {
 	httpIPProviders := stringsToHTTPProviders(p.HTTPIPProviders, ipversion.IP4or6)
@@ -107,29 +218,36 @@ func (p *PubIP) ToHTTPOptions() (options []http.Option) {
 		http.SetProvidersIP(httpIPProviders[0], httpIPProviders[1:]...),
 		http.SetProvidersIP4(httpIPv4Providers[0], httpIPv4Providers[1:]...),
 		http.SetProvidersIP6(httpIPv6Providers[0], httpIPv6Providers[1:]...),
+		http.SetOrder(http.Order(p.HTTPOrder)),
 	}
 }
 
 func stringsToHTTPProviders(providers []string, ipVersion ipversion.IPVersion) (
 	updatedProviders []http.Provider) {
-	updatedProvidersSet := make(map[string]struct{}, len(providers))
+	// Keep the order of the providers for the sequential order.
+	updatedProvidersSet := make(map[http.Provider]struct{}, len(providers))
+	updatedProviders = make([]http.Provider, 0, len(providers))
+	add := func(provider http.Provider) {
+		_, exists := updatedProvidersSet[provider]
+		if exists {
+			return
+		}
+		updatedProvidersSet[provider] = struct{}{}
+		updatedProviders = append(updatedProviders, provider)
+	}
+
 	for _, provider := range providers {
 		if provider != all {
-			updatedProvidersSet[provider] = struct{}{}
+			add(http.Provider(provider))
 			continue
 		}
 
 		allProviders := http.ListProvidersForVersion(ipVersion)
 		for _, provider := range allProviders {
-			updatedProvidersSet[string(provider)] = struct{}{}
+			add(provider)
 		}
 	}
 
-	updatedProviders = make([]http.Provider, 0, len(updatedProvidersSet))
-	for provider := range updatedProvidersSet {
-		updatedProviders = append(updatedProviders, http.Provider(provider))
-	}
-
 	return updatedProviders
 }
 
@@ -162,6 +280,43 @@ var (
 	ErrNoPublicIPDNSProvider = errors.New("no public IP DNS provider specified")
 )
 
+func (p PubIP) validateInterface() (err error) {
+	if !*p.InterfaceEnabled {
+		return nil
+	}
+
+	if p.InterfaceName == "" {
+		return fmt.Errorf("%w", ErrInterfaceNameNotSet)
+	}
+
+	ipVersion, err := ipversion.Parse(p.InterfaceIPVersion)
+	if err != nil || ipVersion == ipversion.IP4and6 {
+		return fmt.Errorf("%w: %s", ErrInterfaceIPVersionNotValid, p.InterfaceIPVersion)
+	}
+
+	return nil
+}
+
+var ErrNoSTUNServer = errors.New("no STUN server specified")
+
+func (p PubIP) validateSTUNServers() (err error) {
+	if !*p.STUNEnabled {
+		return nil
+	}
+
+	if len(p.STUNServers) == 0 {
+		return fmt.Errorf("%w", ErrNoSTUNServer)
+	}
+
+	for _, server := range p.STUNServers {
+		_, _, err = net.SplitHostPort(server)
+		if err != nil {
+			return fmt.Errorf("STUN server %q: %w", server, err)
+		}
+	}
+	return nil
+}
+
 func (p PubIP) validateDNSProviders() (err error) {
 	if len(p.DNSProviders) == 0 {
 		return fmt.Errorf("%w", ErrNoPublicIPDNSProvider)
@@ -234,11 +389,18 @@ func validateHTTPIPProviders(providerStrings []string,
 }
 
 func (p *PubIP) read(r *reader.Reader, warner Warner) (err error) {
-	p.HTTPEnabled, p.DNSEnabled, err = getFetchers(r)
+	p.InterfaceEnabled, p.STUNEnabled, p.HTTPEnabled, p.DNSEnabled, err = getFetchers(r)
 	if err != nil {
 		return err
 	}
 
+	p.InterfaceName = r.String("PUBLICIP_INTERFACE")
+	p.InterfaceIPVersion = r.String("PUBLICIP_INTERFACE_IP_VERSION")
+	p.IPv6PrefixInterface = r.String("PUBLICIP_IPV6_PREFIX_INTERFACE")
+	p.STUNServers = r.CSV("PUBLICIP_STUN_SERVERS")
+
+	p.Strategy = r.String("PUBLICIP_STRATEGY")
+
 	p.HTTPIPProviders = r.CSV("PUBLICIP_HTTP_PROVIDERS",
 		reader.RetroKeys("IP_METHOD"))
 	p.HTTPIPv4Providers = r.CSV("PUBLICIPV4_HTTP_PROVIDERS",
@@ -269,20 +431,16 @@ func (p *PubIP) read(r *reader.Reader, warner Warner) (err error) {
 		}
 	}
 
+	p.HTTPOrder = r.String("PUBLICIP_HTTP_ORDER")
+
 	p.DNSProviders = r.CSV("PUBLICIP_DNS_PROVIDERS")
 
-	// Retro-compatibility
-	for i, provider := range p.DNSProviders {
-		if provider == "google" {
-			warner.Warnf("dns provider google will be ignored " +
-				"since it is no longer supported, " +
-				"see https://github.com/qdm12/ddns-updater/issues/492")
-			p.DNSProviders[i] = p.DNSProviders[len(p.DNSProviders)-1]
-			p.DNSProviders = p.DNSProviders[:len(p.DNSProviders)-1]
-		}
+	p.DNSTimeout, err = r.Duration("PUBLICIP_DNS_TIMEOUT")
+	if err != nil {
+		return err
 	}
 
-	p.DNSTimeout, err = r.Duration("PUBLICIP_DNS_TIMEOUT")
+	p.CacheTTL, err = r.DurationPtr("PUBLICIP_CACHE_TTL")
 	if err != nil {
 		return err
 	}
@@ -292,14 +450,14 @@ func (p *PubIP) read(r *reader.Reader, warner Warner) (err error) {
 
 var ErrFetcherNotValid = errors.New("fetcher is not valid")
 
-func getFetchers(reader *reader.Reader) (http, dns *bool, err error) {
+func getFetchers(reader *reader.Reader) (netInterface, stun, http, dns *bool, err error) {
 	// TODO change to use reader.BoolPtr with retro-compatibility
 	s := reader.String("PUBLICIP_FETCHERS")
 	if s == "" {
-		return nil, nil, nil
+		return nil, nil, nil, nil, nil
 	}
 
-	http, dns = new(bool), new(bool)
+	netInterface, stun, http, dns = new(bool), new(bool), new(bool), new(bool)
 	fields := strings.Split(s, ",")
 	for i, field := range fields {
 		switch strings.ToLower(field) {
@@ -310,14 +468,18 @@ func getFetchers(reader *reader.Reader) (http, dns *bool, err error) {
 			*http = true
 		case "dns":
 			*dns = true
+		case "interface":
+			*netInterface = true
+		case "stun":
+			*stun = true
 		default:
-			return nil, nil, fmt.Errorf(
+			return nil, nil, nil, nil, fmt.Errorf(
 				"%w: %q at position %d of %d",
 				ErrFetcherNotValid, field, i+1, len(fields))
 		}
 	}
 
-	return http, dns, nil
+	return netInterface, stun, http, dns, nil
 }
 
 func handleRetroProvider(provider string) (updatedProvider string) {
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gotree"
+)
+
+// Retry contains the settings to retry failed updates with
+// an exponential backoff. It is used for the program wide
+// settings, and for the settings overrides of each record.
+type Retry struct {
+	MaxAttempts *uint
+	BaseDelay   *time.Duration
+	MaxDelay    *time.Duration
+	Multiplier  *float64
+}
+
+func (r *Retry) setDefaults() {
+	const defaultMaxAttempts = 3
+	r.MaxAttempts = gosettings.DefaultPointer(r.MaxAttempts, defaultMaxAttempts)
+	const defaultBaseDelay = 5 * time.Second
+	r.BaseDelay = gosettings.DefaultPointer(r.BaseDelay, defaultBaseDelay)
+	const defaultMaxDelay = time.Minute
+	r.MaxDelay = gosettings.DefaultPointer(r.MaxDelay, defaultMaxDelay)
+	const defaultMultiplier = 2
+	r.Multiplier = gosettings.DefaultPointer(r.Multiplier, defaultMultiplier)
+}
+
+// OverrideWith returns a copy of the retry settings where each field
+// set in other overrides the corresponding field.
+func (r Retry) OverrideWith(other Retry) (result Retry) {
+	result.MaxAttempts = gosettings.OverrideWithPointer(r.MaxAttempts, other.MaxAttempts)
+	result.BaseDelay = gosettings.OverrideWithPointer(r.BaseDelay, other.BaseDelay)
+	result.MaxDelay = gosettings.OverrideWithPointer(r.MaxDelay, other.MaxDelay)
+	result.Multiplier = gosettings.OverrideWithPointer(r.Multiplier, other.Multiplier)
+	return result
+}
+
+var (
+	ErrRetryMaxAttemptsZero = errors.New("retry maximum attempts cannot be zero")
+	ErrRetryDelayNegative   = errors.New("retry delay cannot be negative")
+	ErrRetryDelayRange      = errors.New("retry maximum delay is lower than the base delay")
+	ErrRetryMultiplierLow   = errors.New("retry multiplier cannot be lower than 1")
+)
+
+func (r Retry) Validate() (err error) {
+	switch {
+	case r.MaxAttempts != nil && *r.MaxAttempts == 0:
+		return fmt.Errorf("%w", ErrRetryMaxAttemptsZero)
+	case r.BaseDelay != nil && *r.BaseDelay < 0:
+		return fmt.Errorf("%w: base delay %s", ErrRetryDelayNegative, *r.BaseDelay)
+	case r.MaxDelay != nil && *r.MaxDelay < 0:
+		return fmt.Errorf("%w: maximum delay %s", ErrRetryDelayNegative, *r.MaxDelay)
+	case r.BaseDelay != nil && r.MaxDelay != nil && *r.MaxDelay < *r.BaseDelay:
+		return fmt.Errorf("%w: maximum delay %s and base delay %s",
+			ErrRetryDelayRange, *r.MaxDelay, *r.BaseDelay)
+	case r.Multiplier != nil && *r.Multiplier < 1:
+		return fmt.Errorf("%w: %g", ErrRetryMultiplierLow, *r.Multiplier)
+	}
+	return nil
+}
+
+// ToSettings returns the retry settings to use for a record.
+// It must be called on defaulted settings.
+func (r Retry) ToSettings() models.RetrySettings {
+	return models.RetrySettings{
+		MaxAttempts: *r.MaxAttempts,
+		BaseDelay:   *r.BaseDelay,
+		MaxDelay:    *r.MaxDelay,
+		Multiplier:  *r.Multiplier,
+	}
+}
+
+func (r Retry) String() string {
+	return r.toLinesNode().String()
+}
+
+func (r Retry) toLinesNode() *gotree.Node {
+	if *r.MaxAttempts == 1 {
+		return gotree.New("Retry: disabled")
+	}
+	node := gotree.New("Retry")
+	node.Appendf("Maximum attempts: %d", *r.MaxAttempts)
+	node.Appendf("Base delay: %s", *r.BaseDelay)
+	node.Appendf("Maximum delay: %s", *r.MaxDelay)
+	node.Appendf("Multiplier: %g", *r.Multiplier)
+	return node
+}
+
+func (r *Retry) read(reader *reader.Reader) (err error) {
+	r.MaxAttempts, err = reader.UintPtr("UPDATE_RETRY_MAX_ATTEMPTS")
+	if err != nil {
+		return err
+	}
+
+	r.BaseDelay, err = reader.DurationPtr("UPDATE_RETRY_BASE_DELAY")
+	if err != nil {
+		return err
+	}
+
+	r.MaxDelay, err = reader.DurationPtr("UPDATE_RETRY_MAX_DELAY")
+	if err != nil {
+		return err
+	}
+
+	r.Multiplier, err = reader.Float64Ptr("UPDATE_RETRY_MULTIPLIER")
+	return err
+}
@@ -16,8 +16,15 @@ type Config struct {
 	Health   Health
 	Paths    Paths
 	Backup   Backup
+	Shutdown Shutdown
+	History  History
 	Logger   Logger
 	Shoutrrr Shoutrrr
+	Webhook  Webhook
+	Telegram Telegram
+	Discord  Discord
+	SMTP     SMTP
+	Gotify   Gotify
 }
 
 func (c *Config) SetDefaults() {
@@ -29,8 +36,15 @@ func (c *Config) SetDefaults() {
 	c.Health.SetDefaults()
 	c.Paths.setDefaults()
 	c.Backup.setDefaults()
+	c.Shutdown.SetDefaults()
+	c.History.SetDefaults()
 	c.Logger.setDefaults()
 	c.Shoutrrr.setDefaults()
+	c.Webhook.setDefaults()
+	c.Telegram.setDefaults()
+	c.Discord.setDefaults()
+	c.SMTP.setDefaults()
+	c.Gotify.setDefaults()
 }
 
 func (c Config) Validate() (err error) {
@@ -46,8 +60,15 @@ func (c Config) Validate() (err error) {
 		"health":    &c.Health,
 		"paths":     &c.Paths,
 		"backup":    &c.Backup,
+		"shutdown":  &c.Shutdown,
+		"history":   &c.History,
 		"logger":    &c.Logger,
 		"shoutrrr":  &c.Shoutrrr,
+		"webhook":   &c.Webhook,
+		"telegram":  &c.Telegram,
+		"discord":   &c.Discord,
+		"smtp":      &c.SMTP,
+		"gotify":    &c.Gotify,
 	}
 
 	for name, v := range toValidate {
@@ -74,8 +95,15 @@ func (c Config) toLinesNode() *gotree.Node {
 	node.AppendNode(c.Health.toLinesNode())
 	node.AppendNode(c.Paths.toLinesNode())
 	node.AppendNode(c.Backup.toLinesNode())
+	node.AppendNode(c.Shutdown.toLinesNode())
+	node.AppendNode(c.History.toLinesNode())
 	node.AppendNode(c.Logger.toLinesNode())
 	node.AppendNode(c.Shoutrrr.ToLinesNode())
+	node.AppendNode(c.Webhook.toLinesNode())
+	node.AppendNode(c.Telegram.toLinesNode())
+	node.AppendNode(c.Discord.toLinesNode())
+	node.AppendNode(c.SMTP.toLinesNode())
+	node.AppendNode(c.Gotify.toLinesNode())
 	return node
 }
 
@@ -114,6 +142,16 @@ func (c *Config) Read(reader *reader.Reader,
 		return fmt.Errorf("reading backup settings: %w", err)
 	}
 
+	err = c.Shutdown.Read(reader)
+	if err != nil {
+		return fmt.Errorf("reading shutdown settings: %w", err)
+	}
+
+	err = c.History.Read(reader)
+	if err != nil {
+		return fmt.Errorf("reading history settings: %w", err)
+	}
+
 	c.Logger.read(reader)
 
 	err = c.Shoutrrr.read(reader, warner)
@@ -121,5 +159,19 @@ func (c *Config) Read(reader *reader.Reader,
 		return fmt.Errorf("reading shoutrrr settings: %w", err)
 	}
 
+	c.Webhook.read(reader)
+	c.Telegram.read(reader)
+	c.Discord.read(reader)
+
+	err = c.SMTP.read(reader)
+	if err != nil {
+		return fmt.Errorf("reading SMTP settings: %w", err)
+	}
+
+	err = c.Gotify.read(reader)
+	if err != nil {
+		return fmt.Errorf("reading gotify settings: %w", err)
+	}
+
 	return nil
 }
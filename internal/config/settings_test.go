@@ -19,8 +19,27 @@ func Test_Settings_String(t *testing.T) {
 |   └── Timeout: 20s
 ├── Update
 |   ├── Period: 10m0s
-|   └── Cooldown: 5m0s
+|   ├── Cooldown: 5m0s
+|   ├── HTTP timeout: 1m0s
+|   ├── Jitter: disabled
+|   ├── Dry run: no
+|   ├── Config reload period: 10s
+|   ├── Update on start: yes
+|   ├── Skip unchanged: no
+|   ├── Retry
+|   |   ├── Maximum attempts: 3
+|   |   ├── Base delay: 5s
+|   |   ├── Maximum delay: 1m0s
+|   |   └── Multiplier: 2
+|   └── Failure backoff
+|       ├── Consecutive failures: 3
+|       ├── Multiplier: 2
+|       └── Maximum interval: 6h0m0s
 ├── Public IP fetching
+|   ├── Strategy: cycle
+|   ├── Cache TTL: 5m0s
+|   ├── Interface enabled: no
+|   ├── STUN enabled: no
 |   ├── HTTP enabled: yes
 |   ├── HTTP IP providers
 |   |   └── all
@@ -28,6 +47,7 @@ func Test_Settings_String(t *testing.T) {
 |   |   └── all
 |   ├── HTTP IPv6 providers
 |   |   └── all
+|   ├── HTTP providers order: cycle
 |   ├── DNS enabled: yes
 |   ├── DNS timeout: 3s
 |   └── DNS over TLS providers
@@ -41,8 +61,13 @@ func Test_Settings_String(t *testing.T) {
 ├── Paths
 |   └── Data directory: ./data
 ├── Backup: disabled
+├── Shutdown
+|   ├── Grace period: 5s
+|   └── Timeout: 10s
+├── History store: disabled
 └── Logger
     ├── Level: INFO
-    └── Caller: hidden`
+    ├── Caller: hidden
+    └── Format: text`
 	assert.Equal(t, expected, s)
 }
@@ -2,8 +2,6 @@ package config
 
 import (
 	"fmt"
-	"net/url"
-	"path"
 
 	"github.com/containrrr/shoutrrr"
 	"github.com/qdm12/gosettings"
@@ -22,9 +20,12 @@ func (s *Shoutrrr) setDefaults() {
 }
 
 func (s Shoutrrr) Validate() (err error) {
-	_, err = shoutrrr.CreateSender(s.Addresses...)
-	if err != nil {
-		return fmt.Errorf("shoutrrr addresses: %w", err)
+	// Validate each address separately to report which one is malformed.
+	for i, address := range s.Addresses {
+		_, err = shoutrrr.CreateSender(address)
+		if err != nil {
+			return fmt.Errorf("shoutrrr address %d of %d: %w", i+1, len(s.Addresses), err)
+		}
 	}
 	return nil
 }
@@ -52,21 +53,6 @@ func (s Shoutrrr) ToLinesNode() *gotree.Node {
 func (s *Shoutrrr) read(r *reader.Reader, warner Warner) (err error) {
 	s.Addresses = r.CSV("SHOUTRRR_ADDRESSES", reader.ForceLowercase(false))
 
-	// Retro-compatibility: GOTIFY_URL and GOTIFY_TOKEN
-	gotifyURLString := r.Get("GOTIFY_URL", reader.ForceLowercase(false))
-	if gotifyURLString != nil {
-		handleDeprecated(warner, "GOTIFY_URL", "SHOUTRRR_ADDRESSES")
-		gotifyURL, err := url.Parse(*gotifyURLString)
-		if err != nil {
-			return fmt.Errorf("gotify URL: %w", err)
-		}
-
-		gotifyToken := r.String("GOTIFY_TOKEN", reader.ForceLowercase(false))
-		handleDeprecated(warner, "GOTIFY_TOKEN", "SHOUTRRR_ADDRESSES")
-		gotifyShoutrrrAddress := gotifyURLTokenToShoutrrr(gotifyURL, gotifyToken)
-		s.Addresses = append(s.Addresses, gotifyShoutrrrAddress)
-	}
-
 	// Retro-compatibility
 	shoutrrrParamsCSV := r.Get("SHOUTRRR_PARAMS")
 	if shoutrrrParamsCSV != nil {
@@ -76,12 +62,3 @@ func (s *Shoutrrr) read(r *reader.Reader, warner Warner) (err error) {
 	s.DefaultTitle = r.String("SHOUTRRR_DEFAULT_TITLE", reader.ForceLowercase(false))
 	return nil
 }
-
-func gotifyURLTokenToShoutrrr(url *url.URL, token string) (address string) {
-	hostAndPath := path.Join(url.Host, url.Path)
-	address = "gotify://" + hostAndPath + "/" + token
-	if url.Scheme == "http" {
-		address += "?DisableTLS=Yes"
-	}
-	return address
-}
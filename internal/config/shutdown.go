@@ -0,0 +1,69 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gotree"
+)
+
+type Shutdown struct {
+	// GracePeriod is the duration given to in-flight record updates
+	// to complete on shutdown, after which they are canceled.
+	// It cannot be nil in the internal state.
+	GracePeriod *time.Duration
+	// Timeout is the maximum duration of the program shutdown,
+	// after which the program exits anyway. It cannot be nil
+	// in the internal state.
+	Timeout *time.Duration
+}
+
+func (s *Shutdown) SetDefaults() {
+	const defaultGracePeriod = 5 * time.Second
+	s.GracePeriod = gosettings.DefaultPointer(s.GracePeriod, defaultGracePeriod)
+	const defaultTimeout = 10 * time.Second
+	s.Timeout = gosettings.DefaultPointer(s.Timeout, defaultTimeout)
+}
+
+var (
+	ErrShutdownGracePeriodNegative = errors.New("shutdown grace period cannot be negative")
+	ErrShutdownTimeoutTooShort     = errors.New("shutdown timeout must be longer than the grace period")
+)
+
+func (s Shutdown) Validate() (err error) {
+	if *s.GracePeriod < 0 {
+		return fmt.Errorf("%w: %s", ErrShutdownGracePeriodNegative, *s.GracePeriod)
+	} else if *s.Timeout <= *s.GracePeriod {
+		return fmt.Errorf("%w: timeout %s is not longer than grace period %s",
+			ErrShutdownTimeoutTooShort, *s.Timeout, *s.GracePeriod)
+	}
+	return nil
+}
+
+func (s Shutdown) String() string {
+	return s.toLinesNode().String()
+}
+
+func (s Shutdown) toLinesNode() *gotree.Node {
+	node := gotree.New("Shutdown")
+	node.Appendf("Grace period: %s", *s.GracePeriod)
+	node.Appendf("Timeout: %s", *s.Timeout)
+	return node
+}
+
+func (s *Shutdown) Read(reader *reader.Reader) (err error) {
+	s.GracePeriod, err = reader.DurationPtr("SHUTDOWN_GRACE_PERIOD")
+	if err != nil {
+		return err
+	}
+
+	s.Timeout, err = reader.DurationPtr("SHUTDOWN_TIMEOUT")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"text/template"
+	"time"
+
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gosettings/validate"
+	"github.com/qdm12/gotree"
+)
+
+type SMTP struct {
+	Host     *string
+	Port     *uint16
+	Username *string
+	Password *string
+	From     *string
+	To       []string
+	// Security can be "auto", "starttls" or "tls". The "auto" value
+	// uses implicit TLS for port 465, and STARTTLS otherwise.
+	Security        string
+	NotifyFailures  *bool
+	SubjectTemplate string
+	BodyTemplate    string
+	Timeout         time.Duration
+}
+
+const (
+	smtpSecurityAuto     = "auto"
+	smtpSecuritySTARTTLS = "starttls"
+	smtpSecurityTLS      = "tls"
+	smtpImplicitTLSPort  = 465
+)
+
+func (s *SMTP) setDefaults() {
+	s.Host = gosettings.DefaultPointer(s.Host, "")
+	const defaultPort = 587
+	s.Port = gosettings.DefaultPointer(s.Port, defaultPort)
+	s.Username = gosettings.DefaultPointer(s.Username, "")
+	s.Password = gosettings.DefaultPointer(s.Password, "")
+	s.From = gosettings.DefaultPointer(s.From, "")
+	s.To = gosettings.DefaultSlice(s.To, []string{})
+	s.Security = gosettings.DefaultComparable(s.Security, smtpSecurityAuto)
+	s.NotifyFailures = gosettings.DefaultPointer(s.NotifyFailures, false)
+	s.SubjectTemplate = gosettings.DefaultComparable(s.SubjectTemplate,
+		`DDNS Updater: {{.FQDN}} {{if .Failed}}update failed{{else}}changed to {{.NewIP}}{{end}}`)
+	s.BodyTemplate = gosettings.DefaultComparable(s.BodyTemplate, `{{.Message}}`)
+	const defaultTimeout = 10 * time.Second
+	s.Timeout = gosettings.DefaultComparable(s.Timeout, defaultTimeout)
+}
+
+var (
+	ErrSMTPFromNotSet = errors.New("SMTP from address is not set")
+	ErrSMTPToNotSet   = errors.New("SMTP to address is not set")
+	ErrSMTPTimeoutLow = errors.New("SMTP timeout is too low")
+)
+
+func (s SMTP) Validate() (err error) {
+	if !s.Enabled() {
+		return nil
+	}
+
+	err = validate.IsOneOf(s.Security, smtpSecurityAuto, smtpSecuritySTARTTLS, smtpSecurityTLS)
+	if err != nil {
+		return fmt.Errorf("security: %w", err)
+	}
+
+	if *s.From == "" {
+		return fmt.Errorf("%w", ErrSMTPFromNotSet)
+	}
+	_, err = mail.ParseAddress(*s.From)
+	if err != nil {
+		return fmt.Errorf("from address: %w", err)
+	}
+
+	if len(s.To) == 0 {
+		return fmt.Errorf("%w", ErrSMTPToNotSet)
+	}
+	for _, to := range s.To {
+		_, err = mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("to address: %w", err)
+		}
+	}
+
+	_, err = template.New("subject").Parse(s.SubjectTemplate)
+	if err != nil {
+		return fmt.Errorf("subject template: %w", err)
+	}
+	_, err = template.New("body").Parse(s.BodyTemplate)
+	if err != nil {
+		return fmt.Errorf("body template: %w", err)
+	}
+
+	if s.Timeout <= 0 {
+		return fmt.Errorf("%w: %s", ErrSMTPTimeoutLow, s.Timeout)
+	}
+
+	return nil
+}
+
+// Enabled returns true if email notifications are enabled.
+func (s SMTP) Enabled() bool {
+	return *s.Host != ""
+}
+
+// ImplicitTLS returns true if the connection to the SMTP server
+// should use TLS directly instead of STARTTLS.
+func (s SMTP) ImplicitTLS() bool {
+	switch s.Security {
+	case smtpSecurityTLS:
+		return true
+	case smtpSecuritySTARTTLS:
+		return false
+	default:
+		return *s.Port == smtpImplicitTLSPort
+	}
+}
+
+func (s SMTP) String() string {
+	return s.toLinesNode().String()
+}
+
+func (s SMTP) toLinesNode() *gotree.Node {
+	if !s.Enabled() {
+		return nil // no host means email notifications are disabled
+	}
+
+	node := gotree.New("SMTP")
+	node.Appendf("Server address: %s:%d", *s.Host, *s.Port)
+	node.Appendf("Security: %s", s.Security)
+	if *s.Username != "" {
+		node.Appendf("Username: %s", *s.Username)
+		node.Appendf("Password: [set]")
+	}
+	node.Appendf("From: %s", *s.From)
+	toNode := node.Appendf("To")
+	for _, to := range s.To {
+		toNode.Appendf(to)
+	}
+	node.Appendf("Notify failures: %s", gosettings.BoolToYesNo(s.NotifyFailures))
+	node.Appendf("Subject template: %s", s.SubjectTemplate)
+	node.Appendf("Body template: %s", s.BodyTemplate)
+	node.Appendf("Timeout: %s", s.Timeout)
+	return node
+}
+
+func (s *SMTP) read(r *reader.Reader) (err error) {
+	s.Host = r.Get("SMTP_HOST")
+
+	s.Port, err = r.Uint16Ptr("SMTP_PORT")
+	if err != nil {
+		return err
+	}
+
+	s.Username = r.Get("SMTP_USERNAME", reader.ForceLowercase(false))
+	s.Password = r.Get("SMTP_PASSWORD", reader.ForceLowercase(false))
+	s.From = r.Get("SMTP_FROM", reader.ForceLowercase(false))
+	s.To = r.CSV("SMTP_TO", reader.ForceLowercase(false))
+	s.Security = r.String("SMTP_SECURITY")
+
+	s.NotifyFailures, err = r.BoolPtr("SMTP_NOTIFY_FAILURES")
+	if err != nil {
+		return err
+	}
+
+	s.SubjectTemplate = r.String("SMTP_SUBJECT_TEMPLATE", reader.ForceLowercase(false))
+	s.BodyTemplate = r.String("SMTP_BODY_TEMPLATE", reader.ForceLowercase(false))
+
+	s.Timeout, err = r.Duration("SMTP_TIMEOUT")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gotree"
+)
+
+type Telegram struct {
+	BotToken *string
+	ChatID   *string
+}
+
+func (t *Telegram) setDefaults() {
+	t.BotToken = gosettings.DefaultPointer(t.BotToken, "")
+	t.ChatID = gosettings.DefaultPointer(t.ChatID, "")
+}
+
+var (
+	ErrTelegramBotTokenNotSet = errors.New("telegram bot token is not set")
+	ErrTelegramChatIDNotSet   = errors.New("telegram chat id is not set")
+)
+
+func (t Telegram) Validate() (err error) {
+	switch {
+	case *t.BotToken == "" && *t.ChatID == "":
+		return nil
+	case *t.BotToken == "":
+		return fmt.Errorf("%w", ErrTelegramBotTokenNotSet)
+	case *t.ChatID == "":
+		return fmt.Errorf("%w", ErrTelegramChatIDNotSet)
+	}
+	return nil
+}
+
+// Enabled returns true if Telegram notifications are enabled.
+func (t Telegram) Enabled() bool {
+	return *t.BotToken != ""
+}
+
+func (t Telegram) String() string {
+	return t.toLinesNode().String()
+}
+
+func (t Telegram) toLinesNode() *gotree.Node {
+	if !t.Enabled() {
+		return nil // no bot token means telegram is disabled
+	}
+
+	node := gotree.New("Telegram")
+	node.Appendf("Bot token: [set]")
+	node.Appendf("Chat ID: %s", *t.ChatID)
+	return node
+}
+
+func (t *Telegram) read(r *reader.Reader) {
+	t.BotToken = r.Get("TELEGRAM_BOT_TOKEN", reader.ForceLowercase(false))
+	t.ChatID = r.Get("TELEGRAM_CHAT_ID", reader.ForceLowercase(false))
+}
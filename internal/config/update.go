@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -12,6 +14,35 @@ import (
 type Update struct {
 	Period   time.Duration
 	Cooldown time.Duration
+	// HTTPTimeout is the maximum duration of each provider update
+	// attempt, and can be zero to not limit it further than the
+	// HTTP client timeout. It cannot be nil in the internal state.
+	HTTPTimeout *time.Duration
+	// Jitter is the maximum random offset added to the scheduled
+	// time of each record update check, and is zero to disable it.
+	Jitter time.Duration
+	// DryRun is true to log the updates that would be done without
+	// calling the providers. It cannot be nil in the internal state.
+	DryRun *bool
+	// ConfigReloadPeriod is the period to check the JSON configuration
+	// file for changes and reload the records, and is zero to disable
+	// reloading. It cannot be nil in the internal state.
+	ConfigReloadPeriod *time.Duration
+	// OnStart is true to update records once at program start,
+	// even if skipping unchanged updates. It cannot be nil in the
+	// internal state.
+	OnStart *bool
+	// SkipUnchanged is true to not update records whose last update
+	// succeeded with the same public IP addresses, until ForceInterval
+	// elapses. It cannot be nil in the internal state.
+	SkipUnchanged *bool
+	// ForceInterval is the duration after the last successful update of
+	// a record after which it is updated even if its IP addresses did not
+	// change, and is zero to never force it. It cannot be nil in the
+	// internal state.
+	ForceInterval  *time.Duration
+	Retry          Retry
+	FailureBackoff FailureBackoff
 }
 
 func (u *Update) setDefaults() {
@@ -19,9 +50,47 @@ func (u *Update) setDefaults() {
 	u.Period = gosettings.DefaultComparable(u.Period, defaultPeriod)
 	const defaultCooldown = 5 * time.Minute
 	u.Cooldown = gosettings.DefaultComparable(u.Cooldown, defaultCooldown)
+	const defaultHTTPTimeout = time.Minute
+	u.HTTPTimeout = gosettings.DefaultPointer(u.HTTPTimeout, defaultHTTPTimeout)
+	u.DryRun = gosettings.DefaultPointer(u.DryRun, false)
+	const defaultConfigReloadPeriod = 10 * time.Second
+	u.ConfigReloadPeriod = gosettings.DefaultPointer(u.ConfigReloadPeriod, defaultConfigReloadPeriod)
+	u.OnStart = gosettings.DefaultPointer(u.OnStart, true)
+	u.SkipUnchanged = gosettings.DefaultPointer(u.SkipUnchanged, false)
+	const defaultForceInterval = 24 * time.Hour
+	u.ForceInterval = gosettings.DefaultPointer(u.ForceInterval, defaultForceInterval)
+	u.Retry.setDefaults()
+	u.FailureBackoff.setDefaults()
 }
 
+var (
+	ErrJitterNegative             = errors.New("jitter cannot be negative")
+	ErrConfigReloadPeriodNegative = errors.New("config reload period cannot be negative")
+	ErrForceIntervalNegative      = errors.New("force interval cannot be negative")
+)
+
 func (u Update) Validate() (err error) {
+	if u.Jitter < 0 {
+		return fmt.Errorf("%w: %s", ErrJitterNegative, u.Jitter)
+	}
+
+	if *u.ConfigReloadPeriod < 0 {
+		return fmt.Errorf("%w: %s", ErrConfigReloadPeriodNegative, *u.ConfigReloadPeriod)
+	}
+
+	if *u.ForceInterval < 0 {
+		return fmt.Errorf("%w: %s", ErrForceIntervalNegative, *u.ForceInterval)
+	}
+
+	err = u.Retry.Validate()
+	if err != nil {
+		return fmt.Errorf("retry: %w", err)
+	}
+
+	err = u.FailureBackoff.Validate()
+	if err != nil {
+		return fmt.Errorf("failure backoff: %w", err)
+	}
 	return nil
 }
 
@@ -33,6 +102,33 @@ func (u Update) toLinesNode() *gotree.Node {
 	node := gotree.New("Update")
 	node.Appendf("Period: %s", u.Period)
 	node.Appendf("Cooldown: %s", u.Cooldown)
+	if *u.HTTPTimeout == 0 {
+		node.Appendf("HTTP timeout: none")
+	} else {
+		node.Appendf("HTTP timeout: %s", *u.HTTPTimeout)
+	}
+	if u.Jitter == 0 {
+		node.Appendf("Jitter: disabled")
+	} else {
+		node.Appendf("Jitter: %s", u.Jitter)
+	}
+	node.Appendf("Dry run: %s", gosettings.BoolToYesNo(u.DryRun))
+	if *u.ConfigReloadPeriod == 0 {
+		node.Appendf("Config reload: disabled")
+	} else {
+		node.Appendf("Config reload period: %s", *u.ConfigReloadPeriod)
+	}
+	node.Appendf("Update on start: %s", gosettings.BoolToYesNo(u.OnStart))
+	switch {
+	case !*u.SkipUnchanged:
+		node.Appendf("Skip unchanged: no")
+	case *u.ForceInterval == 0:
+		node.Appendf("Skip unchanged: yes, never forced")
+	default:
+		node.Appendf("Skip unchanged: yes, forced every %s", *u.ForceInterval)
+	}
+	node.AppendNode(u.Retry.toLinesNode())
+	node.AppendNode(u.FailureBackoff.toLinesNode())
 	return node
 }
 
@@ -43,7 +139,51 @@ func (u *Update) read(reader *reader.Reader, warner Warner) (err error) {
 	}
 
 	u.Cooldown, err = reader.Duration("UPDATE_COOLDOWN_PERIOD")
-	return err
+	if err != nil {
+		return err
+	}
+
+	u.HTTPTimeout, err = reader.DurationPtr("UPDATE_HTTP_TIMEOUT")
+	if err != nil {
+		return err
+	}
+
+	u.Jitter, err = reader.Duration("UPDATE_JITTER")
+	if err != nil {
+		return err
+	}
+
+	u.DryRun, err = reader.BoolPtr("DRY_RUN")
+	if err != nil {
+		return err
+	}
+
+	u.ConfigReloadPeriod, err = reader.DurationPtr("CONFIG_RELOAD_PERIOD")
+	if err != nil {
+		return err
+	}
+
+	u.OnStart, err = reader.BoolPtr("UPDATE_ON_START")
+	if err != nil {
+		return err
+	}
+
+	u.SkipUnchanged, err = reader.BoolPtr("UPDATE_SKIP_UNCHANGED")
+	if err != nil {
+		return err
+	}
+
+	u.ForceInterval, err = reader.DurationPtr("UPDATE_FORCE_INTERVAL")
+	if err != nil {
+		return err
+	}
+
+	err = u.Retry.read(reader)
+	if err != nil {
+		return err
+	}
+
+	return u.FailureBackoff.read(reader)
 }
 
 func readUpdatePeriod(r *reader.Reader, warner Warner) (period time.Duration, err error) {
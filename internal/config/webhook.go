@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/qdm12/gosettings"
+	"github.com/qdm12/gosettings/reader"
+	"github.com/qdm12/gotree"
+)
+
+type Webhook struct {
+	URL *string
+	// Headers are custom headers to set on the webhook request,
+	// each in the format "Name: value".
+	Headers []string
+}
+
+func (w *Webhook) setDefaults() {
+	w.URL = gosettings.DefaultPointer(w.URL, "")
+	w.Headers = gosettings.DefaultSlice(w.Headers, []string{})
+}
+
+var (
+	ErrWebhookURLSchemeNotValid = errors.New("webhook URL scheme is not valid")
+	ErrWebhookHeaderNotValid    = errors.New("webhook header is not valid")
+)
+
+func (w Webhook) Validate() (err error) {
+	if *w.URL == "" {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(*w.URL)
+	if err != nil {
+		return fmt.Errorf("webhook URL: %w", err)
+	} else if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("%w: %s", ErrWebhookURLSchemeNotValid, parsedURL.Scheme)
+	}
+
+	for _, header := range w.Headers {
+		name, _, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: %s", ErrWebhookHeaderNotValid, header)
+		}
+	}
+
+	return nil
+}
+
+// HeadersMap returns the custom headers as a map of header
+// name to header value.
+func (w Webhook) HeadersMap() (headers map[string]string) {
+	headers = make(map[string]string, len(w.Headers))
+	for _, header := range w.Headers {
+		name, value, _ := strings.Cut(header, ":")
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+func (w Webhook) String() string {
+	return w.toLinesNode().String()
+}
+
+func (w Webhook) toLinesNode() *gotree.Node {
+	if *w.URL == "" {
+		return nil // no URL means the webhook is disabled
+	}
+
+	node := gotree.New("Webhook")
+	node.Appendf("URL: %s", *w.URL)
+	if len(w.Headers) > 0 {
+		headersNode := node.Appendf("Headers")
+		for _, header := range w.Headers {
+			name, _, _ := strings.Cut(header, ":")
+			headersNode.Appendf("%s: [set]", strings.TrimSpace(name))
+		}
+	}
+	return node
+}
+
+func (w *Webhook) read(r *reader.Reader) {
+	w.URL = r.Get("WEBHOOK_URL", reader.ForceLowercase(false))
+	w.Headers = r.CSV("WEBHOOK_HEADERS", reader.ForceLowercase(false))
+}
@@ -0,0 +1,176 @@
+// Package cron parses standard 5 fields cron expressions and computes
+// their next fire times.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	spec     string
+	minutes  field
+	hours    field
+	days     field
+	months   field
+	weekdays field
+	// daysRestricted and weekdaysRestricted are used to apply the
+	// cron rule where, if both are restricted, either can match.
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+// field is a bit set of the values allowed for a cron field.
+type field uint64
+
+func (f field) has(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+var (
+	ErrFieldsCount  = errors.New("cron expression must have 5 fields")
+	ErrValueSyntax  = errors.New("cron value syntax is not valid")
+	ErrValueRange   = errors.New("cron value is out of range")
+	ErrNeverMatches = errors.New("cron expression never matches")
+)
+
+// Parse parses a cron expression with the 5 fields minute, hour,
+// day of month, month and day of week. Each field can be `*`, a
+// number, a range such as `1-5`, a list such as `1,3,5` and have
+// a step such as `*/15` or `8-18/2`. Day of week 0 and 7 are Sunday.
+func Parse(spec string) (schedule *Schedule, err error) {
+	fields := strings.Fields(spec)
+	const fieldsCount = 5
+	if len(fields) != fieldsCount {
+		return nil, fmt.Errorf("%w: %q has %d fields", ErrFieldsCount, spec, len(fields))
+	}
+
+	schedule = &Schedule{spec: spec}
+	parsers := []struct {
+		name     string
+		min, max int
+		result   *field
+	}{
+		{name: "minute", min: 0, max: 59, result: &schedule.minutes},
+		{name: "hour", min: 0, max: 23, result: &schedule.hours},
+		{name: "day of month", min: 1, max: 31, result: &schedule.days},
+		{name: "month", min: 1, max: 12, result: &schedule.months},
+		{name: "day of week", min: 0, max: 7, result: &schedule.weekdays},
+	}
+	for i, parser := range parsers {
+		*parser.result, err = parseField(fields[i], parser.min, parser.max)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s field: %w", parser.name, err)
+		}
+	}
+
+	if schedule.weekdays.has(7) { // Sunday as 7
+		schedule.weekdays |= 1
+	}
+	schedule.daysRestricted = fields[2] != "*"
+	schedule.weekdaysRestricted = fields[4] != "*"
+
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("%w: %s", ErrNeverMatches, spec)
+	}
+
+	return schedule, nil
+}
+
+func parseField(s string, minimum, maximum int) (result field, err error) {
+	for _, part := range strings.Split(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: step %q", ErrValueSyntax, stepPart)
+			}
+		}
+
+		start, end := minimum, maximum
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			startString, endString, _ := strings.Cut(rangePart, "-")
+			start, err = parseValue(startString, minimum, maximum)
+			if err != nil {
+				return 0, err
+			}
+			end, err = parseValue(endString, minimum, maximum)
+			if err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("%w: range %q start is after its end", ErrValueSyntax, rangePart)
+			}
+		default:
+			start, err = parseValue(rangePart, minimum, maximum)
+			if err != nil {
+				return 0, err
+			}
+			end = start
+			if hasStep { // a/b means from a to the maximum
+				end = maximum
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			result |= 1 << uint(value)
+		}
+	}
+	return result, nil
+}
+
+func parseValue(s string, minimum, maximum int) (value int, err error) {
+	value, err = strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrValueSyntax, s)
+	}
+	if value < minimum || value > maximum {
+		return 0, fmt.Errorf("%w: %d must be between %d and %d",
+			ErrValueRange, value, minimum, maximum)
+	}
+	return value, nil
+}
+
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time strictly after the time given matching
+// the schedule, in the location of the time given. It returns the zero
+// time if no time matches within the next 5 years.
+func (s *Schedule) Next(after time.Time) (next time.Time) {
+	next = after.Truncate(time.Minute).Add(time.Minute)
+	const maxYears = 5
+	limit := next.AddDate(maxYears, 0, 0)
+	for next.Before(limit) {
+		switch {
+		case !s.months.has(int(next.Month())):
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+		case !s.dayMatches(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+		case !s.hours.has(next.Hour()):
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+		case !s.minutes.has(next.Minute()):
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dayMatch := s.days.has(t.Day())
+	weekdayMatch := s.weekdays.has(int(t.Weekday()))
+	if s.daysRestricted && s.weekdaysRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
@@ -0,0 +1,129 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		spec       string
+		errWrapped error
+		errMessage string
+	}{
+		"every_minute": {
+			spec: "* * * * *",
+		},
+		"lists_ranges_and_steps": {
+			spec: "*/15 8-18/2 1,15 * 1-5",
+		},
+		"too_few_fields": {
+			spec:       "* * * *",
+			errWrapped: ErrFieldsCount,
+			errMessage: `cron expression must have 5 fields: "* * * *" has 4 fields`,
+		},
+		"bad_value": {
+			spec:       "a * * * *",
+			errWrapped: ErrValueSyntax,
+			errMessage: `parsing minute field: cron value syntax is not valid: "a"`,
+		},
+		"out_of_range": {
+			spec:       "0 24 * * *",
+			errWrapped: ErrValueRange,
+			errMessage: "parsing hour field: cron value is out of range: 24 must be between 0 and 23",
+		},
+		"bad_step": {
+			spec:       "*/0 * * * *",
+			errWrapped: ErrValueSyntax,
+			errMessage: `parsing minute field: cron value syntax is not valid: step "0"`,
+		},
+		"reversed_range": {
+			spec:       "0 0 * 5-3 *",
+			errWrapped: ErrValueSyntax,
+			errMessage: `parsing month field: cron value syntax is not valid: range "5-3" start is after its end`,
+		},
+		"never_matches": {
+			spec:       "0 0 30 2 *",
+			errWrapped: ErrNeverMatches,
+			errMessage: "cron expression never matches: 0 0 30 2 *",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			schedule, err := Parse(testCase.spec)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				assert.Nil(t, schedule)
+			} else {
+				require.NotNil(t, schedule)
+				assert.Equal(t, testCase.spec, schedule.String())
+			}
+		})
+	}
+}
+
+func Test_Schedule_Next(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday 2024-01-31 10:07:30 UTC
+	after := time.Date(2024, time.January, 31, 10, 7, 30, 0, time.UTC)
+
+	testCases := map[string]struct {
+		spec string
+		next time.Time
+	}{
+		"every_minute": {
+			spec: "* * * * *",
+			next: time.Date(2024, time.January, 31, 10, 8, 0, 0, time.UTC),
+		},
+		"every_15_minutes": {
+			spec: "*/15 * * * *",
+			next: time.Date(2024, time.January, 31, 10, 15, 0, 0, time.UTC),
+		},
+		"daily": {
+			spec: "30 4 * * *",
+			next: time.Date(2024, time.February, 1, 4, 30, 0, 0, time.UTC),
+		},
+		"month_rollover": {
+			spec: "0 0 1 * *",
+			next: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"leap_day": {
+			spec: "0 12 29 2 *",
+			next: time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC),
+		},
+		"sunday_as_7": {
+			spec: "0 0 * * 7",
+			next: time.Date(2024, time.February, 4, 0, 0, 0, 0, time.UTC),
+		},
+		"day_or_weekday": {
+			spec: "0 0 15 * 5",
+			next: time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			schedule, err := Parse(testCase.spec)
+			require.NoError(t, err)
+
+			next := schedule.Next(after)
+
+			assert.Equal(t, testCase.next, next)
+		})
+	}
+}
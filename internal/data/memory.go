@@ -23,3 +23,11 @@ func (db *Database) SelectAll() (records []records.Record) {
 	defer db.RUnlock()
 	return db.data
 }
+
+// ReplaceAll replaces all the records of the database
+// with the records given.
+func (db *Database) ReplaceAll(records []records.Record) {
+	db.Lock()
+	defer db.Unlock()
+	db.data = records
+}
@@ -28,7 +28,7 @@ func isHealthy(db AllSelecter, resolver LookupIPer) (err error) {
 	for _, record := range records {
 		if record.Status == constants.FAIL {
 			return fmt.Errorf("%w: %s", ErrRecordUpdateFailed, record.String())
-		} else if record.Provider.Proxied() {
+		} else if record.Provider.Proxied() || record.Settings.RecordType != "" {
 			continue
 		}
 
@@ -0,0 +1,115 @@
+package history
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"time"
+)
+
+// Exporter exports the entries of a store.
+type Exporter struct {
+	store Store
+}
+
+func NewExporter(store Store) *Exporter {
+	return &Exporter{
+		store: store,
+	}
+}
+
+// csvHeader is the header of the CSV export,
+// and must not change to keep the export stable.
+var csvHeader = []string{ //nolint:gochecknoglobals
+	"domain", "host", "provider", "ip", "time", "status", "error",
+}
+
+// ExportCSV writes the entries matching the filter as CSV, with
+// a header line and timestamps formatted using RFC3339.
+func (e *Exporter) ExportCSV(ctx context.Context, w io.Writer, filter Filter) (err error) {
+	entries, err := e.store.Query(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("querying entries: %w", err)
+	}
+
+	csvWriter := csv.NewWriter(w)
+	err = csvWriter.Write(csvHeader)
+	if err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	for _, entry := range entries {
+		record := []string{
+			entry.Domain,
+			entry.Host,
+			string(entry.Provider),
+			ipString(entry.IP),
+			entry.Time.UTC().Format(time.RFC3339),
+			string(entry.Status),
+			entry.Error,
+		}
+		err = csvWriter.Write(record)
+		if err != nil {
+			return fmt.Errorf("writing CSV record: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+	err = csvWriter.Error()
+	if err != nil {
+		return fmt.Errorf("flushing CSV: %w", err)
+	}
+	return nil
+}
+
+type jsonEntry struct {
+	Domain   string `json:"domain"`
+	Host     string `json:"host"`
+	Provider string `json:"provider"`
+	IP       string `json:"ip"`
+	Time     string `json:"time"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ExportJSON writes the entries matching the filter as a JSON
+// array, with timestamps formatted using RFC3339.
+func (e *Exporter) ExportJSON(ctx context.Context, w io.Writer, filter Filter) (err error) {
+	entries, err := e.store.Query(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("querying entries: %w", err)
+	}
+
+	jsonEntries := make([]jsonEntry, len(entries))
+	for i, entry := range entries {
+		jsonEntries[i] = jsonEntry{
+			Domain:   entry.Domain,
+			Host:     entry.Host,
+			Provider: string(entry.Provider),
+			IP:       ipString(entry.IP),
+			Time:     entry.Time.UTC().Format(time.RFC3339),
+			Status:   string(entry.Status),
+			Error:    entry.Error,
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(jsonEntries)
+	if err != nil {
+		return fmt.Errorf("json encoding entries: %w", err)
+	}
+	return nil
+}
+
+// ipString returns the IP address given as a string, or the empty
+// string for the entries of records set to a value, without IP address.
+func ipString(ip netip.Addr) string {
+	if !ip.IsValid() {
+		return ""
+	}
+	return ip.String()
+}
@@ -0,0 +1,81 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStore struct {
+	Noop
+	entries []Entry
+}
+
+func (s *testStore) Query(_ context.Context, _ Filter) (entries []Entry, err error) {
+	return s.entries, nil
+}
+
+func newTestExporter() *Exporter {
+	entryTime := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	return NewExporter(&testStore{
+		entries: []Entry{
+			{Domain: "example.com", Host: "@", Provider: "njalla",
+				IP: netip.MustParseAddr("1.2.3.4"), Time: entryTime, Status: "success"},
+			{Domain: "example.com", Host: "sub", Provider: "njalla",
+				IP: netip.MustParseAddr("::1"), Time: entryTime.Add(time.Hour),
+				Status: "failure", Error: "bad request, try again"},
+		},
+	})
+}
+
+func Test_Exporter_ExportCSV(t *testing.T) {
+	t.Parallel()
+
+	exporter := newTestExporter()
+	buffer := bytes.NewBuffer(nil)
+
+	err := exporter.ExportCSV(context.Background(), buffer, Filter{})
+
+	require.NoError(t, err)
+	const expected = "domain,host,provider,ip,time,status,error\n" +
+		"example.com,@,njalla,1.2.3.4,2024-03-01T10:00:00Z,success,\n" +
+		"example.com,sub,njalla,::1,2024-03-01T11:00:00Z,failure,\"bad request, try again\"\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+func Test_Exporter_ExportJSON(t *testing.T) {
+	t.Parallel()
+
+	exporter := newTestExporter()
+	buffer := bytes.NewBuffer(nil)
+
+	err := exporter.ExportJSON(context.Background(), buffer, Filter{})
+
+	require.NoError(t, err)
+	const expected = `[
+  {
+    "domain": "example.com",
+    "host": "@",
+    "provider": "njalla",
+    "ip": "1.2.3.4",
+    "time": "2024-03-01T10:00:00Z",
+    "status": "success"
+  },
+  {
+    "domain": "example.com",
+    "host": "sub",
+    "provider": "njalla",
+    "ip": "::1",
+    "time": "2024-03-01T11:00:00Z",
+    "status": "failure",
+    "error": "bad request, try again"
+  }
+]
+`
+	assert.Equal(t, expected, buffer.String())
+}
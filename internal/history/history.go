@@ -0,0 +1,56 @@
+// Package history defines the store of record update attempts.
+package history
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+)
+
+// Entry is the outcome of an update attempt of a record for an IP address.
+type Entry struct {
+	Domain   string
+	Host     string
+	Provider models.Provider
+	IP       netip.Addr
+	Time     time.Time
+	Status   models.Status
+	// Error is the error message of a failed update attempt,
+	// and is empty for a successful one.
+	Error string
+}
+
+// Store stores the update attempts of records.
+type Store interface {
+	Add(ctx context.Context, entry Entry) (err error)
+	// Last returns the last n entries for the record identified
+	// by its domain and host, ordered from newest to oldest.
+	Last(ctx context.Context, domain, host string, n uint) (entries []Entry, err error)
+	// Query returns the entries matching the filter,
+	// ordered from oldest to newest.
+	Query(ctx context.Context, filter Filter) (entries []Entry, err error)
+	// Prune removes the entries falling outside the store retention.
+	Prune(ctx context.Context) (err error)
+	Close() (err error)
+}
+
+// Filter scopes entries. Its zero value fields are not filtered on.
+type Filter struct {
+	Domain string
+	Host   string
+	// Since is the inclusive lower time bound of entries.
+	Since time.Time
+	// Until is the exclusive upper time bound of entries.
+	Until time.Time
+}
+
+// Retention defines which entries to keep in a store.
+// Its zero value fields disable the corresponding pruning.
+type Retention struct {
+	// MaxAge is the maximum age of entries to keep.
+	MaxAge time.Duration
+	// MaxCount is the maximum number of entries to keep per record.
+	MaxCount uint
+}
@@ -0,0 +1,21 @@
+package history
+
+import "context"
+
+// Noop is a store discarding all entries,
+// used when no history store is configured.
+type Noop struct{}
+
+func (Noop) Add(context.Context, Entry) (err error) { return nil }
+
+func (Noop) Last(context.Context, string, string, uint) (entries []Entry, err error) {
+	return nil, nil
+}
+
+func (Noop) Query(context.Context, Filter) (entries []Entry, err error) {
+	return nil, nil
+}
+
+func (Noop) Prune(context.Context) (err error) { return nil }
+
+func (Noop) Close() (err error) { return nil }
@@ -0,0 +1,83 @@
+// Package logging formats structured log fields and writes log
+// lines as JSON objects.
+package logging
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Field is a structured log field.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// Message returns the message given followed by a tab and the fields
+// given in the logfmt key=value format, such that the fields are kept
+// readable in text logs and become JSON fields in JSON logs. Fields
+// with an empty value are omitted.
+func Message(message string, fields ...Field) string {
+	var builder strings.Builder
+	builder.WriteString(message)
+	separator := "\t"
+	for _, field := range fields {
+		if field.Value == "" {
+			continue
+		}
+		builder.WriteString(separator)
+		separator = " "
+		builder.WriteString(field.Key)
+		builder.WriteByte('=')
+		builder.WriteString(quoteValue(field.Value))
+	}
+	return builder.String()
+}
+
+func quoteValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\"=\\") ||
+		!strconv.CanBackquote(value) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// parseFields parses fields in the logfmt key=value format, and returns
+// false if s is not only made of such fields.
+func parseFields(s string) (fields []Field, ok bool) {
+	for s != "" {
+		equalIndex := strings.IndexByte(s, '=')
+		if equalIndex <= 0 {
+			return nil, false
+		}
+		key := s[:equalIndex]
+		if strings.ContainsAny(key, " \"") {
+			return nil, false
+		}
+		s = s[equalIndex+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			quoted, err := strconv.QuotedPrefix(s)
+			if err != nil {
+				return nil, false
+			}
+			value, _ = strconv.Unquote(quoted)
+			s = s[len(quoted):]
+		} else {
+			end := strings.IndexByte(s, ' ')
+			if end == -1 {
+				end = len(s)
+			}
+			value = s[:end]
+			s = s[end:]
+		}
+
+		if s != "" && !strings.HasPrefix(s, " ") {
+			return nil, false
+		}
+		s = strings.TrimPrefix(s, " ")
+		fields = append(fields, Field{Key: key, Value: value})
+	}
+	return fields, len(fields) > 0
+}
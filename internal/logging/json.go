@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JSONWriter converts the log lines written to it into JSON objects
+// written on a single line to the writer given. Log lines must be
+// formatted without time by the github.com/qdm12/log logger, as
+// "LEVEL [component] message\tfields\tcaller", where the component,
+// the fields and the caller are optional.
+type JSONWriter struct {
+	writer  io.Writer
+	timeNow func() time.Time
+	mutex   sync.Mutex
+}
+
+func NewJSONWriter(writer io.Writer, timeNow func() time.Time) *JSONWriter {
+	return &JSONWriter{
+		writer:  writer,
+		timeNow: timeNow,
+	}
+}
+
+var ansiEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Write writes the log line p as a JSON object with the time, level,
+// component, message and caller keys, as well as a key for each field.
+// Since the logger writes each log line with a single call, new lines
+// within p are kept in the message, for example for the settings summary.
+// It always returns the length of p, and any write error to the
+// underlying writer.
+func (w *JSONWriter) Write(p []byte) (n int, err error) {
+	line := strings.TrimSuffix(string(p), "\n")
+	line = ansiEscapeRegex.ReplaceAllString(line, "")
+	buffer := bytes.NewBuffer(nil)
+	encodeLine(buffer, w.timeNow(), line)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, err = w.writer.Write(buffer.Bytes())
+	return len(p), err
+}
+
+func encodeLine(buffer *bytes.Buffer, now time.Time, line string) {
+	parts := strings.Split(line, "\t")
+	level, component, message := parseHead(parts[0])
+
+	// Keys are written in a fixed order, so an ordered slice of
+	// fields is used instead of a map.
+	keyValues := make([]Field, 0, len(parts)+4) //nolint:gomnd
+	keyValues = append(keyValues,
+		Field{Key: "time", Value: now.Format(time.RFC3339Nano)},
+		Field{Key: "level", Value: level})
+	if component != "" {
+		keyValues = append(keyValues, Field{Key: "component", Value: component})
+	}
+	keyValues = append(keyValues, Field{Key: "message", Value: message})
+
+	for _, part := range parts[1:] {
+		fields, ok := parseFields(part)
+		if ok {
+			keyValues = append(keyValues, fields...)
+			continue
+		}
+		keyValues = append(keyValues, Field{Key: "caller", Value: part})
+	}
+
+	buffer.WriteByte('{')
+	for i, keyValue := range keyValues {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(keyValue.Key)
+		value, _ := json.Marshal(keyValue.Value)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteString("}\n")
+}
+
+// parseHead parses the level, component and message
+// from a line formatted as "LEVEL [component] message".
+func parseHead(head string) (level, component, message string) {
+	level, message, _ = strings.Cut(head, " ")
+	level = strings.ToLower(level)
+	if strings.HasPrefix(message, "[") {
+		end := strings.Index(message, "] ")
+		if end != -1 {
+			component = message[1:end]
+			message = message[end+2:]
+		}
+	}
+	return level, component, message
+}
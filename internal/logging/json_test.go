@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Message(t *testing.T) {
+	t.Parallel()
+
+	message := Message("record updated",
+		Field{Key: "domain", Value: "example.com"},
+		Field{Key: "host", Value: ""},
+		Field{Key: "error", Value: `bad "thing" happened`})
+
+	assert.Equal(t, "record updated\tdomain=example.com error=\"bad \\\"thing\\\" happened\"", message)
+}
+
+func Test_JSONWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	const nowString = `"time":"2024-01-02T03:04:05Z"`
+
+	testCases := map[string]struct {
+		line   string
+		output string
+	}{
+		"message_only": {
+			line:   "INFO some message\n",
+			output: `{` + nowString + `,"level":"info","message":"some message"}` + "\n",
+		},
+		"colored_level_and_component": {
+			line: "\x1b[36mWARN\x1b[0m [http server] listening\n",
+			output: `{` + nowString + `,"level":"warn","component":"http server",` +
+				`"message":"listening"}` + "\n",
+		},
+		"fields_and_caller": {
+			line: "ERROR " + Message("record update failed",
+				Field{Key: "domain", Value: "example.com"},
+				Field{Key: "error", Value: "bad status: 500"}) + "\tmain.go:10\n",
+			output: `{` + nowString + `,"level":"error","message":"record update failed",` +
+				`"domain":"example.com","error":"bad status: 500","caller":"main.go:10"}` + "\n",
+		},
+		"multiple_lines": {
+			line:   "INFO Settings summary:\n├── HTTP client\n",
+			output: `{` + nowString + `,"level":"info","message":"Settings summary:\n├── HTTP client"}` + "\n",
+		},
+		"tab_not_fields": {
+			line:   "DEBUG a\tb c\n",
+			output: `{` + nowString + `,"level":"debug","message":"a","caller":"b c"}` + "\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := bytes.NewBuffer(nil)
+			writer := NewJSONWriter(buffer, func() time.Time { return now })
+
+			n, err := writer.Write([]byte(testCase.line))
+
+			require.NoError(t, err)
+			assert.Equal(t, len(testCase.line), n)
+			assert.Equal(t, testCase.output, buffer.String())
+		})
+	}
+}
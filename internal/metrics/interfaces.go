@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/qdm12/ddns-updater/internal/records"
+)
+
+type Database interface {
+	SelectAll() (records []records.Record)
+}
+
+type PublicIPFetcher interface {
+	IP(ctx context.Context) (netip.Addr, error)
+	IP4(ctx context.Context) (netip.Addr, error)
+	IP6(ctx context.Context) (netip.Addr, error)
+}
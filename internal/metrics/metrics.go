@@ -0,0 +1,77 @@
+// Package metrics exposes Prometheus metrics about record updates
+// and public IP address fetching.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+const namespace = "ddns_updater"
+
+type Metrics struct {
+	registry         *prometheus.Registry
+	updateAttempts   *prometheus.CounterVec
+	updateDuration   *prometheus.HistogramVec
+	publicIPFailures *prometheus.CounterVec
+}
+
+func New(db Database, timeNow func() time.Time) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		updateAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "update_attempts_total",
+			Help:      "Number of record update attempts by provider and result.",
+		}, []string{"provider", "result"}),
+		updateDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "update_duration_seconds",
+			Help:      "Duration of record update attempts by provider.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"provider"}),
+		publicIPFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "public_ip_fetch_failures_total",
+			Help:      "Number of failures fetching the public IP address by IP version.",
+		}, []string{"ip_version"}),
+	}
+
+	m.registry.MustRegister(
+		m.updateAttempts,
+		m.updateDuration,
+		m.publicIPFailures,
+		newRecordsCollector(db, timeNow),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Handler returns the HTTP handler serving the metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// UpdateAttempt records an update attempt of a record of the provider given,
+// which took the duration given and failed if err is not nil.
+func (m *Metrics) UpdateAttempt(provider models.Provider, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.updateAttempts.WithLabelValues(string(provider), result).Inc()
+	m.updateDuration.WithLabelValues(string(provider)).Observe(duration.Seconds())
+}
+
+// PublicIPFetchFailed records a failure fetching the public IP address.
+func (m *Metrics) PublicIPFetchFailed(version ipversion.IPVersion) {
+	m.publicIPFailures.WithLabelValues(version.String()).Inc()
+}
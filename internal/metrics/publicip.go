@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+// InstrumentedFetcher wraps a public IP fetcher to record its failures.
+type InstrumentedFetcher struct {
+	fetcher PublicIPFetcher
+	metrics *Metrics
+}
+
+func NewInstrumentedFetcher(fetcher PublicIPFetcher, metrics *Metrics) *InstrumentedFetcher {
+	return &InstrumentedFetcher{
+		fetcher: fetcher,
+		metrics: metrics,
+	}
+}
+
+func (f *InstrumentedFetcher) IP(ctx context.Context) (ip netip.Addr, err error) {
+	ip, err = f.fetcher.IP(ctx)
+	if err != nil {
+		f.metrics.PublicIPFetchFailed(ipversion.IP4or6)
+	}
+	return ip, err
+}
+
+func (f *InstrumentedFetcher) IP4(ctx context.Context) (ipv4 netip.Addr, err error) {
+	ipv4, err = f.fetcher.IP4(ctx)
+	if err != nil {
+		f.metrics.PublicIPFetchFailed(ipversion.IP4)
+	}
+	return ipv4, err
+}
+
+func (f *InstrumentedFetcher) IP6(ctx context.Context) (ipv6 netip.Addr, err error) {
+	ipv6, err = f.fetcher.IP6(ctx)
+	if err != nil {
+		f.metrics.PublicIPFetchFailed(ipversion.IP6)
+	}
+	return ipv6, err
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// recordsCollector collects metrics from the records state at scrape time.
+type recordsCollector struct {
+	db                  Database
+	timeNow             func() time.Time
+	ipInfo              *prometheus.Desc
+	secondsSinceSuccess *prometheus.Desc
+}
+
+func newRecordsCollector(db Database, timeNow func() time.Time) *recordsCollector {
+	return &recordsCollector{
+		db:      db,
+		timeNow: timeNow,
+		ipInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "record", "ip_info"),
+			"Current IP address of a record, as a label with a constant value of 1.",
+			[]string{"domain", "host", "provider", "ip"}, nil),
+		secondsSinceSuccess: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "record", "seconds_since_last_success"),
+			"Seconds elapsed since the last successful update of a record.",
+			[]string{"domain", "host", "provider"}, nil),
+	}
+}
+
+func (c *recordsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ipInfo
+	ch <- c.secondsSinceSuccess
+}
+
+func (c *recordsCollector) Collect(ch chan<- prometheus.Metric) {
+	now := c.timeNow()
+	for _, record := range c.db.SelectAll() {
+		domain := record.Provider.Domain()
+		host := record.Provider.Host()
+		provider := string(record.Settings.ProviderName)
+
+		currentIP := record.History.GetCurrentIP()
+		if currentIP.IsValid() {
+			ch <- prometheus.MustNewConstMetric(c.ipInfo, prometheus.GaugeValue, 1,
+				domain, host, provider, currentIP.String())
+		}
+
+		successTime := record.History.GetSuccessTime()
+		if !successTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(c.secondsSinceSuccess, prometheus.GaugeValue,
+				now.Sub(successTime).Seconds(), domain, host, provider)
+		}
+	}
+}
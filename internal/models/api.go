@@ -0,0 +1,19 @@
+package models
+
+import (
+	"net/netip"
+	"time"
+)
+
+// APIRecord contains the fields of a record for the JSON API.
+type APIRecord struct {
+	Provider   Provider   `json:"provider"`
+	Domain     string     `json:"domain"`
+	Host       string     `json:"host"`
+	IPVersion  string     `json:"ip_version"`
+	Proxied    bool       `json:"proxied"`
+	Status     Status     `json:"status"`
+	CurrentIP  netip.Addr `json:"current_ip"`
+	LastUpdate *time.Time `json:"last_update"`
+	LastError  string     `json:"last_error,omitempty"`
+}
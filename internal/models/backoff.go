@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// FailureBackoffSettings contains the settings to increase the
+// interval between update checks of a record failing repeatedly.
+type FailureBackoffSettings struct {
+	// Failures is the number of consecutive failed updates after
+	// which the interval is increased, and is zero to disable it.
+	Failures uint
+	// Multiplier is the factor the interval is multiplied with
+	// for each consecutive failure from Failures.
+	Multiplier float64
+	// MaxInterval caps the increased interval.
+	MaxInterval time.Duration
+}
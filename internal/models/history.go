@@ -38,6 +38,24 @@ func (h History) GetCurrentIP() netip.Addr {
 	return h[len(h)-1].IP
 }
 
+// GetCurrentIPs returns the latest IPv4 and IPv6 addresses in the history,
+// which is useful for records updating both addresses.
+func (h History) GetCurrentIPs() (ipv4, ipv6 netip.Addr) {
+	for i := len(h) - 1; i >= 0; i-- {
+		ip := h[i].IP
+		switch {
+		case ip.Is4() && !ipv4.IsValid():
+			ipv4 = ip
+		case ip.Is6() && !ipv6.IsValid():
+			ipv6 = ip
+		}
+		if ipv4.IsValid() && ipv6.IsValid() {
+			break
+		}
+	}
+	return ipv4, ipv6
+}
+
 // GetSuccessTime returns the latest success update time.
 func (h History) GetSuccessTime() time.Time {
 	if len(h) < 1 {
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"net/netip"
 	"testing"
 	"time"
 
@@ -36,3 +37,47 @@ func Test_GetDurationSinceSuccess(t *testing.T) {
 		})
 	}
 }
+
+func Test_History_GetCurrentIPs(t *testing.T) {
+	t.Parallel()
+
+	ipv4Old := netip.MustParseAddr("1.1.1.1")
+	ipv4New := netip.MustParseAddr("2.2.2.2")
+	ipv6Old := netip.MustParseAddr("2001:db8::1")
+	ipv6New := netip.MustParseAddr("2001:db8::2")
+
+	tests := map[string]struct {
+		h    History
+		ipv4 netip.Addr
+		ipv6 netip.Addr
+	}{
+		"empty history": {},
+		"ipv4 only": {
+			h:    History{{IP: ipv4Old}, {IP: ipv4New}},
+			ipv4: ipv4New,
+		},
+		"ipv6 only": {
+			h:    History{{IP: ipv6Old}, {IP: ipv6New}},
+			ipv6: ipv6New,
+		},
+		"latest of each family": {
+			h:    History{{IP: ipv4Old}, {IP: ipv6Old}, {IP: ipv4New}, {IP: ipv6New}},
+			ipv4: ipv4New,
+			ipv6: ipv6New,
+		},
+		"older ipv6 before newer ipv4 events": {
+			h:    History{{IP: ipv6Old}, {IP: ipv4Old}, {IP: ipv4New}},
+			ipv4: ipv4New,
+			ipv6: ipv6Old,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ipv4, ipv6 := tc.h.GetCurrentIPs()
+			assert.Equal(t, tc.ipv4, ipv4)
+			assert.Equal(t, tc.ipv6, ipv6)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/cron"
+)
+
+// RecordSettings contains settings specific to a record,
+// resolved from the program settings and the record
+// JSON configuration.
+type RecordSettings struct {
+	// ProviderName is the name of the DNS provider of the record.
+	ProviderName Provider
+	// Interval is the duration between update checks of the record.
+	Interval time.Duration
+	// Cron is the cron schedule of the update checks of the record,
+	// taking precedence over the interval. It is nil if not set.
+	Cron      *cron.Schedule
+	Retry     RetrySettings
+	RateLimit RateLimitSettings
+	// HealthcheckURL is the URL to ping after each successful update,
+	// and with the /fail suffix after each failed update. It is empty
+	// if not set.
+	HealthcheckURL string
+	// HTTPTimeout is the maximum duration of each update attempt,
+	// and is zero to only rely on the HTTP client timeout.
+	HTTPTimeout time.Duration
+	// ProxyURL is the proxy URL to use for the record provider requests,
+	// overriding the program proxy. It is nil to use the program proxy,
+	// and the empty string to not use any proxy.
+	ProxyURL *string
+	// DryRun is true to only log the updates of the record
+	// instead of calling the provider.
+	DryRun bool
+	// SkipUnchanged is true to not update the record if its last
+	// update succeeded with the same public IP addresses, unless
+	// ForceInterval elapsed since then. ForceInterval is zero to
+	// never force an update.
+	SkipUnchanged bool
+	ForceInterval time.Duration
+	// UpdateOnStart is true to update the record at program start,
+	// even if its IP addresses did not change.
+	UpdateOnStart bool
+	// RecordType is the type of the record set to Content instead
+	// of the public IP address, such as TXT. It is empty for A and
+	// AAAA records updated with the public IP address.
+	RecordType string
+	Content    string
+}
+
+// RateLimitSettings contains the settings to limit the rate of
+// update requests of the records of a same provider account.
+type RateLimitSettings struct {
+	// Requests is the maximum number of update attempts per Interval,
+	// and is zero to not limit them.
+	Requests uint
+	Interval time.Duration
+	// Bucket is the name of the rate limit bucket shared by the
+	// records of the same provider with the same bucket name, and
+	// is empty for the bucket shared by all the provider records.
+	Bucket string
+}
+
+// RetrySettings contains the settings to retry a failed
+// record update with an exponential backoff.
+type RetrySettings struct {
+	// MaxAttempts is the maximum number of update attempts,
+	// including the first one. A value of 1 disables retries.
+	MaxAttempts uint
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay is the maximum delay between two attempts.
+	MaxDelay time.Duration
+	// Multiplier is the factor applied to the delay
+	// after each retry.
+	Multiplier float64
+}
@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Discord sends an embed describing the event to a Discord webhook.
+type Discord struct {
+	client     *http.Client
+	webhookURL string
+}
+
+func NewDiscord(client *http.Client, webhookURL string) *Discord {
+	return &Discord{
+		client:     client,
+		webhookURL: webhookURL,
+	}
+}
+
+const (
+	discordColorGreen = 0x2ecc71
+	discordColorRed   = 0xe74c3c
+)
+
+var ErrDiscordRateLimited = errors.New("discord rate limited")
+
+func (d *Discord) Notify(ctx context.Context, event Event) (err error) {
+	type field struct {
+		Name   string `json:"name"`
+		Value  string `json:"value"`
+		Inline bool   `json:"inline"`
+	}
+	type embed struct {
+		Title       string    `json:"title"`
+		Description string    `json:"description,omitempty"`
+		Color       int       `json:"color"`
+		Fields      []field   `json:"fields"`
+		Timestamp   time.Time `json:"timestamp"`
+	}
+
+	oldIP := "none"
+	if event.OldIP.IsValid() {
+		oldIP = event.OldIP.String()
+	}
+	newIP := "none"
+	if event.NewIP.IsValid() {
+		newIP = event.NewIP.String()
+	}
+	e := embed{
+		Title: "IP address changed",
+		Color: discordColorGreen,
+		Fields: []field{
+			{Name: "Domain", Value: event.FQDN()},
+			{Name: "Provider", Value: string(event.Provider)},
+			{Name: "Old IP", Value: oldIP, Inline: true},
+			{Name: "New IP", Value: newIP, Inline: true},
+		},
+		Timestamp: event.Time,
+	}
+	if event.Failed() {
+		e.Title = "Update failed"
+		e.Description = event.Err.Error()
+		e.Color = discordColorRed
+	}
+
+	requestBody := struct {
+		Embeds []embed `json:"embeds"`
+	}{
+		Embeds: []embed{e},
+	}
+	body, err := json.Marshal(requestBody)
+	if err != nil {
+		return fmt.Errorf("json encoding discord request body: %w", err)
+	}
+
+	retryAfter, err := d.send(ctx, body)
+	if !errors.Is(err, ErrDiscordRateLimited) {
+		return err
+	}
+
+	// Retry once after the delay given by Discord, and drop the message
+	// if it is rate limited again or if the delay exceeds the context deadline.
+	deadline, ok := ctx.Deadline()
+	if ok && time.Now().Add(retryAfter).After(deadline) {
+		return fmt.Errorf("%w: dropping message since retry after %s exceeds deadline",
+			err, retryAfter)
+	}
+	timer := time.NewTimer(retryAfter)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return ctx.Err()
+	case <-timer.C:
+	}
+	_, err = d.send(ctx, body)
+	return err
+}
+
+func (d *Discord) send(ctx context.Context, body []byte) (
+	retryAfter time.Duration, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		d.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("creating discord request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("doing discord request: %w", err)
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusTooManyRequests:
+		retryAfter = parseRetryAfter(response.Header.Get("Retry-After"))
+		return retryAfter, fmt.Errorf("%w: retry after %s", ErrDiscordRateLimited, retryAfter)
+	case response.StatusCode < http.StatusOK,
+		response.StatusCode >= http.StatusMultipleChoices:
+		b, _ := io.ReadAll(response.Body)
+		return 0, fmt.Errorf("discord: %w: %d: %s", ErrHTTPStatusNotValid,
+			response.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return 0, nil
+}
+
+// parseRetryAfter parses the Retry-After header value given in seconds,
+// which can be a decimal number. It defaults to one second if the value
+// cannot be parsed.
+func parseRetryAfter(value string) (retryAfter time.Duration) {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 {
+		return time.Second
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
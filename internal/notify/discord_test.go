@@ -0,0 +1,138 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Discord_Notify(t *testing.T) {
+	t.Parallel()
+
+	event := Event{
+		Domain:   "example.com",
+		Host:     "@",
+		Provider: "njalla",
+		NewIP:    netip.MustParseAddr("1.2.3.4"),
+	}
+
+	testCases := map[string]struct {
+		statusCodes []int
+		retryAfter  string
+		requests    int32
+		errWrapped  error
+		errMessage  string
+	}{
+		"success": {
+			statusCodes: []int{http.StatusNoContent},
+			requests:    1,
+		},
+		"rate_limited_then_success": {
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusNoContent},
+			retryAfter:  "0.01",
+			requests:    2,
+		},
+		"rate_limited_twice": {
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			retryAfter:  "0.01",
+			requests:    2,
+			errWrapped:  ErrDiscordRateLimited,
+			errMessage:  "discord rate limited: retry after 10ms",
+		},
+		"bad_status": {
+			statusCodes: []int{http.StatusBadRequest},
+			requests:    1,
+			errWrapped:  ErrHTTPStatusNotValid,
+			errMessage:  "discord: HTTP status is not valid: 400: bad request",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := requests.Add(1) - 1
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				statusCode := testCase.statusCodes[i]
+				if statusCode == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", testCase.retryAfter)
+				}
+				w.WriteHeader(statusCode)
+				if statusCode == http.StatusBadRequest {
+					_, _ = w.Write([]byte("bad request\n"))
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			discord := NewDiscord(server.Client(), server.URL)
+
+			err := discord.Notify(context.Background(), event)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.requests, requests.Load())
+		})
+	}
+}
+
+func Test_Discord_Notify_retryAfterExceedsDeadline(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	discord := NewDiscord(server.Client(), server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := discord.Notify(ctx, Event{NewIP: netip.MustParseAddr("1.2.3.4")})
+
+	require.ErrorIs(t, err, ErrDiscordRateLimited)
+	assert.EqualError(t, err, "discord rate limited: retry after 1m0s: "+
+		"dropping message since retry after 1m0s exceeds deadline")
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value      string
+		retryAfter time.Duration
+	}{
+		"empty":    {retryAfter: time.Second},
+		"invalid":  {value: "soon", retryAfter: time.Second},
+		"negative": {value: "-1", retryAfter: time.Second},
+		"integer":  {value: "2", retryAfter: 2 * time.Second},
+		"decimal":  {value: "0.5", retryAfter: 500 * time.Millisecond},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			retryAfter := parseRetryAfter(testCase.value)
+
+			assert.Equal(t, testCase.retryAfter, retryAfter)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	eventsBufferSize = 32
+	notifyTimeout    = 10 * time.Second
+)
+
+// Dispatcher sends events to its notifiers in a background goroutine,
+// such that a slow notifier does not block the caller.
+type Dispatcher struct {
+	notifiers []Notifier
+	events    chan Event
+	logger    Logger
+}
+
+func NewDispatcher(notifiers []Notifier, logger Logger) *Dispatcher {
+	return &Dispatcher{
+		notifiers: notifiers,
+		events:    make(chan Event, eventsBufferSize),
+		logger:    logger,
+	}
+}
+
+// Dispatch queues the event to be sent to the notifiers without blocking.
+// The event is dropped if the queue is full.
+func (d *Dispatcher) Dispatch(event Event) {
+	if len(d.notifiers) == 0 {
+		return
+	}
+
+	select {
+	case d.events <- event:
+	default:
+		d.logger.Warn("notification queue is full, dropping event for " + event.FQDN())
+	}
+}
+
+// Run sends queued events to the notifiers until the context is canceled.
+func (d *Dispatcher) Run(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-d.events:
+			d.notify(ctx, event)
+		}
+	}
+}
+
+// timeouter is implemented by notifiers needing a timeout
+// different from the default notify timeout.
+type timeouter interface {
+	Timeout() time.Duration
+}
+
+func (d *Dispatcher) notify(ctx context.Context, event Event) {
+	for _, notifier := range d.notifiers {
+		timeout := notifyTimeout
+		if t, ok := notifier.(timeouter); ok {
+			timeout = t.Timeout()
+		}
+		notifyCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := notifier.Notify(notifyCtx, event)
+		cancel()
+		if err != nil {
+			d.logger.Error(err.Error())
+		}
+	}
+}
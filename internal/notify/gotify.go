@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Gotify sends a message describing the event to a Gotify server.
+type Gotify struct {
+	client   *http.Client
+	url      *url.URL
+	token    string
+	priority int
+}
+
+func NewGotify(client *http.Client, url *url.URL, token string, priority int) *Gotify {
+	return &Gotify{
+		client:   client,
+		url:      url,
+		token:    token,
+		priority: priority,
+	}
+}
+
+func (g *Gotify) Notify(ctx context.Context, event Event) (err error) {
+	title := "IP address changed"
+	if event.Failed() {
+		title = "Update failed"
+	}
+	requestBody := struct {
+		Title    string `json:"title"`
+		Message  string `json:"message"`
+		Priority int    `json:"priority"`
+	}{
+		Title:    title,
+		Message:  event.Message(),
+		Priority: g.priority,
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err = json.NewEncoder(buffer).Encode(requestBody)
+	if err != nil {
+		return fmt.Errorf("json encoding gotify request body: %w", err)
+	}
+
+	u := g.url.JoinPath("message")
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), buffer)
+	if err != nil {
+		return fmt.Errorf("creating gotify request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Gotify-Key", g.token)
+
+	response, err := g.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("doing gotify request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("gotify: %w: %d: %s", ErrHTTPStatusNotValid,
+			response.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Gotify_Notify(t *testing.T) {
+	t.Parallel()
+
+	event := Event{
+		Domain:   "example.com",
+		Host:     "@",
+		Provider: "njalla",
+		NewIP:    netip.MustParseAddr("1.2.3.4"),
+	}
+	failedEvent := event
+	failedEvent.Err = errors.New("test error")
+
+	testCases := map[string]struct {
+		event      Event
+		statusCode int
+		title      string
+		errWrapped error
+		errMessage string
+	}{
+		"ip_change": {
+			event:      event,
+			statusCode: http.StatusOK,
+			title:      "IP address changed",
+		},
+		"failure": {
+			event:      failedEvent,
+			statusCode: http.StatusOK,
+			title:      "Update failed",
+		},
+		"bad_status": {
+			event:      event,
+			statusCode: http.StatusUnauthorized,
+			title:      "IP address changed",
+			errWrapped: ErrHTTPStatusNotValid,
+			errMessage: "gotify: HTTP status is not valid: 401: unauthorized",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/gotify/message", r.URL.Path)
+				assert.Equal(t, "token", r.Header.Get("X-Gotify-Key"))
+				var body struct {
+					Title    string `json:"title"`
+					Message  string `json:"message"`
+					Priority int    `json:"priority"`
+				}
+				err := json.NewDecoder(r.Body).Decode(&body)
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.title, body.Title)
+				assert.Equal(t, testCase.event.Message(), body.Message)
+				assert.Equal(t, 5, body.Priority)
+				w.WriteHeader(testCase.statusCode)
+				if testCase.statusCode != http.StatusOK {
+					_, _ = w.Write([]byte("unauthorized"))
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			serverURL, err := url.Parse(server.URL + "/gotify")
+			require.NoError(t, err)
+			gotify := NewGotify(server.Client(), serverURL, "token", 5)
+
+			err = gotify.Notify(context.Background(), testCase.event)
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package notify
+
+type Logger interface {
+	Warn(s string)
+	Error(s string)
+}
+
+type MessageSender interface {
+	Notify(message string)
+}
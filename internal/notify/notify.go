@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+)
+
+// Event describes a record update to notify about.
+type Event struct {
+	Domain   string
+	Host     string
+	Provider models.Provider
+	OldIP    netip.Addr
+	// NewIP is the zero value for an event about a record
+	// set to a value, such as a TXT record, failing repeatedly.
+	NewIP netip.Addr
+	Time  time.Time
+	// Err is the error of the last update attempt for an
+	// event about an update failing repeatedly, and is nil
+	// for an event about an IP address change.
+	Err error
+}
+
+// Failed returns true if the event is about an update failing repeatedly.
+func (e Event) Failed() bool {
+	return e.Err != nil
+}
+
+// FQDN returns the fully qualified domain name of the record.
+func (e Event) FQDN() string {
+	if e.Host == "@" {
+		return e.Domain
+	}
+	return e.Host + "." + e.Domain
+}
+
+// Message returns a human readable single line message
+// describing the event.
+func (e Event) Message() string {
+	if e.Failed() && !e.NewIP.IsValid() { // record set to a value
+		return e.FQDN() + " (" + string(e.Provider) + ") failed updating: " + e.Err.Error()
+	} else if e.Failed() {
+		return e.FQDN() + " (" + string(e.Provider) + ") failed updating to " +
+			e.NewIP.String() + ": " + e.Err.Error()
+	}
+	message := e.FQDN() + " (" + string(e.Provider) + ") changed"
+	if e.OldIP.IsValid() {
+		message += " from " + e.OldIP.String()
+	}
+	return message + " to " + e.NewIP.String()
+}
+
+// Notifier sends a notification describing an event.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) (err error)
+}
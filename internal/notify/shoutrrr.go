@@ -0,0 +1,22 @@
+package notify
+
+import "context"
+
+// Shoutrrr formats the event as a message and sends it to
+// the Shoutrrr services of the sender.
+type Shoutrrr struct {
+	sender MessageSender
+}
+
+func NewShoutrrr(sender MessageSender) *Shoutrrr {
+	return &Shoutrrr{
+		sender: sender,
+	}
+}
+
+// Notify sends the event message. Send errors are logged
+// by the sender and are not returned.
+func (s *Shoutrrr) Notify(_ context.Context, event Event) (err error) {
+	s.sender.Notify(event.Message())
+	return nil
+}
@@ -0,0 +1,206 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// SMTPSettings contains the settings to send emails with an SMTP server.
+type SMTPSettings struct {
+	Host     string
+	Port     uint16
+	Username string
+	Password string
+	From     string
+	To       []string
+	// ImplicitTLS is true to connect using TLS directly,
+	// and false to upgrade the connection with STARTTLS.
+	ImplicitTLS bool
+	// NotifyFailures is true to also send an email for
+	// events about an update failing repeatedly.
+	NotifyFailures bool
+	// SubjectTemplate and BodyTemplate are Go text templates
+	// executed with the Event to notify about.
+	SubjectTemplate string
+	BodyTemplate    string
+	Timeout         time.Duration
+}
+
+// SMTP sends an email describing the event with an SMTP server.
+type SMTP struct {
+	settings        SMTPSettings
+	subjectTemplate *template.Template
+	bodyTemplate    *template.Template
+}
+
+func NewSMTP(settings SMTPSettings) (s *SMTP, err error) {
+	subjectTemplate, err := template.New("subject").Parse(settings.SubjectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing subject template: %w", err)
+	}
+
+	bodyTemplate, err := template.New("body").Parse(settings.BodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing body template: %w", err)
+	}
+
+	return &SMTP{
+		settings:        settings,
+		subjectTemplate: subjectTemplate,
+		bodyTemplate:    bodyTemplate,
+	}, nil
+}
+
+// Timeout returns the timeout to send one email.
+func (s *SMTP) Timeout() time.Duration {
+	return s.settings.Timeout
+}
+
+var ErrSTARTTLSNotSupported = errors.New("STARTTLS is not supported by the server")
+
+func (s *SMTP) Notify(ctx context.Context, event Event) (err error) {
+	if event.Failed() && !s.settings.NotifyFailures {
+		return nil
+	}
+
+	message, err := s.buildMessage(event)
+	if err != nil {
+		return fmt.Errorf("building email: %w", err)
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to SMTP server: %w", err)
+	}
+	defer client.Close()
+
+	if !s.settings.ImplicitTLS {
+		ok, _ := client.Extension("STARTTLS")
+		if !ok {
+			return fmt.Errorf("%w", ErrSTARTTLSNotSupported)
+		}
+		err = client.StartTLS(&tls.Config{
+			ServerName: s.settings.Host,
+			MinVersion: tls.VersionTLS12,
+		})
+		if err != nil {
+			return fmt.Errorf("starting TLS: %w", err)
+		}
+	}
+
+	if s.settings.Username != "" {
+		auth := smtp.PlainAuth("", s.settings.Username, s.settings.Password, s.settings.Host)
+		err = client.Auth(auth)
+		if err != nil {
+			return fmt.Errorf("authenticating: %w", err)
+		}
+	}
+
+	err = client.Mail(s.settings.From)
+	if err != nil {
+		return fmt.Errorf("setting sender: %w", err)
+	}
+	for _, to := range s.settings.To {
+		err = client.Rcpt(to)
+		if err != nil {
+			return fmt.Errorf("adding recipient %s: %w", to, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("starting email data: %w", err)
+	}
+	_, err = writer.Write(message)
+	if err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("writing email data: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (s *SMTP) dial(ctx context.Context) (client *smtp.Client, err error) {
+	address := net.JoinHostPort(s.settings.Host, strconv.Itoa(int(s.settings.Port)))
+
+	var conn net.Conn
+	if s.settings.ImplicitTLS {
+		dialer := &tls.Dialer{
+			Config: &tls.Config{
+				ServerName: s.settings.Host,
+				MinVersion: tls.VersionTLS12,
+			},
+		}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		dialer := &net.Dialer{}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the smtp client does not support contexts, so the context
+	// deadline is enforced on the connection instead.
+	deadline, ok := ctx.Deadline()
+	if ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("setting connection deadline: %w", err)
+		}
+	}
+
+	client, err = smtp.NewClient(conn, s.settings.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+func (s *SMTP) buildMessage(event Event) (message []byte, err error) {
+	subject := new(strings.Builder)
+	err = s.subjectTemplate.Execute(subject, event)
+	if err != nil {
+		return nil, fmt.Errorf("executing subject template: %w", err)
+	}
+
+	body := new(strings.Builder)
+	err = s.bodyTemplate.Execute(body, event)
+	if err != nil {
+		return nil, fmt.Errorf("executing body template: %w", err)
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	headers := []string{
+		"From: " + s.settings.From,
+		"To: " + strings.Join(s.settings.To, ", "),
+		"Subject: " + strings.Join(strings.Fields(subject.String()), " "),
+		"Date: " + event.Time.Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	for _, header := range headers {
+		buffer.WriteString(header + "\r\n")
+	}
+	buffer.WriteString("\r\n")
+	for _, line := range strings.Split(body.String(), "\n") {
+		buffer.WriteString(strings.TrimSuffix(line, "\r") + "\r\n")
+	}
+
+	return buffer.Bytes(), nil
+}
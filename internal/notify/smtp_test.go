@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SMTP_buildMessage(t *testing.T) {
+	t.Parallel()
+
+	settings := SMTPSettings{
+		From:            "ddns@example.com",
+		To:              []string{"a@example.com", "b@example.com"},
+		SubjectTemplate: "{{.FQDN}} {{if .Failed}}failed{{else}}changed to {{.NewIP}}{{end}}",
+		BodyTemplate:    "{{.Message}}\nBye",
+	}
+	eventTime := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		event   Event
+		message string
+	}{
+		"ip_change": {
+			event: Event{
+				Domain:   "example.com",
+				Host:     "@",
+				Provider: "njalla",
+				OldIP:    netip.MustParseAddr("1.2.3.4"),
+				NewIP:    netip.MustParseAddr("5.6.7.8"),
+				Time:     eventTime,
+			},
+			message: "From: ddns@example.com\r\n" +
+				"To: a@example.com, b@example.com\r\n" +
+				"Subject: example.com changed to 5.6.7.8\r\n" +
+				"Date: Fri, 01 Mar 2024 10:00:00 +0000\r\n" +
+				"MIME-Version: 1.0\r\n" +
+				"Content-Type: text/plain; charset=UTF-8\r\n" +
+				"\r\n" +
+				"example.com (njalla) changed from 1.2.3.4 to 5.6.7.8\r\n" +
+				"Bye\r\n",
+		},
+		"failure": {
+			event: Event{
+				Domain:   "example.com",
+				Host:     "sub",
+				Provider: "njalla",
+				NewIP:    netip.MustParseAddr("5.6.7.8"),
+				Time:     eventTime,
+				Err:      errors.New("bad\r\nSubject: injected"),
+			},
+			message: "From: ddns@example.com\r\n" +
+				"To: a@example.com, b@example.com\r\n" +
+				"Subject: sub.example.com failed\r\n" +
+				"Date: Fri, 01 Mar 2024 10:00:00 +0000\r\n" +
+				"MIME-Version: 1.0\r\n" +
+				"Content-Type: text/plain; charset=UTF-8\r\n" +
+				"\r\n" +
+				"sub.example.com (njalla) failed updating to 5.6.7.8: bad\r\n" +
+				"Subject: injected\r\n" +
+				"Bye\r\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			smtp, err := NewSMTP(settings)
+			require.NoError(t, err)
+
+			message, err := smtp.buildMessage(testCase.event)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.message, string(message))
+		})
+	}
+}
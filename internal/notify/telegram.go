@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Telegram sends a message describing the event to a Telegram chat.
+type Telegram struct {
+	client   *http.Client
+	botToken string
+	chatID   string
+}
+
+func NewTelegram(client *http.Client, botToken, chatID string) *Telegram {
+	return &Telegram{
+		client:   client,
+		botToken: botToken,
+		chatID:   chatID,
+	}
+}
+
+var ErrTelegramResponse = errors.New("telegram request failed")
+
+func (t *Telegram) Notify(ctx context.Context, event Event) (err error) {
+	requestBody := struct {
+		ChatID string `json:"chat_id"`
+		Text   string `json:"text"`
+	}{
+		ChatID: t.chatID,
+		Text:   event.Message(),
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err = json.NewEncoder(buffer).Encode(requestBody)
+	if err != nil {
+		return fmt.Errorf("json encoding telegram request body: %w", err)
+	}
+
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.telegram.org",
+		Path:   "/bot" + t.botToken + "/sendMessage",
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), buffer)
+	if err != nil {
+		return fmt.Errorf("creating telegram request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		// do not leak the bot token present in the URL
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("doing telegram request: %w", err)
+	}
+	defer response.Body.Close()
+
+	var responseBody struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	if err != nil {
+		return fmt.Errorf("json decoding telegram response body: %w", err)
+	}
+
+	if !responseBody.OK {
+		return fmt.Errorf("%w: %d: %s", ErrTelegramResponse,
+			response.StatusCode, responseBody.Description)
+	}
+
+	return nil
+}
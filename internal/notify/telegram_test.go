@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectTransport sends all requests to the server given,
+// keeping their path and query.
+type redirectTransport struct {
+	server *httptest.Server
+}
+
+func (r *redirectTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	serverURL, err := url.Parse(r.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	request = request.Clone(request.Context())
+	request.URL.Scheme = serverURL.Scheme
+	request.URL.Host = serverURL.Host
+	return r.server.Client().Transport.RoundTrip(request)
+}
+
+func Test_Telegram_Notify(t *testing.T) {
+	t.Parallel()
+
+	event := Event{
+		Domain:   "example.com",
+		Host:     "@",
+		Provider: "njalla",
+		NewIP:    netip.MustParseAddr("1.2.3.4"),
+	}
+
+	testCases := map[string]struct {
+		statusCode   int
+		responseBody string
+		errWrapped   error
+		errMessage   string
+	}{
+		"success": {
+			statusCode:   http.StatusOK,
+			responseBody: `{"ok":true}`,
+		},
+		"not_ok": {
+			statusCode:   http.StatusBadRequest,
+			responseBody: `{"ok":false,"description":"Bad Request: chat not found"}`,
+			errWrapped:   ErrTelegramResponse,
+			errMessage:   "telegram request failed: 400: Bad Request: chat not found",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/bottoken/sendMessage", r.URL.Path)
+				var body struct {
+					ChatID string `json:"chat_id"`
+					Text   string `json:"text"`
+				}
+				err := json.NewDecoder(r.Body).Decode(&body)
+				assert.NoError(t, err)
+				assert.Equal(t, "chat", body.ChatID)
+				assert.Equal(t, "example.com (njalla) changed to 1.2.3.4", body.Text)
+				w.WriteHeader(testCase.statusCode)
+				_, _ = w.Write([]byte(testCase.responseBody))
+			}))
+			t.Cleanup(server.Close)
+
+			client := &http.Client{Transport: &redirectTransport{server: server}}
+			telegram := NewTelegram(client, "token", "chat")
+
+			err := telegram.Notify(context.Background(), event)
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+)
+
+// Webhook sends a JSON payload describing IP address change
+// events to a URL.
+type Webhook struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func NewWebhook(client *http.Client, url string, headers map[string]string) *Webhook {
+	return &Webhook{
+		client:  client,
+		url:     url,
+		headers: headers,
+	}
+}
+
+var ErrHTTPStatusNotValid = errors.New("HTTP status is not valid")
+
+func (w *Webhook) Notify(ctx context.Context, event Event) (err error) {
+	if event.Failed() {
+		return nil
+	}
+
+	payload := struct {
+		Domain    string          `json:"domain"`
+		Host      string          `json:"host"`
+		Provider  models.Provider `json:"provider"`
+		OldIP     netip.Addr      `json:"old_ip"`
+		NewIP     netip.Addr      `json:"new_ip"`
+		Timestamp time.Time       `json:"timestamp"`
+	}{
+		Domain:    event.Domain,
+		Host:      event.Host,
+		Provider:  event.Provider,
+		OldIP:     event.OldIP,
+		NewIP:     event.NewIP,
+		Timestamp: event.Time,
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err = json.NewEncoder(buffer).Encode(payload)
+	if err != nil {
+		return fmt.Errorf("json encoding webhook payload: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, buffer)
+	if err != nil {
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range w.headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("doing webhook request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		b, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("webhook: %w: %d: %s", ErrHTTPStatusNotValid,
+			response.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	return nil
+}
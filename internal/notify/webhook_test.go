@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Webhook_Notify(t *testing.T) {
+	t.Parallel()
+
+	event := Event{
+		Domain:   "example.com",
+		Host:     "@",
+		Provider: "njalla",
+		OldIP:    netip.MustParseAddr("1.2.3.4"),
+		NewIP:    netip.MustParseAddr("5.6.7.8"),
+		Time:     time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC),
+	}
+	failedEvent := event
+	failedEvent.Err = errors.New("test error")
+
+	testCases := map[string]struct {
+		event      Event
+		statusCode int
+		requests   int32
+		errWrapped error
+		errMessage string
+	}{
+		"ip_change": {
+			event:      event,
+			statusCode: http.StatusOK,
+			requests:   1,
+		},
+		"failure_event_skipped": {
+			event: failedEvent,
+		},
+		"bad_status": {
+			event:      event,
+			statusCode: http.StatusInternalServerError,
+			requests:   1,
+			errWrapped: ErrHTTPStatusNotValid,
+			errMessage: "webhook: HTTP status is not valid: 500: server error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				assert.Equal(t, "secret", r.Header.Get("X-Token"))
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"domain":"example.com","host":"@","provider":"njalla",`+
+					`"old_ip":"1.2.3.4","new_ip":"5.6.7.8","timestamp":"2024-03-01T10:00:00Z"}`, string(body))
+				w.WriteHeader(testCase.statusCode)
+				if testCase.statusCode != http.StatusOK {
+					_, _ = w.Write([]byte("server error"))
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			webhook := NewWebhook(server.Client(), server.URL, map[string]string{"X-Token": "secret"})
+
+			err := webhook.Notify(context.Background(), testCase.event)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.requests, requests.Load())
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrEnvReferenceNotClosed = errors.New("environment variable reference is not closed")
+	ErrEnvReferenceNameEmpty = errors.New("environment variable reference name is empty")
+	ErrEnvVariableNotSet     = errors.New("environment variable is not set")
+)
+
+// substituteEnv replaces environment variable references in all the
+// string values of the JSON content given. See expandEnv for the syntax.
+func substituteEnv(jsonBytes []byte,
+	lookupEnv func(key string) (value string, ok bool)) (
+	substituted []byte, err error) {
+	if !bytes.Contains(jsonBytes, []byte("${")) {
+		return jsonBytes, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber() // keep numbers as they are written
+	var content any
+	err = decoder.Decode(&content)
+	if err != nil {
+		return nil, fmt.Errorf("decoding JSON: %w", err)
+	}
+
+	content, err = substituteEnvValue(content, lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(content)
+}
+
+func substituteEnvValue(value any,
+	lookupEnv func(key string) (value string, ok bool)) (
+	substituted any, err error) {
+	switch typedValue := value.(type) {
+	case string:
+		return expandEnv(typedValue, lookupEnv)
+	case []any:
+		for i, element := range typedValue {
+			typedValue[i], err = substituteEnvValue(element, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return typedValue, nil
+	case map[string]any:
+		for key, element := range typedValue {
+			typedValue[key], err = substituteEnvValue(element, lookupEnv)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", key, err)
+			}
+		}
+		return typedValue, nil
+	default:
+		return value, nil
+	}
+}
+
+// expandEnv replaces each ${VAR} reference in the string given with the
+// value of the environment variable VAR, and each ${VAR:-default}
+// reference with the value of VAR or with default if VAR is unset or
+// empty. A literal ${ can be written as $${.
+func expandEnv(s string,
+	lookupEnv func(key string) (value string, ok bool)) (
+	expanded string, err error) {
+	var builder strings.Builder
+	for {
+		index := strings.Index(s, "${")
+		if index == -1 {
+			builder.WriteString(s)
+			return builder.String(), nil
+		}
+
+		if index > 0 && s[index-1] == '$' { // escaped $${
+			builder.WriteString(s[:index-1])
+			builder.WriteString("${")
+			s = s[index+len("${"):]
+			continue
+		}
+
+		builder.WriteString(s[:index])
+		s = s[index+len("${"):]
+		end := strings.Index(s, "}")
+		if end == -1 {
+			return "", fmt.Errorf("%w: ${%s", ErrEnvReferenceNotClosed, s)
+		}
+		reference := s[:end]
+		s = s[end+len("}"):]
+
+		name, defaultValue, hasDefault := strings.Cut(reference, ":-")
+		if name == "" {
+			return "", fmt.Errorf("%w: ${%s}", ErrEnvReferenceNameEmpty, reference)
+		}
+		value, ok := lookupEnv(name)
+		switch {
+		case ok && (value != "" || !hasDefault):
+			builder.WriteString(value)
+		case hasDefault:
+			builder.WriteString(defaultValue)
+		default:
+			return "", fmt.Errorf("%w: %s", ErrEnvVariableNotSet, name)
+		}
+	}
+}
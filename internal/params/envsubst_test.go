@@ -0,0 +1,105 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandEnv(t *testing.T) {
+	t.Parallel()
+
+	lookupEnv := func(key string) (value string, ok bool) {
+		env := map[string]string{
+			"TOKEN": "abc",
+			"EMPTY": "",
+		}
+		value, ok = env[key]
+		return value, ok
+	}
+
+	testCases := map[string]struct {
+		s          string
+		expanded   string
+		errWrapped error
+		errMessage string
+	}{
+		"no_reference": {
+			s:        "plain $text",
+			expanded: "plain $text",
+		},
+		"reference": {
+			s:        "token-${TOKEN}-suffix",
+			expanded: "token-abc-suffix",
+		},
+		"empty_variable": {
+			s:        "x${EMPTY}x",
+			expanded: "xx",
+		},
+		"default_unset": {
+			s:        "${UNSET:-fallback}",
+			expanded: "fallback",
+		},
+		"default_empty": {
+			s:        "${EMPTY:-fallback}",
+			expanded: "fallback",
+		},
+		"default_set": {
+			s:        "${TOKEN:-fallback}",
+			expanded: "abc",
+		},
+		"escaped": {
+			s:        "$${TOKEN} ${TOKEN}",
+			expanded: "${TOKEN} abc",
+		},
+		"unset": {
+			s:          "${UNSET}",
+			errWrapped: ErrEnvVariableNotSet,
+			errMessage: "environment variable is not set: UNSET",
+		},
+		"not_closed": {
+			s:          "${TOKEN",
+			errWrapped: ErrEnvReferenceNotClosed,
+			errMessage: "environment variable reference is not closed: ${TOKEN",
+		},
+		"empty_name": {
+			s:          "${:-x}",
+			errWrapped: ErrEnvReferenceNameEmpty,
+			errMessage: "environment variable reference name is empty: ${:-x}",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			expanded, err := expandEnv(testCase.s, lookupEnv)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.expanded, expanded)
+		})
+	}
+}
+
+func Test_substituteEnv(t *testing.T) {
+	t.Parallel()
+
+	lookupEnv := func(key string) (string, bool) {
+		if key == "KEY" {
+			return `a"b`, true
+		}
+		return "", false
+	}
+
+	substituted, err := substituteEnv(
+		[]byte(`{"settings":[{"key":"${KEY}","ttl":300,"hosts":["${UNSET:-@}"]}]}`),
+		lookupEnv)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"settings":[{"key":"a\"b","ttl":300,"hosts":["@"]}]}`,
+		string(substituted))
+}
@@ -7,32 +7,258 @@ import (
 	"fmt"
 	"io/fs"
 	"net/netip"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/chmike/domain"
+	"github.com/qdm12/ddns-updater/internal/config"
+	"github.com/qdm12/ddns-updater/internal/cron"
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/ratelimit"
 	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
 )
 
 type commonSettings struct {
-	Provider   string       `json:"provider"`
-	Domain     string       `json:"domain"`
-	Host       string       `json:"host"`
-	IPVersion  string       `json:"ip_version"`
-	IPv6Suffix netip.Prefix `json:"ipv6_suffix,omitempty"`
+	Provider   string         `json:"provider"`
+	Domain     string         `json:"domain"`
+	Host       hosts          `json:"host"`
+	IPVersion  string         `json:"ip_version"`
+	IPv6Suffix string         `json:"ipv6_suffix,omitempty"`
+	Retry      *retrySettings `json:"retry,omitempty"`
+	// RateLimit overrides the default rate limit of the provider
+	// for the record, and is nil to use the provider default.
+	RateLimit *rateLimitSettings `json:"rate_limit,omitempty"`
+	// HealthcheckURL is the URL to ping after each successful update
+	// of the record, and with the /fail suffix after a failed update.
+	HealthcheckURL string `json:"healthcheck_url,omitempty"`
+	// HTTPTimeout is the maximum duration of each update attempt of
+	// the record, overriding the program HTTP timeout when set.
+	HTTPTimeout *string `json:"http_timeout,omitempty"`
+	// ProxyURL is the proxy URL to use for the record provider,
+	// overriding the program proxy when set. It can be set to the
+	// empty string to not use any proxy.
+	ProxyURL *string `json:"proxy_url,omitempty"`
+	// Interval is the duration between update checks of the record,
+	// overriding the program period when set.
+	Interval *string `json:"interval,omitempty"`
+	// Cron is the cron expression scheduling the update checks
+	// of the record, taking precedence over the interval when set.
+	Cron string `json:"cron,omitempty"`
+	// DryRun is true to log the record updates without calling the
+	// provider, overriding the program dry run setting when set.
+	DryRun *bool `json:"dry_run,omitempty"`
+	// SkipUnchanged is true to not update the record if its last update
+	// succeeded with the same public IP address, overriding the program
+	// setting when set. ForceInterval is the duration after which it is
+	// updated anyway, such as "24h", and "0s" to never force it.
+	SkipUnchanged *bool   `json:"skip_unchanged,omitempty"`
+	ForceInterval *string `json:"force_interval,omitempty"`
+	// UpdateOnStart is false to not update the record at program start,
+	// overriding the program setting when set.
+	UpdateOnStart *bool `json:"update_on_start,omitempty"`
+	// RecordType is the type of record to set to Content or Target instead
+	// of to the public IP address, for example "TXT" or "CNAME". It is empty
+	// to update A or AAAA records with the public IP address.
+	RecordType string `json:"record_type,omitempty"`
+	Content    string `json:"content,omitempty"`
+	// Target is the hostname a CNAME record points to.
+	Target string `json:"target,omitempty"`
 	// Retro values for warnings
 	IPMethod *string `json:"ip_method,omitempty"`
 	Delay    *uint64 `json:"delay,omitempty"`
 }
 
+// retrySettings are the retry settings of a record,
+// overriding the program retry settings when set.
+type retrySettings struct {
+	MaxAttempts *uint    `json:"max_attempts,omitempty"`
+	BaseDelay   *string  `json:"base_delay,omitempty"`
+	MaxDelay    *string  `json:"max_delay,omitempty"`
+	Multiplier  *float64 `json:"multiplier,omitempty"`
+}
+
+// hosts is the host JSON field, which can be a host, a comma separated
+// list of hosts, or an array of hosts.
+type hosts []string
+
+func (h *hosts) UnmarshalJSON(data []byte) (err error) {
+	var host string
+	err = json.Unmarshal(data, &host)
+	if err == nil {
+		*h = strings.Split(host, ",")
+		return nil
+	}
+
+	var hostsSlice []string
+	err = json.Unmarshal(data, &hostsSlice)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrHostNotValid, err)
+	} else if len(hostsSlice) == 0 {
+		return fmt.Errorf("%w: array is empty", ErrHostNotValid)
+	}
+	for i, host := range hostsSlice {
+		if strings.TrimSpace(host) == "" {
+			return fmt.Errorf("%w: array element %d is empty", ErrHostNotValid, i+1)
+		}
+	}
+	*h = hostsSlice
+	return nil
+}
+
+func (h hosts) String() string {
+	return strings.Join(h, ",")
+}
+
+func (r *retrySettings) toConfig() (retry config.Retry, err error) {
+	if r == nil {
+		return retry, nil
+	}
+
+	retry.MaxAttempts = r.MaxAttempts
+	retry.Multiplier = r.Multiplier
+	retry.BaseDelay, err = parseDurationPtr(r.BaseDelay)
+	if err != nil {
+		return retry, fmt.Errorf("parsing base delay: %w", err)
+	}
+	retry.MaxDelay, err = parseDurationPtr(r.MaxDelay)
+	if err != nil {
+		return retry, fmt.Errorf("parsing maximum delay: %w", err)
+	}
+
+	err = retry.Validate()
+	if err != nil {
+		return retry, err
+	}
+	return retry, nil
+}
+
+type rateLimitSettings struct {
+	Requests *uint   `json:"requests,omitempty"`
+	Interval *string `json:"interval,omitempty"`
+	Bucket   string  `json:"bucket,omitempty"`
+}
+
+var ErrRateLimitIntervalNotPositive = errors.New("rate limit interval must be positive")
+
+type rateLimitBucket struct {
+	provider models.Provider
+	bucket   string
+}
+
+var ErrRateLimitBucketConflict = errors.New("rate limit differs from a previous record of the same bucket")
+
+// checkRateLimitBucket returns an error if the rate limit of the providers
+// given differs from the one of a previous record of the same provider and
+// bucket, since these records share the same limiter. The buckets map is
+// updated with the rate limit of buckets used for the first time.
+func checkRateLimitBucket(buckets map[rateLimitBucket]models.RateLimitSettings,
+	providers []ProviderSettings) (err error) {
+	for _, provider := range providers {
+		key := rateLimitBucket{provider: provider.Name, bucket: provider.RateLimit.Bucket}
+		existing, ok := buckets[key]
+		if !ok {
+			buckets[key] = provider.RateLimit
+			continue
+		} else if existing.Requests == provider.RateLimit.Requests &&
+			existing.Interval == provider.RateLimit.Interval {
+			continue
+		}
+		return fmt.Errorf("%w: %d requests per %s instead of %d requests per %s, "+
+			"use a different bucket name for a different rate limit",
+			ErrRateLimitBucketConflict, provider.RateLimit.Requests, provider.RateLimit.Interval,
+			existing.Requests, existing.Interval)
+	}
+	return nil
+}
+
+// toModel returns the rate limit settings of the record, using the
+// default rate limit of the provider given for fields left unset.
+func (r *rateLimitSettings) toModel(providerName models.Provider) (
+	rateLimit models.RateLimitSettings, err error) {
+	rateLimit = ratelimit.Default(providerName)
+	if r == nil {
+		return rateLimit, nil
+	}
+
+	if r.Requests != nil {
+		rateLimit.Requests = *r.Requests
+	}
+	interval, err := parseDurationPtr(r.Interval)
+	if err != nil {
+		return rateLimit, fmt.Errorf("parsing interval: %w", err)
+	} else if interval != nil {
+		rateLimit.Interval = *interval
+	}
+	rateLimit.Bucket = r.Bucket
+
+	if rateLimit.Requests > 0 && rateLimit.Interval <= 0 {
+		return rateLimit, fmt.Errorf("%w: %s", ErrRateLimitIntervalNotPositive, rateLimit.Interval)
+	}
+	return rateLimit, nil
+}
+
+func parseDurationPtr(s *string) (duration *time.Duration, err error) {
+	if s == nil {
+		return nil, nil //nolint:nilnil
+	}
+	d, err := time.ParseDuration(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ProviderSettings contains a provider and the settings specific to
+// its record, which override the program settings when set.
+type ProviderSettings struct {
+	Provider provider.Provider
+	Name     models.Provider
+	Retry    config.Retry
+	// RateLimit is the rate limit of the record, which is the
+	// provider default rate limit if not set.
+	RateLimit models.RateLimitSettings
+	// HealthcheckURL is the healthcheck URL of the record, and is empty
+	// if not set.
+	HealthcheckURL string
+	// HTTPTimeout is the HTTP timeout of the record, and is nil
+	// if not set.
+	HTTPTimeout *time.Duration
+	// ProxyURL is the proxy URL of the record, and is nil if not set.
+	ProxyURL *string
+	// Interval is the update check interval of the record, and is nil
+	// if not set.
+	Interval *time.Duration
+	// Cron is the cron schedule of the record, and is nil if not set.
+	Cron *cron.Schedule
+	// DryRun is the dry run setting of the record, and is nil if not set.
+	DryRun *bool
+	// SkipUnchanged and ForceInterval are the skip unchanged settings
+	// of the record, and are nil if not set.
+	SkipUnchanged *bool
+	ForceInterval *time.Duration
+	// UpdateOnStart is the update on start setting of the record,
+	// and is nil if not set.
+	UpdateOnStart *bool
+	// RecordType is the record type to set to Content, and is empty
+	// for A and AAAA records set to the public IP address. For CNAME
+	// records, Content is the fully qualified target hostname ending
+	// with a dot.
+	RecordType string
+	Content    string
+	// Raw is the raw JSON settings of the record, with secret files
+	// and environment variables resolved.
+	Raw json.RawMessage
+}
+
 // JSONProviders obtain the update settings from the JSON content,
 // first trying from the environment variable CONFIG and then from
-// the file config.json.
+// the file at the path given, which can also be a YAML file.
 func (r *Reader) JSONProviders(filePath string) (
-	providers []provider.Provider, warnings []string, err error) {
+	providers []ProviderSettings, warnings []string, err error) {
 	providers, warnings, err = r.getProvidersFromEnv(filePath)
 	if providers != nil || warnings != nil || err != nil {
 		return providers, warnings, err
@@ -40,11 +266,39 @@ func (r *Reader) JSONProviders(filePath string) (
 	return r.getProvidersFromFile(filePath)
 }
 
+// JSONProvidersFromFile obtains the update settings from the JSON
+// file only, ignoring the environment variable CONFIG.
+func (r *Reader) JSONProvidersFromFile(filePath string) (
+	providers []ProviderSettings, warnings []string, err error) {
+	return r.getProvidersFromFile(filePath)
+}
+
+// ValidateJSON validates the JSON settings of all the records, first
+// trying from the environment variable CONFIG and then from the file
+// at the path given, without writing any file. It returns an error for
+// each record with invalid settings in recordErrors, and returns err
+// if the JSON settings cannot be read or parsed.
+func (r *Reader) ValidateJSON(filePath string) (providers []ProviderSettings,
+	warnings []string, recordErrors []RecordError, err error) {
+	jsonBytes := []byte(os.Getenv("CONFIG"))
+	if len(jsonBytes) == 0 {
+		jsonBytes, err = r.readFile(filePath)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		jsonBytes, err = decodeConfigFile(filePath, jsonBytes)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return extractRecordsSettings(jsonBytes, r.readFile, r.lookupEnv)
+}
+
 var errWriteConfigToFile = errors.New("cannot write configuration to file")
 
 // getProvidersFromFile obtain the update settings from config.json.
 func (r *Reader) getProvidersFromFile(filePath string) (
-	providers []provider.Provider, warnings []string, err error) {
+	providers []ProviderSettings, warnings []string, err error) {
 	r.logger.Info("reading JSON config from file " + filePath)
 	bytes, err := r.readFile(filePath)
 	if err != nil {
@@ -64,13 +318,18 @@ func (r *Reader) getProvidersFromFile(filePath string) (
 	}
 	r.logger.Debug("config read: " + string(bytes))
 
-	return extractAllSettings(bytes)
+	bytes, err = decodeConfigFile(filePath, bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return extractAllSettings(bytes, r.readFile, r.lookupEnv)
 }
 
 // getProvidersFromEnv obtain the update settings from the environment variable CONFIG.
 // If the settings are valid, they are written to the filePath.
 func (r *Reader) getProvidersFromEnv(filePath string) (
-	providers []provider.Provider, warnings []string, err error) {
+	providers []ProviderSettings, warnings []string, err error) {
 	s := os.Getenv("CONFIG")
 	if s == "" {
 		return nil, nil, nil
@@ -80,7 +339,7 @@ func (r *Reader) getProvidersFromEnv(filePath string) (
 
 	b := []byte(s)
 
-	providers, warnings, err = extractAllSettings(b)
+	providers, warnings, err = extractAllSettings(b, r.readFile, r.lookupEnv)
 	if err != nil {
 		return providers, warnings, fmt.Errorf("configuration given: %w", err)
 	}
@@ -104,49 +363,128 @@ var (
 	errUnmarshalRaw    = errors.New("cannot unmarshal raw configuration")
 )
 
-func extractAllSettings(jsonBytes []byte) (
-	allProviders []provider.Provider, warnings []string, err error) {
+func extractAllSettings(jsonBytes []byte,
+	readFile func(filename string) ([]byte, error),
+	lookupEnv func(key string) (value string, ok bool)) (
+	allProviders []ProviderSettings, warnings []string, err error) {
+	allProviders, warnings, recordErrors, err := extractRecordsSettings(jsonBytes,
+		readFile, lookupEnv)
+	if err != nil {
+		return nil, warnings, err
+	} else if len(recordErrors) > 0 {
+		return nil, warnings, recordErrors[0].Err
+	}
+	return allProviders, warnings, nil
+}
+
+// RecordError is an error for the record settings at
+// the index Index in the JSON settings array.
+type RecordError struct {
+	Index    int
+	Provider string
+	Domain   string
+	Host     string
+	Err      error
+}
+
+func (r RecordError) Error() string {
+	return fmt.Sprintf("record %d (provider %s, domain %s, host %s): %s",
+		r.Index+1, r.Provider, r.Domain, r.Host, r.Err)
+}
+
+func (r RecordError) Unwrap() error {
+	return r.Err
+}
+
+// extractRecordsSettings extracts the settings of all the records,
+// returning an error for each record with invalid settings in
+// recordErrors, and returning err only if the JSON content as a
+// whole cannot be used.
+func extractRecordsSettings(jsonBytes []byte,
+	readFile func(filename string) ([]byte, error),
+	lookupEnv func(key string) (value string, ok bool)) (
+	allProviders []ProviderSettings, warnings []string,
+	recordErrors []RecordError, err error) {
 	config := struct {
 		CommonSettings []commonSettings `json:"settings"`
 	}{}
 	rawConfig := struct {
 		Settings []json.RawMessage `json:"settings"`
 	}{}
+	jsonBytes, err = substituteEnv(jsonBytes, lookupEnv)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("substituting environment variables: %w", err)
+	}
 	err = json.Unmarshal(jsonBytes, &config)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%w: %w", errUnmarshalCommon, err)
+		return nil, nil, nil, fmt.Errorf("%w: %w", errUnmarshalCommon, err)
 	}
 	err = json.Unmarshal(jsonBytes, &rawConfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%w: %w", errUnmarshalRaw, err)
+		return nil, nil, nil, fmt.Errorf("%w: %w", errUnmarshalRaw, err)
 	}
 	// TODO(v3): remove retro compatibility with IPV6_PREFIX
 	retroIPv6Suffix, err := getRetroIPv6Suffix()
 	if err != nil {
-		return nil, nil, fmt.Errorf("getting retro-compatible global IPV6 suffix: %w", err)
+		return nil, nil, nil, fmt.Errorf("getting retro-compatible global IPV6 suffix: %w", err)
 	}
 
+	rateLimitBuckets := make(map[rateLimitBucket]models.RateLimitSettings)
 	for i, common := range config.CommonSettings {
-		newProvider, newWarnings, err := makeSettingsFromObject(common, rawConfig.Settings[i],
+		recordError := RecordError{
+			Index:    i,
+			Provider: common.Provider,
+			Domain:   common.Domain,
+			Host:     common.Host.String(),
+		}
+		rawSettings, err := resolveSecretFiles(rawConfig.Settings[i], readFile)
+		if err != nil {
+			recordError.Err = fmt.Errorf("resolving secret files for provider %s: %w",
+				common.Provider, err)
+			recordErrors = append(recordErrors, recordError)
+			continue
+		}
+		newProvider, newWarnings, err := makeSettingsFromObject(common, rawSettings,
 			retroIPv6Suffix)
 		warnings = append(warnings, newWarnings...)
 		if err != nil {
-			return nil, warnings, err
+			recordError.Err = err
+			recordErrors = append(recordErrors, recordError)
+			continue
+		}
+		err = checkRateLimitBucket(rateLimitBuckets, newProvider)
+		if err != nil {
+			recordError.Err = err
+			recordErrors = append(recordErrors, recordError)
+			continue
 		}
 		allProviders = append(allProviders, newProvider...)
 	}
 
-	return allProviders, warnings, nil
+	return allProviders, warnings, recordErrors, nil
 }
 
 var (
-	ErrProviderNoLongerSupported = errors.New("provider no longer supported")
-	ErrDomainBlank               = errors.New("domain cannot be blank for provider")
+	ErrProviderNoLongerSupported    = errors.New("provider no longer supported")
+	ErrHostNotValid                 = errors.New("host must be a string or an array of strings")
+	ErrHealthcheckURLSchemeNotValid = errors.New("healthcheck URL scheme is not valid")
+	ErrDomainBlank                  = errors.New("domain cannot be blank for provider")
+	ErrIPv6SuffixNotIPv6            = errors.New("IPv6 suffix is not an IPv6 address")
+	ErrHTTPTimeoutNegative          = errors.New("HTTP timeout cannot be negative")
+	ErrIntervalNotPositive          = errors.New("interval must be positive")
+	ErrForceIntervalNegative        = errors.New("force interval cannot be negative")
+	ErrRecordTypeNotValid           = errors.New("record type is not valid")
+	ErrRecordTypeNotSupported       = errors.New("record type is not supported by provider")
+	ErrContentNotSet                = errors.New("content is not set")
+	ErrTargetNotSet                 = errors.New("target is not set")
+	ErrTargetNotValid               = errors.New("target is not a valid hostname")
+	ErrRecordValueUnused            = errors.New("record value is not used for record type")
+	ErrCNAMEAtApex                  = errors.New(`CNAME record cannot be set for host "@"`)
 )
 
 func makeSettingsFromObject(common commonSettings, rawSettings json.RawMessage,
 	retroGlobalIPv6Suffix netip.Prefix) (
-	providers []provider.Provider, warnings []string, err error) {
+	providers []ProviderSettings, warnings []string, err error) {
 	if common.Provider == "google" {
 		return nil, nil, fmt.Errorf("%w: %s", ErrProviderNoLongerSupported, common.Provider)
 	}
@@ -165,19 +503,22 @@ func makeSettingsFromObject(common commonSettings, rawSettings json.RawMessage,
 	providerName := models.Provider(common.Provider)
 	if providerName == constants.DuckDNS { // only hosts, no domain
 		if common.Domain != "" { // retro compatibility
-			if common.Host == "" {
-				common.Host = strings.TrimSuffix(common.Domain, ".duckdns.org")
+			if common.Host.String() == "" {
+				common.Host = hosts{strings.TrimSuffix(common.Domain, ".duckdns.org")}
 				warnings = append(warnings,
 					fmt.Sprintf("DuckDNS record should have %q specified as host instead of %q as domain",
-						common.Host, common.Domain))
+						common.Host.String(), common.Domain))
 			} else {
 				warnings = append(warnings,
 					fmt.Sprintf("ignoring domain %q because host %q is specified for DuckDNS record",
-						common.Domain, common.Host))
+						common.Domain, common.Host.String()))
 			}
 		}
 	}
-	hosts := strings.Split(common.Host, ",")
+	hostsSlice := []string(common.Host)
+	if len(hostsSlice) == 0 { // host field not set
+		hostsSlice = []string{""}
+	}
 
 	if common.IPVersion == "" {
 		common.IPVersion = ipversion.IP4or6.String()
@@ -187,7 +528,10 @@ func makeSettingsFromObject(common commonSettings, rawSettings json.RawMessage,
 		return nil, nil, err
 	}
 
-	ipv6Suffix := common.IPv6Suffix
+	ipv6Suffix, err := parseIPv6Suffix(common.IPv6Suffix)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing IPv6 suffix: %w", err)
+	}
 	if !ipv6Suffix.IsValid() {
 		ipv6Suffix = retroGlobalIPv6Suffix
 	}
@@ -198,14 +542,190 @@ func makeSettingsFromObject(common commonSettings, rawSettings json.RawMessage,
 				ipv6Suffix, ipVersion))
 	}
 
-	providers = make([]provider.Provider, len(hosts))
-	for i, host := range hosts {
+	retry, err := common.Retry.toConfig()
+	if err != nil {
+		return nil, warnings, fmt.Errorf("retry settings: %w", err)
+	}
+
+	rateLimit, err := common.RateLimit.toModel(providerName)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("rate limit settings: %w", err)
+	}
+
+	httpTimeout, err := parseDurationPtr(common.HTTPTimeout)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("parsing HTTP timeout: %w", err)
+	} else if httpTimeout != nil && *httpTimeout < 0 {
+		return nil, warnings, fmt.Errorf("%w: %s", ErrHTTPTimeoutNegative, *httpTimeout)
+	}
+
+	interval, err := parseDurationPtr(common.Interval)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("parsing interval: %w", err)
+	} else if interval != nil && *interval <= 0 {
+		return nil, warnings, fmt.Errorf("%w: %s", ErrIntervalNotPositive, *interval)
+	}
+
+	forceInterval, err := parseDurationPtr(common.ForceInterval)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("parsing force interval: %w", err)
+	} else if forceInterval != nil && *forceInterval < 0 {
+		return nil, warnings, fmt.Errorf("%w: %s", ErrForceIntervalNegative, *forceInterval)
+	}
+
+	var cronSchedule *cron.Schedule
+	if common.Cron != "" {
+		cronSchedule, err = cron.Parse(common.Cron)
+		if err != nil {
+			return nil, warnings, fmt.Errorf("parsing cron expression: %w", err)
+		}
+		if interval != nil {
+			warnings = append(warnings,
+				fmt.Sprintf("ignoring interval %s because cron expression %q is specified",
+					*interval, common.Cron))
+		}
+	}
+
+	recordType, content, err := parseRecordType(common.RecordType, common.Content, common.Target)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	if common.ProxyURL != nil && *common.ProxyURL != "" {
+		_, err = config.ParseProxyURL(*common.ProxyURL)
+		if err != nil {
+			return nil, warnings, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+	}
+
+	if common.HealthcheckURL != "" {
+		healthcheckURL, err := url.Parse(common.HealthcheckURL)
+		if err != nil {
+			return nil, warnings, fmt.Errorf("parsing healthcheck URL: %w", err)
+		} else if healthcheckURL.Scheme != "http" && healthcheckURL.Scheme != "https" {
+			return nil, warnings, fmt.Errorf("%w: %s", ErrHealthcheckURLSchemeNotValid,
+				healthcheckURL.Scheme)
+		}
+	}
+
+	providers = make([]ProviderSettings, len(hostsSlice))
+	for i, host := range hostsSlice {
 		host = strings.TrimSpace(host)
-		providers[i], err = provider.New(providerName, rawSettings, common.Domain,
+		providers[i].Provider, err = provider.New(providerName, rawSettings, common.Domain,
 			host, ipVersion, ipv6Suffix)
 		if err != nil {
 			return nil, warnings, err
 		}
+		if recordType != "" {
+			_, ok := providers[i].Provider.(provider.ValueUpdater)
+			if !ok {
+				return nil, warnings, fmt.Errorf("%w: %s records for provider %s",
+					ErrRecordTypeNotSupported, recordType, providerName)
+			} else if recordType == constants.CNAME && host == "@" {
+				return nil, warnings, fmt.Errorf("%w: domain %s", ErrCNAMEAtApex, common.Domain)
+			}
+		}
+		providers[i].Name = providerName
+		providers[i].Retry = retry
+		providers[i].RateLimit = rateLimit
+		providers[i].HealthcheckURL = common.HealthcheckURL
+		providers[i].HTTPTimeout = httpTimeout
+		providers[i].ProxyURL = common.ProxyURL
+		providers[i].Interval = interval
+		providers[i].Cron = cronSchedule
+		providers[i].DryRun = common.DryRun
+		providers[i].SkipUnchanged = common.SkipUnchanged
+		providers[i].ForceInterval = forceInterval
+		providers[i].UpdateOnStart = common.UpdateOnStart
+		providers[i].RecordType = recordType
+		providers[i].Content = content
+		providers[i].Raw = rawSettings
 	}
 	return providers, warnings, nil
 }
+
+// parseRecordType parses the record type and returns it with the value to
+// set the record to. The record type can be empty for A and AAAA records
+// updated with the public IP address, TXT for a record set to the content
+// given, or CNAME for a record pointing to the target hostname given.
+func parseRecordType(recordType, content, target string) (
+	parsed, value string, err error) {
+	parsed = strings.ToUpper(recordType)
+	switch parsed {
+	case "":
+		switch {
+		case content != "":
+			return "", "", fmt.Errorf("%w: content is only used with a record type", ErrRecordValueUnused)
+		case target != "":
+			return "", "", fmt.Errorf("%w: target is only used with a record type", ErrRecordValueUnused)
+		}
+		return "", "", nil
+	case constants.TXT:
+		if content == "" {
+			return "", "", fmt.Errorf("%w: for %s record", ErrContentNotSet, parsed)
+		} else if target != "" {
+			return "", "", fmt.Errorf("%w: target for %s record", ErrRecordValueUnused, parsed)
+		}
+		return parsed, content, nil
+	case constants.CNAME:
+		if target == "" {
+			return "", "", fmt.Errorf("%w: for %s record", ErrTargetNotSet, parsed)
+		} else if content != "" {
+			return "", "", fmt.Errorf("%w: content for %s record", ErrRecordValueUnused, parsed)
+		}
+		value, err = parseCNAMETarget(target)
+		if err != nil {
+			return "", "", err
+		}
+		return parsed, value, nil
+	default:
+		return "", "", fmt.Errorf("%w: %s", ErrRecordTypeNotValid, recordType)
+	}
+}
+
+// parseCNAMETarget checks the target is a hostname and returns
+// it fully qualified with a trailing dot.
+func parseCNAMETarget(target string) (fqdn string, err error) {
+	hostname := strings.TrimSuffix(target, ".")
+	_, ipErr := netip.ParseAddr(hostname)
+	switch {
+	case ipErr == nil:
+		return "", fmt.Errorf("%w: %s is an IP address", ErrTargetNotValid, target)
+	case hostname == "" || strings.ContainsAny(hostname, "/:@ \t"):
+		return "", fmt.Errorf("%w: %s", ErrTargetNotValid, target)
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		if label == "" {
+			return "", fmt.Errorf("%w: %s has an empty label", ErrTargetNotValid, target)
+		}
+	}
+	return hostname + ".", nil
+}
+
+// parseIPv6Suffix parses an IPv6 suffix such as 0:0:0:0:72ad:8fbb:a54e:bedd/64
+// where the bits are the suffix length. If no bits are specified, such as
+// for ::abcd:1, the suffix length defaults to 64 bits.
+func parseIPv6Suffix(s string) (suffix netip.Prefix, err error) {
+	if s == "" {
+		return netip.Prefix{}, nil
+	}
+
+	if strings.Contains(s, "/") {
+		suffix, err = netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+	} else {
+		const defaultSuffixBits = 64
+		address, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		suffix = netip.PrefixFrom(address, defaultSuffixBits)
+	}
+
+	if !suffix.Addr().Is6() {
+		return netip.Prefix{}, fmt.Errorf("%w: %s", ErrIPv6SuffixNotIPv6, s)
+	}
+	return suffix, nil
+}
@@ -0,0 +1,317 @@
+package params
+
+import (
+	"net/netip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_extractRecordsSettings(t *testing.T) {
+	t.Parallel()
+
+	jsonBytes := []byte(`{"settings":[
+		{"provider":"njalla","domain":"example.com","host":"@","key":"key"},
+		{"provider":"njalla","domain":"example.com","host":"a"},
+		{"provider":"njalla","domain":"example.com","host":"b","key":"key","cron":"bad"}
+	]}`)
+
+	providers, _, recordErrors, err := extractRecordsSettings(jsonBytes,
+		os.ReadFile, os.LookupEnv)
+
+	require.NoError(t, err)
+	assert.Len(t, providers, 1)
+	require.Len(t, recordErrors, 2)
+	assert.EqualError(t, recordErrors[0],
+		"record 2 (provider njalla, domain example.com, host a): key is not set")
+	assert.EqualError(t, recordErrors[1],
+		"record 3 (provider njalla, domain example.com, host b): parsing cron expression: "+
+			`cron expression must have 5 fields: "bad" has 1 fields`)
+}
+
+func Test_extractRecordsSettings_hosts(t *testing.T) {
+	t.Parallel()
+
+	jsonBytes := []byte(`{"settings":[
+		{"provider":"njalla","domain":"example.com","host":"@, a","key":"key"},
+		{"provider":"njalla","domain":"example.com","host":["b","c"],"key":"key"},
+		{"provider":"njalla","domain":"example.com","host":["d,e"],"key":"key"}
+	]}`)
+
+	providers, _, recordErrors, err := extractRecordsSettings(jsonBytes,
+		os.ReadFile, os.LookupEnv)
+
+	require.NoError(t, err)
+	assert.Empty(t, recordErrors)
+	hosts := make([]string, len(providers))
+	for i, provider := range providers {
+		hosts[i] = provider.Provider.Host()
+	}
+	// array elements are not split on commas
+	assert.Equal(t, []string{"@", "a", "b", "c", "d,e"}, hosts)
+
+	invalidHosts := map[string]string{
+		"1": "host must be a string or an array of strings: " +
+			"json: cannot unmarshal number into Go value of type []string",
+		"[]":        "host must be a string or an array of strings: array is empty",
+		`["a",""]`:  "host must be a string or an array of strings: array element 2 is empty",
+		`["a"," "]`: "host must be a string or an array of strings: array element 2 is empty",
+	}
+	for host, errMessage := range invalidHosts {
+		jsonBytes = []byte(`{"settings":[{"provider":"njalla","domain":"example.com","host":` + host + `}]}`)
+		_, _, _, err = extractRecordsSettings(jsonBytes, os.ReadFile, os.LookupEnv)
+		assert.ErrorIs(t, err, ErrHostNotValid, host)
+		assert.ErrorContains(t, err, errMessage, host)
+	}
+}
+
+func Test_extractRecordsSettings_rateLimitBucket(t *testing.T) {
+	t.Parallel()
+
+	jsonBytes := []byte(`{"settings":[
+		{"provider":"njalla","domain":"example.com","host":"a","key":"key",
+			"rate_limit":{"requests":10,"interval":"1m"}},
+		{"provider":"njalla","domain":"example.com","host":"b","key":"key",
+			"rate_limit":{"requests":10,"interval":"1m"}},
+		{"provider":"njalla","domain":"example.com","host":"c","key":"key",
+			"rate_limit":{"requests":5,"interval":"1m"}},
+		{"provider":"njalla","domain":"example.com","host":"d","key":"key",
+			"rate_limit":{"requests":5,"interval":"1m","bucket":"other"}}
+	]}`)
+
+	providers, _, recordErrors, err := extractRecordsSettings(jsonBytes,
+		os.ReadFile, os.LookupEnv)
+
+	require.NoError(t, err)
+	hosts := make([]string, len(providers))
+	for i, provider := range providers {
+		hosts[i] = provider.Provider.Host()
+	}
+	assert.Equal(t, []string{"a", "b", "d"}, hosts)
+	require.Len(t, recordErrors, 1)
+	assert.Equal(t, 2, recordErrors[0].Index)
+	assert.ErrorIs(t, recordErrors[0], ErrRateLimitBucketConflict)
+	assert.EqualError(t, recordErrors[0].Err, "rate limit differs from a previous record of the same bucket: "+
+		"5 requests per 1m0s instead of 10 requests per 1m0s, use a different bucket name for a different rate limit")
+}
+
+func Test_extractRecordsSettings_recordType(t *testing.T) {
+	t.Parallel()
+
+	jsonBytes := []byte(`{"settings":[
+		{"provider":"gandi","domain":"example.com","host":"_acme","personal_access_token":"token",
+			"record_type":"txt","content":"value"},
+		{"provider":"gandi","domain":"example.com","host":"a","personal_access_token":"token",
+			"record_type":"txt"},
+		{"provider":"gandi","domain":"example.com","host":"b","personal_access_token":"token",
+			"record_type":"MX","content":"value"},
+		{"provider":"njalla","domain":"example.com","host":"c","key":"key",
+			"record_type":"TXT","content":"value"},
+		{"provider":"hetzner","domain":"example.com","host":"home","token":"token",
+			"record_type":"cname","target":"home.dynamic.net"},
+		{"provider":"hetzner","domain":"example.com","host":"@","token":"token",
+			"record_type":"CNAME","target":"home.dynamic.net"}
+	]}`)
+
+	providers, _, recordErrors, err := extractRecordsSettings(jsonBytes,
+		os.ReadFile, os.LookupEnv)
+
+	require.NoError(t, err)
+	require.Len(t, providers, 2)
+	assert.Equal(t, "TXT", providers[0].RecordType)
+	assert.Equal(t, "value", providers[0].Content)
+	assert.Equal(t, "CNAME", providers[1].RecordType)
+	assert.Equal(t, "home.dynamic.net.", providers[1].Content)
+	require.Len(t, recordErrors, 4)
+	assert.EqualError(t, recordErrors[0],
+		"record 2 (provider gandi, domain example.com, host a): content is not set: for TXT record")
+	assert.EqualError(t, recordErrors[1],
+		"record 3 (provider gandi, domain example.com, host b): record type is not valid: MX")
+	assert.EqualError(t, recordErrors[2],
+		"record 4 (provider njalla, domain example.com, host c): "+
+			"record type is not supported by provider: TXT records for provider njalla")
+	assert.EqualError(t, recordErrors[3],
+		"record 6 (provider hetzner, domain example.com, host @): "+
+			`CNAME record cannot be set for host "@": domain example.com`)
+}
+
+func Test_parseCNAMETarget(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		target     string
+		fqdn       string
+		errWrapped error
+		errMessage string
+	}{
+		"hostname": {
+			target: "home.dynamic.net",
+			fqdn:   "home.dynamic.net.",
+		},
+		"fully_qualified": {
+			target: "home.dynamic.net.",
+			fqdn:   "home.dynamic.net.",
+		},
+		"ip_address": {
+			target:     "1.2.3.4",
+			errWrapped: ErrTargetNotValid,
+			errMessage: "target is not a valid hostname: 1.2.3.4 is an IP address",
+		},
+		"url": {
+			target:     "https://home.dynamic.net",
+			errWrapped: ErrTargetNotValid,
+			errMessage: "target is not a valid hostname: https://home.dynamic.net",
+		},
+		"empty_label": {
+			target:     "home..net",
+			errWrapped: ErrTargetNotValid,
+			errMessage: "target is not a valid hostname: home..net has an empty label",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fqdn, err := parseCNAMETarget(testCase.target)
+
+			assert.Equal(t, testCase.fqdn, fqdn)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_parseIPv6Suffix(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s          string
+		suffix     netip.Prefix
+		errWrapped error
+		errMessage string
+	}{
+		"empty": {},
+		"default_bits": {
+			s:      "::abcd:1",
+			suffix: netip.MustParsePrefix("::abcd:1/64"),
+		},
+		"explicit_bits": {
+			s:      "::abcd:1/80",
+			suffix: netip.MustParsePrefix("::abcd:1/80"),
+		},
+		"ipv4_address": {
+			s:          "1.2.3.4",
+			errWrapped: ErrIPv6SuffixNotIPv6,
+			errMessage: "IPv6 suffix is not an IPv6 address: 1.2.3.4",
+		},
+		"ipv4_prefix": {
+			s:          "1.2.3.4/24",
+			errWrapped: ErrIPv6SuffixNotIPv6,
+			errMessage: "IPv6 suffix is not an IPv6 address: 1.2.3.4/24",
+		},
+		"garbage": {
+			s:          "garbage",
+			errMessage: `ParseAddr("garbage"): unable to parse IP`,
+		},
+		"garbage_bits": {
+			s:          "::abcd:1/x",
+			errMessage: `netip.ParsePrefix("::abcd:1/x"): bad bits after slash: "x"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			suffix, err := parseIPv6Suffix(testCase.s)
+
+			assert.Equal(t, testCase.suffix, suffix)
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_rateLimitSettings_toModel(t *testing.T) {
+	t.Parallel()
+
+	uintPtr := func(n uint) *uint { return &n }
+	stringPtr := func(s string) *string { return &s }
+
+	testCases := map[string]struct {
+		settings   *rateLimitSettings
+		provider   models.Provider
+		rateLimit  models.RateLimitSettings
+		errWrapped error
+		errMessage string
+	}{
+		"provider_default": {
+			provider:  constants.Route53,
+			rateLimit: models.RateLimitSettings{Requests: 5, Interval: time.Second},
+		},
+		"no_default": {
+			provider: constants.Njalla,
+		},
+		"override_requests": {
+			settings: &rateLimitSettings{Requests: uintPtr(2), Bucket: "account"},
+			provider: constants.Route53,
+			rateLimit: models.RateLimitSettings{
+				Requests: 2,
+				Interval: time.Second,
+				Bucket:   "account",
+			},
+		},
+		"disabled": {
+			settings:  &rateLimitSettings{Requests: uintPtr(0)},
+			provider:  constants.Route53,
+			rateLimit: models.RateLimitSettings{Interval: time.Second},
+		},
+		"interval_not_set": {
+			settings:   &rateLimitSettings{Requests: uintPtr(1)},
+			provider:   constants.Njalla,
+			rateLimit:  models.RateLimitSettings{Requests: 1},
+			errWrapped: ErrRateLimitIntervalNotPositive,
+			errMessage: "rate limit interval must be positive: 0s",
+		},
+		"malformed_interval": {
+			settings:   &rateLimitSettings{Interval: stringPtr("x")},
+			provider:   constants.Njalla,
+			errMessage: `parsing interval: time: invalid duration "x"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rateLimit, err := testCase.settings.toModel(testCase.provider)
+
+			assert.Equal(t, testCase.rateLimit, rateLimit)
+			if testCase.errMessage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+			assert.EqualError(t, err, testCase.errMessage)
+		})
+	}
+}
@@ -9,6 +9,7 @@ type Reader struct {
 	logger    Logger
 	readFile  func(filename string) ([]byte, error)
 	writeFile func(filename string, data []byte, perm fs.FileMode) (err error)
+	lookupEnv func(key string) (value string, ok bool)
 }
 
 type Logger interface {
@@ -21,5 +22,6 @@ func NewReader(logger Logger) *Reader {
 		logger:    logger,
 		readFile:  os.ReadFile,
 		writeFile: os.WriteFile,
+		lookupEnv: os.LookupEnv,
 	}
 }
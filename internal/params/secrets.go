@@ -0,0 +1,79 @@
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// secretFileSuffix is the suffix of JSON fields holding the path
+// to a file containing the value of the field without the suffix,
+// for example "key_file" for "key".
+const secretFileSuffix = "_file"
+
+var (
+	ErrSecretFilePathNotString = errors.New("secret file path is not a string")
+	ErrSecretFileAndValueSet   = errors.New("secret file and value are both set")
+	ErrSecretFileEmpty         = errors.New("secret file is empty")
+)
+
+// resolveSecretFiles replaces each field with the "_file" suffix in the
+// raw JSON settings given with the field without the suffix, set to the
+// content of the file at the path value of the field. Surrounding spaces
+// and new lines of the file content are trimmed.
+func resolveSecretFiles(rawSettings json.RawMessage,
+	readFile func(filename string) ([]byte, error)) (
+	resolved json.RawMessage, err error) {
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(rawSettings, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUnmarshalRaw, err)
+	}
+
+	changed := false
+	for key, value := range fields {
+		secretKey, ok := strings.CutSuffix(key, secretFileSuffix)
+		if !ok || secretKey == "" {
+			continue
+		}
+
+		var path string
+		err = json.Unmarshal(value, &path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: for field %q", ErrSecretFilePathNotString, key)
+		} else if path == "" {
+			continue
+		}
+
+		if existing, ok := fields[secretKey]; ok && !isEmptyJSONValue(existing) {
+			return nil, fmt.Errorf("%w: %q and %q", ErrSecretFileAndValueSet, secretKey, key)
+		}
+
+		content, err := readFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading secret file for field %q: %w", secretKey, err)
+		}
+		secret := strings.TrimSpace(string(content))
+		if secret == "" {
+			return nil, fmt.Errorf("%w: %s", ErrSecretFileEmpty, path)
+		}
+
+		fields[secretKey], err = json.Marshal(secret)
+		if err != nil {
+			return nil, fmt.Errorf("encoding secret for field %q: %w", secretKey, err)
+		}
+		delete(fields, key)
+		changed = true
+	}
+
+	if !changed {
+		return rawSettings, nil
+	}
+	return json.Marshal(fields)
+}
+
+func isEmptyJSONValue(value json.RawMessage) bool {
+	trimmed := strings.TrimSpace(string(value))
+	return trimmed == "" || trimmed == "null" || trimmed == `""`
+}
@@ -0,0 +1,84 @@
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resolveSecretFiles(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	readFile := func(filename string) ([]byte, error) {
+		switch filename {
+		case "/run/secrets/key":
+			return []byte("secret\n"), nil
+		case "/run/secrets/empty":
+			return []byte(" \n"), nil
+		default:
+			return nil, errTest
+		}
+	}
+
+	testCases := map[string]struct {
+		rawSettings string
+		resolved    string
+		errWrapped  error
+		errMessage  string
+	}{
+		"no_secret_file": {
+			rawSettings: `{"provider":"njalla","key":"plain"}`,
+			resolved:    `{"provider":"njalla","key":"plain"}`,
+		},
+		"secret_file": {
+			rawSettings: `{"provider":"njalla","key_file":"/run/secrets/key"}`,
+			resolved:    `{"key":"secret","provider":"njalla"}`,
+		},
+		"empty_value_and_secret_file": {
+			rawSettings: `{"key":"","key_file":"/run/secrets/key"}`,
+			resolved:    `{"key":"secret"}`,
+		},
+		"empty_secret_file_path": {
+			rawSettings: `{"key":"plain","key_file":""}`,
+			resolved:    `{"key":"plain","key_file":""}`,
+		},
+		"path_not_string": {
+			rawSettings: `{"key_file":1}`,
+			errWrapped:  ErrSecretFilePathNotString,
+			errMessage:  `secret file path is not a string: for field "key_file"`,
+		},
+		"value_and_secret_file": {
+			rawSettings: `{"key":"plain","key_file":"/run/secrets/key"}`,
+			errWrapped:  ErrSecretFileAndValueSet,
+			errMessage:  `secret file and value are both set: "key" and "key_file"`,
+		},
+		"read_error": {
+			rawSettings: `{"key_file":"/missing"}`,
+			errWrapped:  errTest,
+			errMessage:  `reading secret file for field "key": test error`,
+		},
+		"empty_file": {
+			rawSettings: `{"key_file":"/run/secrets/empty"}`,
+			errWrapped:  ErrSecretFileEmpty,
+			errMessage:  "secret file is empty: /run/secrets/empty",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resolved, err := resolveSecretFiles(json.RawMessage(testCase.rawSettings), readFile)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.resolved, string(resolved))
+		})
+	}
+}
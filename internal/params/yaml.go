@@ -0,0 +1,108 @@
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFilePath returns the path of the records configuration file
+// in the data directory given, which is config.yaml or config.yml if
+// one of them exists, and config.json otherwise.
+func ConfigFilePath(dataDir string,
+	stat func(name string) (os.FileInfo, error)) (path string) {
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		path = filepath.Join(dataDir, name)
+		_, err := stat(path)
+		if err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dataDir, "config.json")
+}
+
+func isYAMLFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeConfigFile returns the JSON content of the configuration file
+// content given, converting it from YAML if the file path has a YAML
+// extension, so both formats share the same parsing and validation.
+func decodeConfigFile(path string, content []byte) (jsonBytes []byte, err error) {
+	if !isYAMLFile(path) {
+		return content, nil
+	}
+	jsonBytes, err = yamlToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("converting YAML to JSON: %w", err)
+	}
+	return jsonBytes, nil
+}
+
+var ErrYAMLKeyNotString = errors.New("YAML mapping key is not a string")
+
+func yamlToJSON(yamlBytes []byte) (jsonBytes []byte, err error) {
+	var content any
+	err = yaml.Unmarshal(yamlBytes, &content)
+	if err != nil {
+		return nil, fmt.Errorf("decoding YAML: %w", err)
+	}
+	if content == nil { // empty document
+		return []byte("{}"), nil
+	}
+
+	content, err = yamlValueToJSONValue(content)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(content)
+}
+
+// yamlValueToJSONValue converts YAML mappings with non-string
+// keys, which cannot be encoded to JSON, to mappings with
+// string keys.
+func yamlValueToJSONValue(value any) (converted any, err error) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, element := range typedValue {
+			typedValue[key], err = yamlValueToJSONValue(element)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", key, err)
+			}
+		}
+		return typedValue, nil
+	case map[any]any:
+		mapping := make(map[string]any, len(typedValue))
+		for key, element := range typedValue {
+			stringKey, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", ErrYAMLKeyNotString, key)
+			}
+			mapping[stringKey], err = yamlValueToJSONValue(element)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", stringKey, err)
+			}
+		}
+		return mapping, nil
+	case []any:
+		for i, element := range typedValue {
+			typedValue[i], err = yamlValueToJSONValue(element)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return typedValue, nil
+	default:
+		return value, nil
+	}
+}
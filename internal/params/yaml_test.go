@@ -0,0 +1,61 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_yamlToJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		yaml       string
+		json       string
+		errWrapped error
+		errMessage string
+	}{
+		"empty": {
+			json: `{}`,
+		},
+		"settings": {
+			yaml: `
+# comment
+settings:
+  - provider: njalla
+    domain: example.com
+    host: "@"
+    ttl: 300
+    provider_ip: true
+    retry:
+      max_attempts: 5
+`,
+			json: `{"settings":[{"provider":"njalla","domain":"example.com","host":"@",` +
+				`"ttl":300,"provider_ip":true,"retry":{"max_attempts":5}}]}`,
+		},
+		"non_string_key": {
+			yaml: `
+settings:
+  - 1: value
+`,
+			errWrapped: ErrYAMLKeyNotString,
+			errMessage: `field "settings": YAML mapping key is not a string: 1`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			jsonBytes, err := yamlToJSON([]byte(testCase.yaml))
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			assert.JSONEq(t, testCase.json, string(jsonBytes))
+		})
+	}
+}
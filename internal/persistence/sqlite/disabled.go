@@ -0,0 +1,24 @@
+//go:build !sqlite
+
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/history"
+)
+
+var ErrNotBuilt = errors.New("SQLite support is not built in, build the program with the sqlite build tag")
+
+type Database struct {
+	history.Noop
+}
+
+// New returns an error since the program is built without the sqlite build tag.
+func New(_ context.Context, _ string, _ history.Retention,
+	_ func() time.Time) (*Database, error) {
+	return nil, fmt.Errorf("%w", ErrNotBuilt)
+}
@@ -0,0 +1,240 @@
+//go:build sqlite
+
+// Package sqlite implements the history store with an SQLite database.
+// It is only built with the sqlite build tag.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/history"
+	"github.com/qdm12/ddns-updater/internal/models"
+	_ "modernc.org/sqlite" // register the sqlite driver
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS entries (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	domain TEXT NOT NULL,
+	host TEXT NOT NULL,
+	provider TEXT NOT NULL,
+	ip TEXT NOT NULL,
+	time INTEGER NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS entries_record_time ON entries (domain, host, time);`
+
+type Database struct {
+	db        *sql.DB
+	retention history.Retention
+	timeNow   func() time.Time
+}
+
+// New opens or creates the SQLite database at the file path given.
+// Entries falling outside the retention given are removed on Prune calls.
+func New(ctx context.Context, filePath string, retention history.Retention,
+	timeNow func() time.Time) (*Database, error) {
+	db, err := sql.Open("sqlite", "file:"+filePath+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, schema)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("creating schema: %w", err)
+	}
+
+	return &Database{
+		db:        db,
+		retention: retention,
+		timeNow:   timeNow,
+	}, nil
+}
+
+func (d *Database) Close() (err error) {
+	return d.db.Close()
+}
+
+func (d *Database) Add(ctx context.Context, entry history.Entry) (err error) {
+	const query = `INSERT INTO entries (domain, host, provider, ip, time, status, error)
+VALUES (?, ?, ?, ?, ?, ?, ?)`
+	ip := "" // no IP address for records set to a value
+	if entry.IP.IsValid() {
+		ip = entry.IP.String()
+	}
+	_, err = d.db.ExecContext(ctx, query, entry.Domain, entry.Host,
+		string(entry.Provider), ip, entry.Time.UnixNano(),
+		string(entry.Status), entry.Error)
+	if err != nil {
+		return fmt.Errorf("inserting entry: %w", err)
+	}
+	return nil
+}
+
+func (d *Database) Last(ctx context.Context, domain, host string, n uint) (
+	entries []history.Entry, err error) {
+	const query = `SELECT domain, host, provider, ip, time, status, error FROM entries
+WHERE domain = ? AND host = ?
+ORDER BY time DESC, id DESC
+LIMIT ?`
+	rows, err := d.db.QueryContext(ctx, query, domain, host, n)
+	if err != nil {
+		return nil, fmt.Errorf("querying entries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEntries(rows)
+}
+
+func (d *Database) Query(ctx context.Context, filter history.Filter) (
+	entries []history.Entry, err error) {
+	var conditions []string
+	var args []any
+	if filter.Domain != "" {
+		conditions = append(conditions, "domain = ?")
+		args = append(args, filter.Domain)
+	}
+	if filter.Host != "" {
+		conditions = append(conditions, "host = ?")
+		args = append(args, filter.Host)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "time >= ?")
+		args = append(args, filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "time < ?")
+		args = append(args, filter.Until.UnixNano())
+	}
+
+	query := "SELECT domain, host, provider, ip, time, status, error FROM entries"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY time ASC, id ASC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying entries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEntries(rows)
+}
+
+// Prune removes the entries older than the retention maximum age, and the
+// oldest entries of each record beyond the retention maximum count.
+// Records are pruned each in their own transaction, to keep transactions
+// short and not block entries being added for too long.
+func (d *Database) Prune(ctx context.Context) (err error) {
+	if d.retention.MaxAge == 0 && d.retention.MaxCount == 0 {
+		return nil
+	}
+
+	type record struct {
+		domain string
+		host   string
+	}
+	rows, err := d.db.QueryContext(ctx, "SELECT DISTINCT domain, host FROM entries")
+	if err != nil {
+		return fmt.Errorf("querying records: %w", err)
+	}
+	var records []record
+	for rows.Next() {
+		var r record
+		err = rows.Scan(&r.domain, &r.host)
+		if err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("scanning record: %w", err)
+		}
+		records = append(records, r)
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return fmt.Errorf("iterating over records: %w", err)
+	}
+
+	for _, r := range records {
+		err = d.pruneRecord(ctx, r.domain, r.host)
+		if err != nil {
+			return fmt.Errorf("pruning entries for domain %s and host %s: %w",
+				r.domain, r.host, err)
+		}
+	}
+	return nil
+}
+
+func (d *Database) pruneRecord(ctx context.Context, domain, host string) (err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	if d.retention.MaxAge > 0 {
+		const query = `DELETE FROM entries WHERE domain = ? AND host = ? AND time < ?`
+		oldest := d.timeNow().Add(-d.retention.MaxAge).UnixNano()
+		_, err = tx.ExecContext(ctx, query, domain, host, oldest)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("deleting entries older than %s: %w", d.retention.MaxAge, err)
+		}
+	}
+
+	if d.retention.MaxCount > 0 {
+		const query = `DELETE FROM entries WHERE domain = ? AND host = ? AND id NOT IN (
+SELECT id FROM entries WHERE domain = ? AND host = ?
+ORDER BY time DESC, id DESC
+LIMIT ?)`
+		_, err = tx.ExecContext(ctx, query, domain, host, domain, host, d.retention.MaxCount)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("deleting entries beyond %d entries: %w", d.retention.MaxCount, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+func scanEntries(rows *sql.Rows) (entries []history.Entry, err error) {
+	for rows.Next() {
+		var (
+			entry    history.Entry
+			provider string
+			ip       string
+			unixNano int64
+			status   string
+		)
+		err = rows.Scan(&entry.Domain, &entry.Host, &provider, &ip,
+			&unixNano, &status, &entry.Error)
+		if err != nil {
+			return nil, fmt.Errorf("scanning entry: %w", err)
+		}
+		entry.Provider = models.Provider(provider)
+		entry.Status = models.Status(status)
+		entry.Time = time.Unix(0, unixNano)
+		if ip != "" {
+			entry.IP, err = netip.ParseAddr(ip)
+			if err != nil {
+				return nil, fmt.Errorf("parsing entry IP address: %w", err)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("iterating over entries: %w", err)
+	}
+	return entries, nil
+}
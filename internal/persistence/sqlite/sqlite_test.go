@@ -0,0 +1,118 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"net/netip"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/constants"
+	"github.com/qdm12/ddns-updater/internal/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Database_Last(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := New(ctx, filepath.Join(t.TempDir(), "history.db"),
+		history.Retention{}, time.Now)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	baseTime := time.Unix(1700000000, 0)
+	entries := []history.Entry{
+		{Domain: "example.com", Host: "@", Provider: "njalla",
+			IP: netip.MustParseAddr("1.2.3.4"), Time: baseTime, Status: constants.SUCCESS},
+		{Domain: "example.com", Host: "@", Provider: "njalla",
+			IP: netip.MustParseAddr("1.2.3.5"), Time: baseTime.Add(time.Hour),
+			Status: constants.FAIL, Error: "bad request"},
+		{Domain: "example.com", Host: "sub", Provider: "njalla",
+			IP: netip.MustParseAddr("::1"), Time: baseTime.Add(2 * time.Hour), Status: constants.SUCCESS},
+		{Domain: "example.com", Host: "@", Provider: "njalla",
+			IP: netip.MustParseAddr("1.2.3.6"), Time: baseTime.Add(3 * time.Hour), Status: constants.SUCCESS},
+		// entry of a record set to a value, without IP address
+		{Domain: "example.com", Host: "_acme", Provider: "njalla",
+			Time: baseTime.Add(4 * time.Hour), Status: constants.FAIL, Error: "bad request"},
+	}
+	for _, entry := range entries {
+		err = db.Add(ctx, entry)
+		require.NoError(t, err)
+	}
+
+	last, err := db.Last(ctx, "example.com", "@", 2)
+
+	require.NoError(t, err)
+	expected := []history.Entry{entries[3], entries[1]}
+	require.Len(t, last, len(expected))
+	for i := range expected {
+		assert.True(t, expected[i].Time.Equal(last[i].Time))
+		last[i].Time = expected[i].Time
+	}
+	assert.Equal(t, expected, last)
+
+	last, err = db.Last(ctx, "example.com", "_acme", 1)
+
+	require.NoError(t, err)
+	require.Len(t, last, 1)
+	assert.False(t, last[0].IP.IsValid())
+
+	var storedIP string
+	err = db.db.QueryRowContext(ctx, "SELECT ip FROM entries WHERE host = '_acme'").Scan(&storedIP)
+	require.NoError(t, err)
+	assert.Empty(t, storedIP)
+}
+
+func Test_Database_Prune(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	retention := history.Retention{
+		MaxAge:   24 * time.Hour,
+		MaxCount: 2,
+	}
+	db, err := New(ctx, filepath.Join(t.TempDir(), "history.db"),
+		retention, func() time.Time { return now })
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	makeEntry := func(host string, age time.Duration) history.Entry {
+		return history.Entry{Domain: "example.com", Host: host, Provider: "njalla",
+			IP: netip.MustParseAddr("1.2.3.4"), Time: now.Add(-age), Status: constants.SUCCESS}
+	}
+	entries := []history.Entry{
+		makeEntry("@", 48*time.Hour), // too old
+		makeEntry("@", 3*time.Hour),  // beyond max count
+		makeEntry("@", 2*time.Hour),
+		makeEntry("@", time.Hour),
+		makeEntry("sub", 25*time.Hour), // too old
+		makeEntry("sub", time.Hour),
+	}
+	for _, entry := range entries {
+		err = db.Add(ctx, entry)
+		require.NoError(t, err)
+	}
+
+	err = db.Prune(ctx)
+	require.NoError(t, err)
+
+	remaining, err := db.Query(ctx, history.Filter{})
+	require.NoError(t, err)
+	expected := []history.Entry{entries[2], entries[3], entries[5]}
+	require.Len(t, remaining, len(expected))
+	for i := range expected {
+		assert.True(t, expected[i].Time.Equal(remaining[i].Time))
+		assert.Equal(t, expected[i].Host, remaining[i].Host)
+	}
+}
@@ -3,6 +3,7 @@ package constants
 const (
 	Badauth    = "badauth"
 	Success    = "success"
+	Nochg      = "nochg"
 	Nohost     = "nohost"
 	Notfqdn    = "notfqdn"
 	Badagent   = "badagent"
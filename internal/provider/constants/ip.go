@@ -1,6 +1,8 @@
 package constants
 
 const (
-	A    = "A"
-	AAAA = "AAAA"
+	A     = "A"
+	AAAA  = "AAAA"
+	TXT   = "TXT"
+	CNAME = "CNAME"
 )
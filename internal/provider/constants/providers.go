@@ -6,14 +6,23 @@ import "github.com/qdm12/ddns-updater/internal/models"
 const (
 	Aliyun       models.Provider = "aliyun"
 	AllInkl      models.Provider = "allinkl"
+	Azure        models.Provider = "azure"
+	Bunny        models.Provider = "bunny"
+	ChangeIP     models.Provider = "changeip"
 	Cloudflare   models.Provider = "cloudflare"
+	ClouDNS      models.Provider = "cloudns"
+	Constellix   models.Provider = "constellix"
+	CoreNetworks models.Provider = "corenetworks"
 	Custom       models.Provider = "custom"
 	Dd24         models.Provider = "dd24"
 	DdnssDe      models.Provider = "ddnss"
 	DeSEC        models.Provider = "desec"
 	DigitalOcean models.Provider = "digitalocean"
 	DNSOMatic    models.Provider = "dnsomatic"
+	DNSimple     models.Provider = "dnsimple"
+	DNSMadeEasy  models.Provider = "dnsmadeeasy"
 	DNSPod       models.Provider = "dnspod"
+	Domeneshop   models.Provider = "domeneshop"
 	DonDominio   models.Provider = "dondominio"
 	Dreamhost    models.Provider = "dreamhost"
 	DuckDNS      models.Provider = "duckdns"
@@ -25,29 +34,40 @@ const (
 	FreeDNS      models.Provider = "freedns"
 	Gandi        models.Provider = "gandi"
 	GCP          models.Provider = "gcp"
+	GleSYS       models.Provider = "glesys"
 	GoDaddy      models.Provider = "godaddy"
 	GoIP         models.Provider = "goip"
 	HE           models.Provider = "he"
+	Hosttech     models.Provider = "hosttech"
 	Hetzner      models.Provider = "hetzner"
 	Infomaniak   models.Provider = "infomaniak"
 	INWX         models.Provider = "inwx"
 	Ionos        models.Provider = "ionos"
+	Joker        models.Provider = "joker"
 	Linode       models.Provider = "linode"
+	Loopia       models.Provider = "loopia"
 	LuaDNS       models.Provider = "luadns"
+	MythicBeasts models.Provider = "mythicbeasts"
 	Namecheap    models.Provider = "namecheap"
 	NameCom      models.Provider = "name.com"
 	Netcup       models.Provider = "netcup"
+	NFSN         models.Provider = "nfsn"
 	Njalla       models.Provider = "njalla"
 	NoIP         models.Provider = "noip"
 	NowDNS       models.Provider = "nowdns"
 	OpenDNS      models.Provider = "opendns"
 	OVH          models.Provider = "ovh"
 	Porkbun      models.Provider = "porkbun"
+	Route53      models.Provider = "route53"
+	Scaleway     models.Provider = "scaleway"
 	SelfhostDe   models.Provider = "selfhost.de"
 	Servercow    models.Provider = "servercow"
 	Spdyn        models.Provider = "spdyn"
 	Strato       models.Provider = "strato"
+	TransIP      models.Provider = "transip"
 	Variomedia   models.Provider = "variomedia"
+	Vultr        models.Provider = "vultr"
+	Yandex       models.Provider = "yandex"
 	Zoneedit     models.Provider = "zoneedit"
 )
 
@@ -55,13 +75,22 @@ func ProviderChoices() []models.Provider {
 	return []models.Provider{
 		Aliyun,
 		AllInkl,
+		Azure,
+		Bunny,
+		ChangeIP,
 		Cloudflare,
+		ClouDNS,
+		Constellix,
+		CoreNetworks,
 		Dd24,
 		DdnssDe,
 		DeSEC,
 		DigitalOcean,
 		DNSOMatic,
+		DNSimple,
+		DNSMadeEasy,
 		DNSPod,
+		Domeneshop,
 		DonDominio,
 		Dreamhost,
 		DuckDNS,
@@ -73,27 +102,40 @@ func ProviderChoices() []models.Provider {
 		FreeDNS,
 		Gandi,
 		GCP,
+		GleSYS,
 		GoDaddy,
 		GoIP,
 		HE,
+		Hosttech,
 		Hetzner,
 		Infomaniak,
 		INWX,
 		Ionos,
+		Joker,
 		Linode,
+		Loopia,
 		LuaDNS,
+		MythicBeasts,
 		Namecheap,
 		NameCom,
+		Netcup,
+		NFSN,
 		Njalla,
 		NoIP,
 		NowDNS,
 		OpenDNS,
 		OVH,
 		Porkbun,
+		Route53,
+		Scaleway,
 		SelfhostDe,
+		Servercow,
 		Spdyn,
 		Strato,
+		TransIP,
 		Variomedia,
+		Vultr,
+		Yandex,
 		Zoneedit,
 	}
 }
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// retryableError marks the error it wraps as transient.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// Retryable wraps the error given to mark it as transient,
+// such that the update failing with it can be retried.
+// It returns nil if the error given is nil.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err}
+}
+
+// HTTPStatus returns an error wrapping ErrHTTPStatusNotValid with the
+// status code and message given. The error is marked as retryable for
+// server side (5xx) and rate limiting (429) status codes.
+func HTTPStatus(statusCode int, message string) error {
+	err := fmt.Errorf("%w: %d: %s", ErrHTTPStatusNotValid, statusCode, message)
+	if statusCode >= http.StatusInternalServerError ||
+		statusCode == http.StatusTooManyRequests {
+		return Retryable(err)
+	}
+	return err
+}
+
+// permanentErrors are errors for which retrying
+// the same update cannot succeed.
+var permanentErrors = []error{ //nolint:gochecknoglobals
+	ErrAccountInactive,
+	ErrAuth,
+	ErrBadRequest,
+	ErrBannedAbuse,
+	ErrBannedUserAgent,
+	ErrFeatureUnavailable,
+	ErrHostnameNotExists,
+	ErrKeyNotSet,
+	ErrKeyNotValid,
+	ErrRecordNotOwned,
+	ErrResponseBodyTooLarge,
+	ErrTokenNotSet,
+	context.Canceled,
+}
+
+// transientErrors are errors for which retrying
+// the same update later can succeed.
+var transientErrors = []error{ //nolint:gochecknoglobals
+	ErrDNSServerSide,
+	ErrRateLimited,
+	ErrUnmarshalResponse,
+}
+
+// IsRetryable returns true if the error given is transient, such that
+// the update failing with it can be retried. Errors wrapping a permanent
+// error such as ErrAuth are never retryable. Otherwise, errors marked with
+// Retryable, wrapping a transient error such as ErrRateLimited or being
+// transient network errors as defined by isNetworkError are retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, permanentErr := range permanentErrors {
+		if errors.Is(err, permanentErr) {
+			return false
+		}
+	}
+
+	var retryable *retryableError
+	if errors.As(err, &retryable) {
+		return true
+	}
+
+	for _, transientErr := range transientErrors {
+		if errors.Is(err, transientErr) {
+			return true
+		}
+	}
+
+	return isNetworkError(err)
+}
+
+// isNetworkError returns true if the error given is a timeout, a DNS
+// resolution failure other than the host not being found, or a connection
+// failure while dialing, reading or writing. Other HTTP client errors, such
+// as certificate verification or unsupported protocol scheme errors, are
+// not network errors even though *url.Error implements net.Error.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		switch opErr.Op {
+		case "dial", "read", "write":
+			return true
+		}
+	}
+	return false
+}
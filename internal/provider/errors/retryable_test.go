@@ -0,0 +1,103 @@
+package errors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsRetryable(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err       error
+		retryable bool
+	}{
+		"nil": {},
+		"unclassified": {
+			err: errors.New("test"),
+		},
+		"marked": {
+			err:       fmt.Errorf("updating: %w", Retryable(errors.New("test"))),
+			retryable: true,
+		},
+		"transient_sentinel": {
+			err:       fmt.Errorf("updating: %w", ErrRateLimited),
+			retryable: true,
+		},
+		"permanent_sentinel": {
+			err: fmt.Errorf("updating: %w", ErrAuth),
+		},
+		"response_body_too_large": {
+			err: fmt.Errorf("%w: %w: exceeding 10 bytes",
+				ErrUnmarshalResponse, ErrResponseBodyTooLarge),
+		},
+		"permanent_sentinel_marked": {
+			err: Retryable(fmt.Errorf("updating: %w", ErrAuth)),
+		},
+		"http_status_server_side": {
+			err:       HTTPStatus(http.StatusBadGateway, "bad gateway"),
+			retryable: true,
+		},
+		"http_status_rate_limited": {
+			err:       HTTPStatus(http.StatusTooManyRequests, "too many requests"),
+			retryable: true,
+		},
+		"http_status_client_side": {
+			err: HTTPStatus(http.StatusNotFound, "not found"),
+		},
+		"network_error": {
+			err: &url.Error{Op: "Get", URL: "https://example.com",
+				Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}},
+			retryable: true,
+		},
+		"network_read_error": {
+			err: &url.Error{Op: "Get", URL: "https://example.com",
+				Err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}},
+			retryable: true,
+		},
+		"timeout": {
+			err:       &url.Error{Op: "Get", URL: "https://example.com", Err: context.DeadlineExceeded},
+			retryable: true,
+		},
+		"dns_temporary_failure": {
+			err: &url.Error{Op: "Get", URL: "https://example.com",
+				Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}}},
+			retryable: true,
+		},
+		"dns_host_not_found": {
+			err: &url.Error{Op: "Get", URL: "https://example.com",
+				Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", IsNotFound: true}}},
+		},
+		"tls_unknown_authority": {
+			err: &url.Error{Op: "Get", URL: "https://example.com",
+				Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}},
+		},
+		"unsupported_protocol_scheme": {
+			err: &url.Error{Op: "Get", URL: "ftp://example.com",
+				Err: errors.New(`unsupported protocol scheme "ftp"`)},
+		},
+		"context_canceled": {
+			err: &url.Error{Op: "Get", URL: "https://example.com", Err: context.Canceled},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			retryable := IsRetryable(testCase.err)
+
+			assert.Equal(t, testCase.retryable, retryable)
+		})
+	}
+}
@@ -21,16 +21,20 @@ var (
 	ErrIPSentMalformed           = errors.New("malformed IP address sent")
 	ErrNoService                 = errors.New("no service")
 	ErrPrivateIPSent             = errors.New("private IP cannot be routed")
+	ErrRateLimited               = errors.New("rate limited")
 	ErrReceivedNoIP              = errors.New("received no IP address in response")
 	ErrReceivedNoResult          = errors.New("received no result in response")
 	ErrRecordNotEditable         = errors.New("record is not editable")
 	ErrRecordNotFound            = errors.New("record not found")
+	ErrRecordNotOwned            = errors.New("record does not belong to the account")
 	ErrRecordResourceSetNotFound = errors.New("record resource set not found")
+	ErrResponseBodyTooLarge      = errors.New("response body is too large")
 	ErrResponseTooShort          = errors.New("response is too short")
 	ErrResultsCountReceived      = errors.New("wrong number of results received")
 	ErrSessionIsEmpty            = errors.New("session received is empty")
 	ErrSystemParamNotValid       = errors.New("system parameter is not valid")
 	ErrUnknownResponse           = errors.New("unknown response received")
+	ErrUnmarshalResponse         = errors.New("cannot unmarshal response")
 	ErrUnsuccessful              = errors.New("unsuccessful result")
 	ErrZoneNotFound              = errors.New("zone not found")
 )
@@ -8,6 +8,9 @@ var (
 	ErrAPIKeyNotSet           = errors.New("API key is not set")
 	ErrAPISecretNotSet        = errors.New("API secret is not set")
 	ErrAppKeyNotSet           = errors.New("app key is not set")
+	ErrAuthIDNotSet           = errors.New("auth id is not set")
+	ErrClientIDNotSet         = errors.New("client id is not set")
+	ErrClientSecretNotSet     = errors.New("client secret is not set")
 	ErrConsumerKeyNotSet      = errors.New("consumer key is not set")
 	ErrCredentialsNotSet      = errors.New("credentials are not set")
 	ErrCredentialsNotValid    = errors.New("credentials are not valid")
@@ -24,11 +27,15 @@ var (
 	ErrIPv6KeyNotSet          = errors.New("IPv6 key is not set")
 	ErrKeyNotSet              = errors.New("key is not set")
 	ErrKeyNotValid            = errors.New("key is not valid")
+	ErrModeNotValid           = errors.New("mode is not valid")
 	ErrNameNotSet             = errors.New("name is not set")
 	ErrPasswordNotSet         = errors.New("password is not set")
 	ErrPasswordNotValid       = errors.New("password is not valid")
+	ErrResourceGroupNotSet    = errors.New("resource group is not set")
 	ErrSecretNotSet           = errors.New("secret is not set")
+	ErrSubscriptionIDNotSet   = errors.New("subscription id is not set")
 	ErrSuccessRegexNotSet     = errors.New("success regex is not set")
+	ErrTenantIDNotSet         = errors.New("tenant id is not set")
 	ErrTokenNotSet            = errors.New("token is not set")
 	ErrTokenNotValid          = errors.New("token is not valid")
 	ErrTTLNotSet              = errors.New("TTL is not set")
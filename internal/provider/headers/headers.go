@@ -1,9 +1,30 @@
 package headers
 
-import "net/http"
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+const defaultUserAgent = "DDNS-Updater quentin.mcgaw@gmail.com"
+
+var userAgent atomic.Pointer[string] //nolint:gochecknoglobals
+
+// OverrideUserAgent sets the User-Agent used by SetUserAgent instead
+// of the default one. An empty user agent restores the default one.
+func OverrideUserAgent(newUserAgent string) {
+	if newUserAgent == "" {
+		userAgent.Store(nil)
+		return
+	}
+	userAgent.Store(&newUserAgent)
+}
 
 func SetUserAgent(request *http.Request) {
-	request.Header.Set("User-Agent", "DDNS-Updater quentin.mcgaw@gmail.com")
+	value := defaultUserAgent
+	if override := userAgent.Load(); override != nil {
+		value = *override
+	}
+	request.Header.Set("User-Agent", value)
 }
 
 func SetContentType(request *http.Request, contentType string) {
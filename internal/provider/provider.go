@@ -12,14 +12,23 @@ import (
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/aliyun"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/allinkl"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/azure"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/bunny"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/changeip"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/cloudflare"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/cloudns"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/constellix"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/corenetworks"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/custom"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/dd24"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/ddnss"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/desec"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/digitalocean"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/dnsimple"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/dnsmadeeasy"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/dnsomatic"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/dnspod"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/domeneshop"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/dondominio"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/dreamhost"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/duckdns"
@@ -31,29 +40,40 @@ import (
 	"github.com/qdm12/ddns-updater/internal/provider/providers/freedns"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/gandi"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/gcp"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/glesys"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/godaddy"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/goip"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/he"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/hetzner"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/hosttech"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/infomaniak"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/inwx"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/ionos"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/joker"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/linode"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/loopia"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/luadns"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/mythicbeasts"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/namecheap"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/namecom"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/netcup"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/nfsn"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/njalla"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/noip"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/nowdns"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/opendns"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/ovh"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/porkbun"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/route53"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/scaleway"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/selfhostde"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/servercow"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/spdyn"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/strato"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/transip"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/variomedia"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/vultr"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/yandex"
 	"github.com/qdm12/ddns-updater/internal/provider/providers/zoneedit"
 	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
 )
@@ -64,12 +84,32 @@ type Provider interface {
 	Host() string
 	BuildDomainName() string
 	HTML() models.HTMLRow
+	// Proxied returns true if the record is proxied by the DNS provider,
+	// in which case the record resolves to the provider proxy addresses
+	// instead of the public IP address. Cloudflare returns its "proxied"
+	// setting, DNS-O-Matic returns true for the host "all" since it forwards
+	// updates to other services, and other providers return false.
 	Proxied() bool
 	IPVersion() ipversion.IPVersion
 	IPv6Suffix() netip.Prefix
 	Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error)
 }
 
+// DualStackUpdater is implemented by providers able to update
+// both the IPv4 and IPv6 addresses of a record in a single request.
+type DualStackUpdater interface {
+	UpdateBoth(ctx context.Context, client *http.Client, ipv4, ipv6 netip.Addr) (
+		newIPv4, newIPv6 netip.Addr, err error)
+}
+
+// ValueUpdater is implemented by providers able to set a record of
+// another type than A or AAAA, such as a TXT or CNAME record, to a fixed
+// value instead of an IP address. It is implemented by the DigitalOcean,
+// Gandi and Hetzner providers.
+type ValueUpdater interface {
+	UpdateValue(ctx context.Context, client *http.Client, recordType, value string) (err error)
+}
+
 var ErrProviderUnknown = errors.New("unknown provider")
 
 //nolint:gocyclo
@@ -80,8 +120,20 @@ func New(providerName models.Provider, data json.RawMessage, domain, host string
 		return aliyun.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.AllInkl:
 		return allinkl.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Azure:
+		return azure.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Bunny:
+		return bunny.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.ChangeIP:
+		return changeip.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Cloudflare:
 		return cloudflare.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.ClouDNS:
+		return cloudns.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Constellix:
+		return constellix.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.CoreNetworks:
+		return corenetworks.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Custom:
 		return custom.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Dd24:
@@ -94,8 +146,14 @@ func New(providerName models.Provider, data json.RawMessage, domain, host string
 		return digitalocean.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.DNSOMatic:
 		return dnsomatic.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.DNSimple:
+		return dnsimple.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.DNSMadeEasy:
+		return dnsmadeeasy.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.DNSPod:
 		return dnspod.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Domeneshop:
+		return domeneshop.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.DonDominio:
 		return dondominio.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Dreamhost:
@@ -118,12 +176,16 @@ func New(providerName models.Provider, data json.RawMessage, domain, host string
 		return gandi.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.GCP:
 		return gcp.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.GleSYS:
+		return glesys.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.GoDaddy:
 		return godaddy.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.GoIP:
 		return goip.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.HE:
 		return he.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Hosttech:
+		return hosttech.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Hetzner:
 		return hetzner.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Infomaniak:
@@ -132,16 +194,24 @@ func New(providerName models.Provider, data json.RawMessage, domain, host string
 		return inwx.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Ionos:
 		return ionos.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Joker:
+		return joker.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Linode:
 		return linode.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Loopia:
+		return loopia.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.LuaDNS:
 		return luadns.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.MythicBeasts:
+		return mythicbeasts.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Namecheap:
 		return namecheap.New(data, domain, host)
 	case constants.NameCom:
 		return namecom.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Netcup:
 		return netcup.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.NFSN:
+		return nfsn.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Njalla:
 		return njalla.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.NoIP:
@@ -154,6 +224,10 @@ func New(providerName models.Provider, data json.RawMessage, domain, host string
 		return ovh.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Porkbun:
 		return porkbun.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Route53:
+		return route53.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Scaleway:
+		return scaleway.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.SelfhostDe:
 		return selfhostde.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Servercow:
@@ -162,8 +236,14 @@ func New(providerName models.Provider, data json.RawMessage, domain, host string
 		return spdyn.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Strato:
 		return strato.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.TransIP:
+		return transip.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Variomedia:
 		return variomedia.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Vultr:
+		return vultr.New(data, domain, host, ipVersion, ipv6Suffix)
+	case constants.Yandex:
+		return yandex.New(data, domain, host, ipVersion, ipv6Suffix)
 	case constants.Zoneedit:
 		return zoneedit.New(data, domain, host, ipVersion, ipv6Suffix)
 	default:
@@ -10,16 +10,11 @@ import (
 	"strings"
 )
 
+// sign computes the signature of the request parameters and sets it
+// as the Signature parameter of urlValues.
+// See https://www.alibabacloud.com/help/en/dns/api-alidns-2015-01-09-request-signing
 func sign(method string, urlValues url.Values, accessKeySecret string) {
-	sortedParams := make(sort.StringSlice, 0, len(urlValues))
-	for key, values := range urlValues {
-		s := url.QueryEscape(key) + "=" + url.QueryEscape(values[0])
-		sortedParams = append(sortedParams, s)
-	}
-	sortedParams.Sort()
-
-	stringToSign := strings.ToUpper(method) + "&%2F&" +
-		url.QueryEscape(strings.Join(sortedParams, "&"))
+	stringToSign := buildStringToSign(method, urlValues)
 
 	key := []byte(accessKeySecret + "&")
 	hmac := hmac.New(sha1.New, key)
@@ -28,3 +23,26 @@ func sign(method string, urlValues url.Values, accessKeySecret string) {
 	signature := base64.StdEncoding.EncodeToString(signedBytes)
 	urlValues.Set("Signature", signature)
 }
+
+func buildStringToSign(method string, urlValues url.Values) string {
+	sortedParams := make(sort.StringSlice, 0, len(urlValues))
+	for key, values := range urlValues {
+		s := percentEncode(key) + "=" + percentEncode(values[0])
+		sortedParams = append(sortedParams, s)
+	}
+	sortedParams.Sort()
+
+	return strings.ToUpper(method) + "&" + percentEncode("/") + "&" +
+		percentEncode(strings.Join(sortedParams, "&"))
+}
+
+// percentEncode encodes the string s following RFC 3986 as required
+// by Alibaba Cloud, where spaces are encoded as %20 instead of + and
+// the character ~ is not encoded.
+func percentEncode(s string) string {
+	s = url.QueryEscape(s)
+	s = strings.ReplaceAll(s, "+", "%20")
+	s = strings.ReplaceAll(s, "*", "%2A")
+	s = strings.ReplaceAll(s, "%7E", "~")
+	return s
+}
@@ -0,0 +1,64 @@
+package aliyun
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sign(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		method               string
+		urlValues            url.Values
+		accessKeySecret      string
+		expectedStringToSign string
+		expectedSignature    string
+	}{
+		"documented_example": {
+			method: http.MethodGet,
+			urlValues: url.Values{
+				"Format":           {"XML"},
+				"AccessKeyId":      {"testid"},
+				"Action":           {"DescribeDomainRecords"},
+				"SignatureMethod":  {"HMAC-SHA1"},
+				"DomainName":       {"example.com"},
+				"SignatureNonce":   {"f59ed6a9-83fc-473b-9cc6-99c95df3856e"},
+				"SignatureVersion": {"1.0"},
+				"Version":          {"2015-01-09"},
+				"Timestamp":        {"2016-03-24T16:41:54Z"},
+			},
+			accessKeySecret: "testsecret",
+			expectedStringToSign: "GET&%2F&AccessKeyId%3Dtestid%26Action%3DDescribeDomainRecords" +
+				"%26DomainName%3Dexample.com%26Format%3DXML%26SignatureMethod%3DHMAC-SHA1" +
+				"%26SignatureNonce%3Df59ed6a9-83fc-473b-9cc6-99c95df3856e%26SignatureVersion%3D1.0" +
+				"%26Timestamp%3D2016-03-24T16%253A41%253A54Z%26Version%3D2015-01-09",
+			expectedSignature: "uRpHwaSEt3J+6KQD//svCh/x+pI=",
+		},
+		"special_characters": {
+			method: http.MethodGet,
+			urlValues: url.Values{
+				"Value": {"a b*c~d"},
+			},
+			accessKeySecret:      "testsecret",
+			expectedStringToSign: "GET&%2F&Value%3Da%2520b%252Ac~d",
+			expectedSignature:    "7R47wHeZPciYTvqrNo1eKTNMO18=",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stringToSign := buildStringToSign(testCase.method, testCase.urlValues)
+			assert.Equal(t, testCase.expectedStringToSign, stringToSign)
+
+			sign(testCase.method, testCase.urlValues, testCase.accessKeySecret)
+			assert.Equal(t, testCase.expectedSignature, testCase.urlValues.Get("Signature"))
+		})
+	}
+}
@@ -16,7 +16,7 @@ func (p *Provider) getRecordID(ctx context.Context, client *http.Client,
 	recordType string) (recordID string, err error) {
 	u := &url.URL{
 		Scheme: "https",
-		Host:   "dns.aliyuncs.com",
+		Host:   "alidns.aliyuncs.com",
 	}
 	values := newURLValues(p.accessKeyID)
 	values.Set("Action", "DescribeDomainRecords")
@@ -29,13 +29,17 @@ func New(data json.RawMessage, domain, host string,
 	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
 	p *Provider, err error) {
 	extraSettings := struct {
-		AccessKeyID  string `json:"access_key_id"`
-		AccessSecret string `json:"access_secret"`
-		Region       string `json:"region"`
+		AccessKeyID     string `json:"access_key_id"`
+		AccessSecret    string `json:"access_secret"`
+		AccessKeySecret string `json:"access_key_secret"`
+		Region          string `json:"region"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding aliyun extra settings: %w", err)
+	}
+	if extraSettings.AccessSecret == "" {
+		extraSettings.AccessSecret = extraSettings.AccessKeySecret
 	}
 	p = &Provider{
 		domain:       domain,
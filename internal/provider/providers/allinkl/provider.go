@@ -107,6 +107,36 @@ func (p *Provider) HTML() models.HTMLRow {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	var ipv4, ipv6 netip.Addr
+	if ip.Is6() {
+		ipv6 = ip
+	} else {
+		ipv4 = ip
+	}
+
+	newIPv4, newIPv6, err := p.update(ctx, client, ipv4, ipv6)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	if ip.Is6() {
+		return newIPv6, nil
+	}
+	return newIPv4, nil
+}
+
+// UpdateBoth updates both the A and AAAA records in a single request.
+// If only one of the IP addresses given is valid, only the record
+// matching its IP family is updated.
+func (p *Provider) UpdateBoth(ctx context.Context, client *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
+	return p.update(ctx, client, ipv4, ipv6)
+}
+
+// update sends the valid IP addresses given in a single request and
+// returns the IP addresses received for each valid IP address given.
+func (p *Provider) update(ctx context.Context, client *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
 	u := url.URL{
 		Scheme: "https",
 		Host:   "dyndns.kasserver.com",
@@ -115,73 +145,86 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	}
 	values := url.Values{}
 	values.Set("host", utils.BuildURLQueryHostname(p.host, p.domain))
-	useProviderIP := p.useProviderIP && (ip.Is4() || !p.ipv6Suffix.IsValid())
-	if !useProviderIP {
-		if ip.Is6() {
-			values.Set("myip6", ip.String())
-		} else {
-			values.Set("myip", ip.String())
-		}
+	useProviderIPv4 := p.useProviderIP
+	useProviderIPv6 := p.useProviderIP && !p.ipv6Suffix.IsValid()
+	if ipv4.IsValid() && !useProviderIPv4 {
+		values.Set("myip", ipv4.String())
+	}
+	if ipv6.IsValid() && !useProviderIPv6 {
+		values.Set("myip6", ipv6.String())
 	}
 	u.RawQuery = values.Encode()
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+		return newIPv4, newIPv6, fmt.Errorf("creating http request: %w", err)
 	}
 	headers.SetUserAgent(request)
 
 	response, err := client.Do(request)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("doing http request: %w", err)
+		return newIPv4, newIPv6, fmt.Errorf("doing http request: %w", err)
 	}
 	defer response.Body.Close()
 
 	b, err := io.ReadAll(response.Body)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("reading response body: %w", err)
+		return newIPv4, newIPv6, fmt.Errorf("reading response body: %w", err)
 	}
 	s := string(b)
 
 	if response.StatusCode != http.StatusOK {
-		return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
+		return newIPv4, newIPv6, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
 			response.StatusCode, utils.ToSingleLine(s))
 	}
 
 	switch s {
 	case "":
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrReceivedNoResult)
+		return newIPv4, newIPv6, fmt.Errorf("%w", errors.ErrReceivedNoResult)
 	case constants.Nineoneone:
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrDNSServerSide)
+		return newIPv4, newIPv6, fmt.Errorf("%w", errors.ErrDNSServerSide)
 	case constants.Abuse:
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBannedAbuse)
+		return newIPv4, newIPv6, fmt.Errorf("%w", errors.ErrBannedAbuse)
 	case "!donator":
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrFeatureUnavailable)
+		return newIPv4, newIPv6, fmt.Errorf("%w", errors.ErrFeatureUnavailable)
 	case constants.Badagent:
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBannedUserAgent)
+		return newIPv4, newIPv6, fmt.Errorf("%w", errors.ErrBannedUserAgent)
 	case constants.Badauth:
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
+		return newIPv4, newIPv6, fmt.Errorf("%w", errors.ErrAuth)
 	case constants.Nohost:
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
+		return newIPv4, newIPv6, fmt.Errorf("%w", errors.ErrHostnameNotExists)
 	}
 	if !strings.Contains(s, "nochg") && !strings.Contains(s, "good") {
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, s)
+		return newIPv4, newIPv6, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, s)
 	}
-	var ips []netip.Addr
-	if ip.Is4() {
-		ips = ipextract.IPv4(s)
-	} else {
-		ips = ipextract.IPv6(s)
+
+	if ipv4.IsValid() {
+		newIPv4, err = extractReceivedIP(ipextract.IPv4(s), ipv4, useProviderIPv4)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("for ipv4: %w", err)
+		}
 	}
 
+	if ipv6.IsValid() {
+		newIPv6, err = extractReceivedIP(ipextract.IPv6(s), ipv6, useProviderIPv6)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("for ipv6: %w", err)
+		}
+	}
+
+	return newIPv4, newIPv6, nil
+}
+
+func extractReceivedIP(ips []netip.Addr, sentIP netip.Addr,
+	useProviderIP bool) (newIP netip.Addr, err error) {
 	if len(ips) == 0 {
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrReceivedNoIP)
 	}
 
 	newIP = ips[0]
-	if !useProviderIP && ip.Compare(newIP) != 0 {
+	if !useProviderIP && sentIP.Compare(newIP) != 0 {
 		return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
-			errors.ErrIPReceivedMismatch, ip, newIP)
+			errors.ErrIPReceivedMismatch, sentIP, newIP)
 	}
 	return newIP, nil
 }
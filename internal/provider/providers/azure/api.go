@@ -0,0 +1,139 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+)
+
+type ipRecord struct {
+	IPv4Address string `json:"ipv4Address,omitempty"`
+	IPv6Address string `json:"ipv6Address,omitempty"`
+}
+
+type recordSetProperties struct {
+	TTL         uint32     `json:"TTL"`
+	ARecords    []ipRecord `json:"ARecords,omitempty"`
+	AAAARecords []ipRecord `json:"AAAARecords,omitempty"`
+}
+
+// See https://learn.microsoft.com/en-us/rest/api/dns/record-sets/create-or-update
+func (p *Provider) putRecordSet(ctx context.Context, client *http.Client,
+	token string, ip netip.Addr) (err error) {
+	recordType := constants.A
+	properties := recordSetProperties{TTL: p.ttl}
+	if ip.Is6() {
+		recordType = constants.AAAA
+		properties.AAAARecords = []ipRecord{{IPv6Address: ip.String()}}
+	} else {
+		properties.ARecords = []ipRecord{{IPv4Address: ip.String()}}
+	}
+
+	u := url.URL{
+		Scheme: "https",
+		Host:   "management.azure.com",
+		Path: "/subscriptions/" + url.PathEscape(p.subscriptionID) +
+			"/resourceGroups/" + url.PathEscape(p.resourceGroup) +
+			"/providers/Microsoft.Network/dnsZones/" + url.PathEscape(p.zone) +
+			"/" + recordType + "/" + url.PathEscape(p.relativeName()),
+		RawQuery: url.Values{"api-version": {"2018-05-01"}}.Encode(),
+	}
+
+	requestData := struct {
+		Properties recordSetProperties `json:"properties"`
+	}{Properties: properties}
+	buffer := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buffer)
+	err = encoder.Encode(requestData)
+	if err != nil {
+		return fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), buffer)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAuthBearer(request, token)
+	headers.SetContentType(request, "application/json")
+	headers.SetAccept(request, "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+	case http.StatusUnauthorized:
+		p.token = "" // force requesting a new token on the next update
+		return makeStatusError(response)
+	default:
+		return makeStatusError(response)
+	}
+
+	var responseData struct {
+		Properties recordSetProperties `json:"properties"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	records := responseData.Properties.ARecords
+	if ip.Is6() {
+		records = responseData.Properties.AAAARecords
+	}
+	for _, record := range records {
+		receivedIP, err := netip.ParseAddr(record.IPv4Address + record.IPv6Address)
+		if err == nil && receivedIP.Compare(ip) == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: sent ip %s not found in response",
+		errors.ErrIPReceivedMismatch, ip)
+}
+
+func makeStatusError(response *http.Response) error {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var errorData struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	message := string(b)
+	if json.Unmarshal(b, &errorData) == nil && errorData.Error.Message != "" {
+		message = errorData.Error.Code + ": " + errorData.Error.Message
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrZoneNotFound, message)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", errors.ErrRateLimited, message)
+	default:
+		return fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
+			response.StatusCode, message)
+	}
+}
@@ -0,0 +1,112 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+)
+
+// tokenRenewMargin is the duration before the token expiry
+// from which a new token is requested.
+const tokenRenewMargin = time.Minute
+
+// getToken returns the cached access token if it is not near its expiry,
+// and otherwise requests a new access token and caches it.
+func (p *Provider) getToken(ctx context.Context, client *http.Client) (
+	token string, err error) {
+	now := p.timeNow()
+	if p.token != "" && now.Before(p.tokenExpiry.Add(-tokenRenewMargin)) {
+		return p.token, nil
+	}
+
+	token, expiresIn, err := p.requestToken(ctx, client)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = token
+	p.tokenExpiry = now.Add(expiresIn)
+	return token, nil
+}
+
+// See https://learn.microsoft.com/en-us/entra/identity-platform/v2-oauth2-client-creds-grant-flow
+func (p *Provider) requestToken(ctx context.Context, client *http.Client) (
+	token string, expiresIn time.Duration, err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "login.microsoftonline.com",
+		Path:   "/" + url.PathEscape(p.tenantID) + "/oauth2/v2.0/token",
+	}
+	values := url.Values{}
+	values.Set("grant_type", "client_credentials")
+	values.Set("client_id", p.clientID)
+	values.Set("client_secret", p.clientSecret)
+	values.Set("scope", "https://management.azure.com/.default")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(),
+		strings.NewReader(values.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/x-www-form-urlencoded")
+	headers.SetAccept(request, "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", 0, makeTokenError(response)
+	}
+
+	var data struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return "", 0, fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	if data.AccessToken == "" {
+		return "", 0, fmt.Errorf("%w: no access token", errors.ErrAuth)
+	}
+	return data.AccessToken, time.Duration(data.ExpiresIn) * time.Second, nil
+}
+
+// makeTokenError returns an error wrapping ErrAuth with the error
+// description returned, which starts with an AADSTS error code such
+// as "AADSTS7000215: Invalid client secret provided.".
+func makeTokenError(response *http.Response) error {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading body: %w",
+			errors.ErrAuth, response.StatusCode, err)
+	}
+
+	var data struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	err = json.Unmarshal(b, &data)
+	if err != nil || data.ErrorDescription == "" {
+		return fmt.Errorf("%w: %d: %s", errors.ErrAuth, response.StatusCode, string(b))
+	}
+
+	// Only keep the first line, the description contains a trace id,
+	// correlation id and timestamp on the following lines.
+	description, _, _ := strings.Cut(data.ErrorDescription, "\r\n")
+	return fmt.Errorf("%w: %s: %s", errors.ErrAuth, data.Error, description)
+}
@@ -0,0 +1,159 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain         string
+	host           string
+	ipVersion      ipversion.IPVersion
+	ipv6Suffix     netip.Prefix
+	tenantID       string
+	clientID       string
+	clientSecret   string
+	subscriptionID string
+	resourceGroup  string
+	zone           string
+	ttl            uint32
+	token          string
+	tokenExpiry    time.Time
+	timeNow        func() time.Time
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		TenantID       string `json:"tenant_id"`
+		ClientID       string `json:"client_id"`
+		ClientSecret   string `json:"client_secret"`
+		SubscriptionID string `json:"subscription_id"`
+		ResourceGroup  string `json:"resource_group"`
+		Zone           string `json:"zone"`
+		TTL            uint32 `json:"ttl"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding azure extra settings: %w", err)
+	}
+
+	zone := extraSettings.Zone
+	if zone == "" {
+		zone = domain
+	}
+	ttl := extraSettings.TTL
+	if ttl == 0 {
+		const defaultTTL = 300
+		ttl = defaultTTL
+	}
+
+	p = &Provider{
+		domain:         domain,
+		host:           host,
+		ipVersion:      ipVersion,
+		ipv6Suffix:     ipv6Suffix,
+		tenantID:       extraSettings.TenantID,
+		clientID:       extraSettings.ClientID,
+		clientSecret:   extraSettings.ClientSecret,
+		subscriptionID: extraSettings.SubscriptionID,
+		resourceGroup:  extraSettings.ResourceGroup,
+		zone:           zone,
+		ttl:            ttl,
+		timeNow:        time.Now,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	switch {
+	case p.tenantID == "":
+		return fmt.Errorf("%w", errors.ErrTenantIDNotSet)
+	case p.clientID == "":
+		return fmt.Errorf("%w", errors.ErrClientIDNotSet)
+	case p.clientSecret == "":
+		return fmt.Errorf("%w", errors.ErrClientSecretNotSet)
+	case p.subscriptionID == "":
+		return fmt.Errorf("%w", errors.ErrSubscriptionIDNotSet)
+	case p.resourceGroup == "":
+		return fmt.Errorf("%w", errors.ErrResourceGroupNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.Azure, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://azure.microsoft.com/en-us/products/dns\">Azure</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// relativeName returns the record set name relative to the zone,
+// which is "@" for the zone apex.
+func (p *Provider) relativeName() string {
+	fqdn := utils.BuildURLQueryHostname(p.host, p.domain)
+	if fqdn == p.zone {
+		return "@"
+	}
+	return strings.TrimSuffix(fqdn, "."+p.zone)
+}
+
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	token, err := p.getToken(ctx, client)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting access token: %w", err)
+	}
+
+	err = p.putRecordSet(ctx, client, token, ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("putting record set: %w", err)
+	}
+
+	return ip, nil
+}
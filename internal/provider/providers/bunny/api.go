@@ -0,0 +1,177 @@
+package bunny
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+// Record types are integers in the Bunny API.
+const (
+	recordTypeA    = 0
+	recordTypeAAAA = 1
+)
+
+type apiRecord struct {
+	ID    int64  `json:"Id"`
+	Type  int    `json:"Type"`
+	TTL   uint32 `json:"Ttl"`
+	Value string `json:"Value"`
+	Name  string `json:"Name"`
+}
+
+// See https://docs.bunny.net/reference/dnszonepublic_index
+func (p *Provider) getZoneID(ctx context.Context, client *http.Client) (
+	zoneID int64, err error) {
+	values := url.Values{}
+	values.Set("search", p.domain)
+	values.Set("perPage", "1000")
+	var data struct {
+		Items []struct {
+			ID     int64  `json:"Id"`
+			Domain string `json:"Domain"`
+		} `json:"Items"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, "/dnszone", values, nil, http.StatusOK, &data)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, zone := range data.Items {
+		if zone.Domain == p.domain {
+			return zone.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: in %d zone(s)", errors.ErrZoneNotFound, len(data.Items))
+}
+
+// See https://docs.bunny.net/reference/dnszonepublic_index2
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	zoneID int64, recordType string) (record apiRecord, err error) {
+	apiRecordType := recordTypeA
+	if recordType == constants.AAAA {
+		apiRecordType = recordTypeAAAA
+	}
+
+	var data struct {
+		Records []apiRecord `json:"Records"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, zonePath(zoneID), nil, nil, http.StatusOK, &data)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	recordName := utils.BuildRecordName(p.host)
+	for _, record := range data.Records {
+		if record.Name == recordName && record.Type == apiRecordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(data.Records))
+}
+
+// See https://docs.bunny.net/reference/dnszonepublic_updaterecord
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	zoneID int64, record apiRecord, ip netip.Addr) (err error) {
+	path := zonePath(zoneID) + "/records/" + strconv.FormatInt(record.ID, 10)
+	record.Value = ip.String()
+	return p.doRequest(ctx, client, http.MethodPost, path, nil, record, http.StatusNoContent, nil)
+}
+
+func zonePath(zoneID int64) string {
+	return "/dnszone/" + strconv.FormatInt(zoneID, 10)
+}
+
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	method, path string, values url.Values, requestData any,
+	expectedStatus int, responseData any) (err error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.bunny.net",
+		Path:     path,
+		RawQuery: values.Encode(),
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+	request.Header.Set("AccessKey", p.apiKey)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != expectedStatus {
+		return makeStatusError(response)
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
+
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Message string `json:"Message"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && data.Message != "" {
+		message = data.Message
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrZoneNotFound, message)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
@@ -0,0 +1,152 @@
+package cloudns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecord struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Host   string `json:"host"`
+	Record string `json:"record"`
+	TTL    string `json:"ttl"`
+}
+
+// See https://www.cloudns.net/wiki/article/57/
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	recordType string) (record apiRecord, err error) {
+	values := url.Values{}
+	values.Set("domain-name", p.domain)
+	values.Set("host", p.recordHost())
+	values.Set("type", recordType)
+	b, err := p.doRequest(ctx, client, "/dns/records.json", values)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	// The API returns an empty JSON array if no record is found,
+	// and an object of records keyed by record id otherwise.
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		return apiRecord{}, fmt.Errorf("%w", errors.ErrRecordNotFound)
+	}
+
+	var records map[string]apiRecord
+	err = json.Unmarshal(b, &records)
+	if err != nil {
+		return apiRecord{}, fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	recordHost := p.recordHost()
+	for _, record := range records {
+		if record.Host == recordHost && record.Type == recordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(records))
+}
+
+// See https://www.cloudns.net/wiki/article/60/
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	record apiRecord, ip netip.Addr) (err error) {
+	values := url.Values{}
+	values.Set("domain-name", p.domain)
+	values.Set("record-id", record.ID)
+	values.Set("host", record.Host)
+	values.Set("record", ip.String())
+	values.Set("ttl", record.TTL)
+	b, err := p.doRequest(ctx, client, "/dns/mod-record.json", values)
+	if err != nil {
+		return err
+	}
+
+	var data statusResponse
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	if data.Status != "Success" {
+		return fmt.Errorf("%w: %s: %s", errors.ErrUnknownResponse,
+			data.Status, data.StatusDescription)
+	}
+	return nil
+}
+
+// recordHost returns the host as expected by the ClouDNS API,
+// which uses an empty host for the apex of the domain.
+func (p *Provider) recordHost() string {
+	if p.host == "@" {
+		return ""
+	}
+	return p.host
+}
+
+type statusResponse struct {
+	Status            string `json:"status"`
+	StatusDescription string `json:"statusDescription"`
+}
+
+// doRequest sends the authentication and the values given as a form
+// to the API path and returns the response body. It returns an error
+// if the API responds with a failed status.
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	path string, values url.Values) (responseBody []byte, err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.cloudns.net",
+		Path:   path,
+	}
+
+	if p.subAuthID != "" {
+		values.Set("sub-auth-id", p.subAuthID)
+	} else {
+		values.Set("auth-id", p.authID)
+	}
+	values.Set("auth-password", p.authPassword)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(),
+		strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/x-www-form-urlencoded")
+	headers.SetAccept(request, "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err = io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
+			response.StatusCode, utils.ToSingleLine(string(responseBody)))
+	}
+
+	var data statusResponse
+	_ = json.Unmarshal(responseBody, &data)
+	if data.Status == "Failed" {
+		return nil, fmt.Errorf("%w: %s", errors.ErrBadRequest, data.StatusDescription)
+	}
+
+	return responseBody, nil
+}
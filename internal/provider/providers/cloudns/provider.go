@@ -0,0 +1,126 @@
+package cloudns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	// authID or subAuthID is used to authenticate,
+	// together with authPassword.
+	authID       string
+	subAuthID    string
+	authPassword string
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		AuthID       string `json:"auth_id"`
+		SubAuthID    string `json:"sub_auth_id"`
+		AuthPassword string `json:"auth_password"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding cloudns extra settings: %w", err)
+	}
+	p = &Provider{
+		domain:       domain,
+		host:         host,
+		ipVersion:    ipVersion,
+		ipv6Suffix:   ipv6Suffix,
+		authID:       extraSettings.AuthID,
+		subAuthID:    extraSettings.SubAuthID,
+		authPassword: extraSettings.AuthPassword,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	switch {
+	case p.authID == "" && p.subAuthID == "":
+		return fmt.Errorf("%w: auth id or sub auth id must be set", errors.ErrAuthIDNotSet)
+	case p.authPassword == "":
+		return fmt.Errorf("%w", errors.ErrPasswordNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.ClouDNS, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://www.cloudns.net/\">ClouDNS</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://www.cloudns.net/wiki/article/42/
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	record, err := p.getRecord(ctx, client, recordType)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting record: %w", err)
+	}
+
+	if record.Record == ip.String() {
+		return ip, nil
+	}
+
+	err = p.updateRecord(ctx, client, record, ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating record: %w", err)
+	}
+
+	return ip, nil
+}
@@ -0,0 +1,175 @@
+package constellix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecord struct {
+	ID         int64             `json:"id,omitempty"`
+	Name       string            `json:"name"`
+	TTL        uint32            `json:"ttl"`
+	RoundRobin []roundRobinValue `json:"roundRobin"`
+}
+
+type roundRobinValue struct {
+	Value       string `json:"value"`
+	DisableFlag bool   `json:"disableFlag"`
+}
+
+// See https://api-docs.constellix.com/#get-domain-by-name
+func (p *Provider) getDomainID(ctx context.Context, client *http.Client) (
+	domainID int64, err error) {
+	values := url.Values{}
+	values.Set("exact", p.domain)
+	var domains []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, "/domains/search", values, nil, &domains)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, domain := range domains {
+		if domain.Name == p.domain {
+			return domain.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: in %d domain(s)", errors.ErrDomainNotFound, len(domains))
+}
+
+// See https://api-docs.constellix.com/#search-records
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	domainID int64, recordType string) (record apiRecord, err error) {
+	recordName := utils.BuildRecordName(p.host)
+	values := url.Values{}
+	values.Set("exact", recordName)
+	var records []apiRecord
+	err = p.doRequest(ctx, client, http.MethodGet,
+		recordsPath(domainID, recordType)+"/search", values, nil, &records)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	for _, record := range records {
+		if record.Name == recordName {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(records))
+}
+
+// See https://api-docs.constellix.com/#update-a-record
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	domainID int64, record apiRecord, ip netip.Addr) (err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+	path := recordsPath(domainID, recordType) + "/" + strconv.FormatInt(record.ID, 10)
+	record.ID = 0
+	record.RoundRobin = []roundRobinValue{{Value: ip.String()}}
+	return p.doRequest(ctx, client, http.MethodPut, path, nil, record, nil)
+}
+
+func recordsPath(domainID int64, recordType string) string {
+	return "/domains/" + strconv.FormatInt(domainID, 10) + "/records/" + recordType
+}
+
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	method, path string, values url.Values, requestData, responseData any) (err error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.dns.constellix.com",
+		Path:     "/v1" + path,
+		RawQuery: values.Encode(),
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+	sign(request, p.apiKey, p.secretKey, p.timeNow())
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return makeStatusError(response)
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
+
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Errors []string `json:"errors"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && len(data.Errors) > 0 {
+		message = strings.Join(data.Errors, ", ")
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, message)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", errors.ErrRateLimited, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
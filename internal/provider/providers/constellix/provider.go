@@ -0,0 +1,129 @@
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	apiKey     string
+	secretKey  string
+	timeNow    func() time.Time
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		APIKey    string `json:"api_key"`
+		SecretKey string `json:"secret_key"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding constellix extra settings: %w", err)
+	}
+	p = &Provider{
+		domain:     domain,
+		host:       host,
+		ipVersion:  ipVersion,
+		ipv6Suffix: ipv6Suffix,
+		apiKey:     extraSettings.APIKey,
+		secretKey:  extraSettings.SecretKey,
+		timeNow:    time.Now,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	switch {
+	case p.apiKey == "":
+		return fmt.Errorf("%w", errors.ErrAPIKeyNotSet)
+	case p.secretKey == "":
+		return fmt.Errorf("%w", errors.ErrSecretNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.Constellix, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://constellix.com/\">Constellix</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://api-docs.constellix.com/
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	domainID, err := p.getDomainID(ctx, client)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting domain id: %w", err)
+	}
+
+	record, err := p.getRecord(ctx, client, domainID, recordType)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting record: %w", err)
+	}
+
+	if len(record.RoundRobin) == 1 && record.RoundRobin[0].Value == ip.String() {
+		return ip, nil
+	}
+
+	err = p.updateRecord(ctx, client, domainID, record, ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating record: %w", err)
+	}
+
+	return ip, nil
+}
@@ -0,0 +1,27 @@
+package constellix
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// sign sets the authentication headers on the request.
+// The HMAC header is the base64 encoded HMAC-SHA1 of the request date,
+// in milliseconds since the Unix epoch, keyed with the secret key.
+// See https://api-docs.constellix.com/#authentication
+func sign(request *http.Request, apiKey, secretKey string, now time.Time) {
+	requestDate := strconv.FormatInt(now.UnixMilli(), 10)
+	request.Header.Set("x-cnsdns-apiKey", apiKey)
+	request.Header.Set("x-cnsdns-requestDate", requestDate)
+	request.Header.Set("x-cnsdns-hmac", computeHMAC(secretKey, requestDate))
+}
+
+func computeHMAC(secretKey, requestDate string) string {
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	_, _ = mac.Write([]byte(requestDate))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
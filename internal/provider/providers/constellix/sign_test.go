@@ -0,0 +1,57 @@
+package constellix
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_computeHMAC(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		secretKey   string
+		requestDate string
+		hmac        string
+	}{
+		"empty_secret": {
+			requestDate: "1700000000000",
+			hmac:        "T/UhzzeZXMiSs4GT6fwHKznVy+Q=",
+		},
+		"secret": {
+			secretKey:   "secretkey",
+			requestDate: "1700000000000",
+			hmac:        "eZZ3CfjiFphzdfNvJQN965UiMiQ=",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hmac := computeHMAC(testCase.secretKey, testCase.requestDate)
+
+			assert.Equal(t, testCase.hmac, hmac)
+		})
+	}
+}
+
+func Test_sign(t *testing.T) {
+	t.Parallel()
+
+	request, err := http.NewRequest(http.MethodGet, "https://api.dns.constellix.com/v1/domains", nil)
+	require.NoError(t, err)
+	now := time.UnixMilli(1700000000000)
+
+	sign(request, "apikey", "secretkey", now)
+
+	expectedHeaders := http.Header{
+		"X-Cnsdns-Apikey":      {"apikey"},
+		"X-Cnsdns-Requestdate": {"1700000000000"},
+		"X-Cnsdns-Hmac":        {"eZZ3CfjiFphzdfNvJQN965UiMiQ="},
+	}
+	assert.Equal(t, expectedHeaders, request.Header)
+}
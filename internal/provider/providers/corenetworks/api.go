@@ -0,0 +1,161 @@
+package corenetworks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+// defaultTTL is the TTL in seconds of created records if no ttl
+// is configured and no previous record exists.
+const defaultTTL = 3600
+
+type apiRecord struct {
+	Name string `json:"name"`
+	TTL  uint32 `json:"ttl,omitempty"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
+// See https://beta.api.core-networks.de/doc/#functon_auth_token
+func (p *Provider) getToken(ctx context.Context, client *http.Client) (
+	token string, err error) {
+	requestData := struct {
+		Login    string `json:"login"`
+		Password string `json:"password"`
+	}{
+		Login:    p.user,
+		Password: p.password,
+	}
+	var data struct {
+		Token string `json:"token"`
+	}
+	err = doRequest(ctx, client, "", http.MethodPost, "/auth/token", nil,
+		requestData, &data)
+	if err != nil {
+		return "", err
+	}
+
+	if data.Token == "" {
+		return "", fmt.Errorf("%w: no token", errors.ErrReceivedNoResult)
+	}
+	return data.Token, nil
+}
+
+// See https://beta.api.core-networks.de/doc/#functon_dnszones_records
+func (p *Provider) getRecords(ctx context.Context, client *http.Client,
+	token, recordType string) (records []apiRecord, err error) {
+	values := url.Values{}
+	values.Set("name", p.host)
+	values.Set("type", recordType)
+	err = doRequest(ctx, client, token, http.MethodGet, p.recordsPath()+"/", values,
+		nil, &records)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// See https://beta.api.core-networks.de/doc/#functon_dnszones_records_delete
+func (p *Provider) deleteRecord(ctx context.Context, client *http.Client,
+	token string, record apiRecord) (err error) {
+	requestData := apiRecord{
+		Name: record.Name,
+		Type: record.Type,
+		Data: record.Data,
+	}
+	return doRequest(ctx, client, token, http.MethodPost, p.recordsPath()+"/delete", nil,
+		requestData, nil)
+}
+
+// See https://beta.api.core-networks.de/doc/#functon_dnszones_records_add
+func (p *Provider) createRecord(ctx context.Context, client *http.Client,
+	token string, record apiRecord) (err error) {
+	return doRequest(ctx, client, token, http.MethodPost, p.recordsPath()+"/", nil,
+		record, nil)
+}
+
+// commit applies the changes made to the zone records.
+// See https://beta.api.core-networks.de/doc/#functon_dnszones_commit
+func (p *Provider) commit(ctx context.Context, client *http.Client,
+	token string) (err error) {
+	return doRequest(ctx, client, token, http.MethodPost, p.recordsPath()+"/commit", nil,
+		nil, nil)
+}
+
+func (p *Provider) recordsPath() string {
+	return "/dnszones/" + p.domain + "/records"
+}
+
+// doRequest sends a request to the API and decodes the response body
+// into responseData if it is not nil. The token is not set in the
+// request headers if it is empty.
+func doRequest(ctx context.Context, client *http.Client, token,
+	method, path string, values url.Values, requestData, responseData any) (err error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "beta.api.core-networks.de",
+		Path:     path,
+		RawQuery: values.Encode(),
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+	if token != "" {
+		headers.SetAuthBearer(request, token)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, utils.BodyToSingleLine(response.Body))
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrZoneNotFound, utils.BodyToSingleLine(response.Body))
+	default:
+		return fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
+			response.StatusCode, utils.BodyToSingleLine(response.Body))
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
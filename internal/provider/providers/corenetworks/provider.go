@@ -0,0 +1,156 @@
+package corenetworks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	user       string
+	password   string
+	ttl        uint32
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+		TTL      uint32 `json:"ttl"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding corenetworks extra settings: %w", err)
+	}
+	p = &Provider{
+		domain:     domain,
+		host:       host,
+		ipVersion:  ipVersion,
+		ipv6Suffix: ipv6Suffix,
+		user:       extraSettings.User,
+		password:   extraSettings.Password,
+		ttl:        extraSettings.TTL,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	switch {
+	case p.user == "":
+		return fmt.Errorf("%w", errors.ErrUsernameNotSet)
+	case p.password == "":
+		return fmt.Errorf("%w", errors.ErrPasswordNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.CoreNetworks, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://www.core-networks.de/\">Core-Networks</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://beta.api.core-networks.de/doc/
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	token, err := p.getToken(ctx, client)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting token: %w", err)
+	}
+
+	records, err := p.getRecords(ctx, client, token, recordType)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting records: %w", err)
+	}
+
+	if len(records) == 1 && records[0].Data == ip.String() {
+		return ip, nil
+	}
+
+	// The API has no update endpoint, so existing records
+	// are deleted and a new record is created instead.
+	for _, record := range records {
+		err = p.deleteRecord(ctx, client, token, record)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("deleting record: %w", err)
+		}
+	}
+
+	newRecord := apiRecord{
+		Name: p.host,
+		TTL:  defaultTTL,
+		Type: recordType,
+		Data: ip.String(),
+	}
+	switch {
+	case p.ttl != 0:
+		newRecord.TTL = p.ttl
+	case len(records) > 0:
+		newRecord.TTL = records[0].TTL
+	}
+	err = p.createRecord(ctx, client, token, newRecord)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("creating record: %w", err)
+	}
+
+	// Changes to the zone are only applied once committed.
+	err = p.commit(ctx, client, token)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("committing zone changes: %w", err)
+	}
+
+	return ip, nil
+}
@@ -0,0 +1,109 @@
+package corenetworks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func Test_Provider_Update(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		recordsBody string
+		requests    []string
+	}{
+		"record_up_to_date": {
+			recordsBody: `[{"name":"sub","ttl":1800,"type":"A","data":"1.2.3.4"}]`,
+			requests: []string{
+				"POST /auth/token",
+				"GET /dnszones/domain.com/records/?name=sub&type=A",
+			},
+		},
+		"record_changed": {
+			recordsBody: `[{"name":"sub","ttl":1800,"type":"A","data":"5.6.7.8"}]`,
+			requests: []string{
+				"POST /auth/token",
+				"GET /dnszones/domain.com/records/?name=sub&type=A",
+				"POST /dnszones/domain.com/records/delete " +
+					`{"name":"sub","type":"A","data":"5.6.7.8"}`,
+				"POST /dnszones/domain.com/records/ " +
+					`{"name":"sub","ttl":1800,"type":"A","data":"1.2.3.4"}`,
+				"POST /dnszones/domain.com/records/commit",
+			},
+		},
+		"record_absent": {
+			recordsBody: `[]`,
+			requests: []string{
+				"POST /auth/token",
+				"GET /dnszones/domain.com/records/?name=sub&type=A",
+				"POST /dnszones/domain.com/records/ " +
+					`{"name":"sub","ttl":3600,"type":"A","data":"1.2.3.4"}`,
+				"POST /dnszones/domain.com/records/commit",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []string
+			client := &http.Client{
+				Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					request := r.Method + " " + r.URL.RequestURI()
+					if r.Body != nil && r.URL.Path != "/auth/token" {
+						b, err := io.ReadAll(r.Body)
+						require.NoError(t, err)
+						request += " " + strings.TrimSpace(string(b))
+					}
+					requests = append(requests, request)
+
+					responseBody := ""
+					switch r.URL.Path {
+					case "/auth/token":
+						assert.Empty(t, r.Header.Get("Authorization"))
+						responseBody = `{"token":"token","expires":3600}`
+					case "/dnszones/domain.com/records/":
+						assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+						if r.Method == http.MethodGet {
+							responseBody = testCase.recordsBody
+						}
+					default:
+						assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(responseBody)),
+					}, nil
+				}),
+			}
+
+			provider := &Provider{
+				domain:   "domain.com",
+				host:     "sub",
+				user:     "user",
+				password: "password",
+			}
+			ip := netip.MustParseAddr("1.2.3.4")
+
+			newIP, err := provider.Update(context.Background(), client, ip)
+
+			require.NoError(t, err)
+			assert.Equal(t, ip, newIP)
+			assert.Equal(t, testCase.requests, requests)
+		})
+	}
+}
@@ -101,57 +101,87 @@ func (p *Provider) HTML() models.HTMLRow {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	if ip.Is6() {
+		_, newIP, err = p.update(ctx, client, netip.Addr{}, ip)
+	} else {
+		newIP, _, err = p.update(ctx, client, ip, netip.Addr{})
+	}
+	return newIP, err
+}
+
+// UpdateBoth updates the A and AAAA records in a single request.
+func (p *Provider) UpdateBoth(ctx context.Context, client *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
+	return p.update(ctx, client, ipv4, ipv6)
+}
+
+func (p *Provider) update(ctx context.Context, client *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
 	u := url.URL{
 		Scheme: "https",
-		User:   url.UserPassword(p.BuildDomainName(), p.token),
 		Host:   "update.dedyn.io",
 		Path:   "/nic/update",
 	}
 	values := url.Values{}
 	values.Set("hostname", utils.BuildURLQueryHostname(p.host, p.domain))
-	useProviderIP := p.useProviderIP && (ip.Is4() || !p.ipv6Suffix.IsValid())
-	if useProviderIP {
-		values.Set("myip", ip.String())
+	// deSEC can only detect the IP address the request comes from,
+	// so both addresses are sent explicitly when updating both records.
+	dualStack := ipv4.IsValid() && ipv6.IsValid()
+	useProviderIP := p.useProviderIP && !dualStack &&
+		(ipv4.IsValid() || !p.ipv6Suffix.IsValid())
+	if !useProviderIP {
+		// deSEC removes the record of the IP family not specified,
+		// so the other family record is explicitly preserved.
+		values.Set("myipv4", "preserve")
+		values.Set("myipv6", "preserve")
+		if ipv4.IsValid() {
+			values.Set("myipv4", ipv4.String())
+		}
+		if ipv6.IsValid() {
+			values.Set("myipv6", ipv6.String())
+		}
 	}
 	u.RawQuery = values.Encode()
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("creating http request: %w", err)
 	}
 	headers.SetUserAgent(request)
+	request.Header.Set("Authorization", "Token "+p.token)
 
 	response, err := client.Do(request)
 	if err != nil {
-		return netip.Addr{}, err
+		return netip.Addr{}, netip.Addr{}, err
 	}
 	defer response.Body.Close()
 
 	b, err := io.ReadAll(response.Body)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("reading response body: %w", err)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("reading response body: %w", err)
 	}
 	s := string(b)
 
 	switch response.StatusCode {
 	case http.StatusOK:
 	case http.StatusUnauthorized:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrAuth, utils.ToSingleLine(s))
-	case http.StatusNotFound:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrHostnameNotExists, utils.ToSingleLine(s))
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrAuth, utils.ToSingleLine(s))
+	case http.StatusForbidden, http.StatusNotFound:
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrRecordNotOwned, utils.ToSingleLine(s))
 	default:
-		return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
-			response.StatusCode, utils.ToSingleLine(s))
+		return netip.Addr{}, netip.Addr{}, errors.HTTPStatus(response.StatusCode, utils.ToSingleLine(s))
 	}
 
 	switch {
 	case strings.HasPrefix(s, constants.Notfqdn):
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
+	case strings.HasPrefix(s, constants.Nohost):
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w", errors.ErrRecordNotOwned)
 	case strings.HasPrefix(s, "badrequest"):
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBadRequest)
-	case strings.HasPrefix(s, "good"):
-		return ip, nil
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w", errors.ErrBadRequest)
+	case strings.HasPrefix(s, "good"), strings.HasPrefix(s, constants.Nochg):
+		return ipv4, ipv6, nil
 	default:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, utils.ToSingleLine(s))
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, utils.ToSingleLine(s))
 	}
 }
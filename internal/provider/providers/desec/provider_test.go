@@ -0,0 +1,126 @@
+package desec
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func Test_Provider_update(t *testing.T) {
+	t.Parallel()
+
+	ipv4 := netip.MustParseAddr("1.2.3.4")
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+
+	testCases := map[string]struct {
+		useProviderIP bool
+		ipv6Suffix    netip.Prefix
+		ipv4          netip.Addr
+		ipv6          netip.Addr
+		query         url.Values
+	}{
+		"ipv4": {
+			ipv4: ipv4,
+			query: url.Values{
+				"hostname": {"example.com"},
+				"myipv4":   {"1.2.3.4"},
+				"myipv6":   {"preserve"},
+			},
+		},
+		"ipv6": {
+			ipv6: ipv6,
+			query: url.Values{
+				"hostname": {"example.com"},
+				"myipv4":   {"preserve"},
+				"myipv6":   {"2001:db8::1"},
+			},
+		},
+		"ipv4_provider_ip": {
+			useProviderIP: true,
+			ipv4:          ipv4,
+			query:         url.Values{"hostname": {"example.com"}},
+		},
+		"ipv6_provider_ip": {
+			useProviderIP: true,
+			ipv6:          ipv6,
+			query:         url.Values{"hostname": {"example.com"}},
+		},
+		"ipv6_provider_ip_with_suffix": {
+			useProviderIP: true,
+			ipv6Suffix:    netip.MustParsePrefix("::1/64"),
+			ipv6:          ipv6,
+			query: url.Values{
+				"hostname": {"example.com"},
+				"myipv4":   {"preserve"},
+				"myipv6":   {"2001:db8::1"},
+			},
+		},
+		"dual_stack": {
+			ipv4: ipv4,
+			ipv6: ipv6,
+			query: url.Values{
+				"hostname": {"example.com"},
+				"myipv4":   {"1.2.3.4"},
+				"myipv6":   {"2001:db8::1"},
+			},
+		},
+		"dual_stack_provider_ip": {
+			useProviderIP: true,
+			ipv4:          ipv4,
+			ipv6:          ipv6,
+			query: url.Values{
+				"hostname": {"example.com"},
+				"myipv4":   {"1.2.3.4"},
+				"myipv6":   {"2001:db8::1"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &http.Client{
+				Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, "update.dedyn.io", r.URL.Host)
+					assert.Equal(t, "/nic/update", r.URL.Path)
+					assert.Equal(t, "Token token", r.Header.Get("Authorization"))
+					assert.Equal(t, testCase.query, r.URL.Query())
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader("good")),
+					}, nil
+				}),
+			}
+
+			provider := &Provider{
+				domain:        "example.com",
+				host:          "@",
+				ipv6Suffix:    testCase.ipv6Suffix,
+				token:         "token",
+				useProviderIP: testCase.useProviderIP,
+			}
+
+			newIPv4, newIPv6, err := provider.update(context.Background(), client,
+				testCase.ipv4, testCase.ipv6)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ipv4, newIPv4)
+			assert.Equal(t, testCase.ipv6, newIPv6)
+		})
+	}
+}
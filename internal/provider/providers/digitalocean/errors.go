@@ -0,0 +1,29 @@
+package digitalocean
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+// checkStatusCode returns an error if the response status code is not
+// the expected status code, classifying authentication and rate
+// limiting errors. See https://docs.digitalocean.com/reference/api/api-reference/#section/Introduction/Rate-Limit
+func checkStatusCode(response *http.Response, expected int) (err error) {
+	switch response.StatusCode {
+	case expected:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrAuth, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s requests remaining, resetting at unix time %s",
+			errors.ErrRateLimited, response.Header.Get("Ratelimit-Remaining"),
+			response.Header.Get("Ratelimit-Reset"))
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"net/netip"
 	"net/url"
+	"strings"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
@@ -123,9 +125,9 @@ func (p *Provider) getRecordID(ctx context.Context, recordType string, client *h
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("%w: %d: %s",
-			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	err = checkStatusCode(response, http.StatusOK)
+	if err != nil {
+		return 0, err
 	}
 
 	decoder := json.NewDecoder(response.Body)
@@ -140,7 +142,7 @@ func (p *Provider) getRecordID(ctx context.Context, recordType string, client *h
 	}
 
 	if len(result.DomainRecords) == 0 {
-		return 0, fmt.Errorf("%w", errors.ErrReceivedNoResult)
+		return 0, fmt.Errorf("%w", errors.ErrRecordNotFound)
 	} else if result.DomainRecords[0].ID == 0 {
 		return 0, fmt.Errorf("%w", errors.ErrDomainIDNotFound)
 	}
@@ -154,9 +156,60 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		recordType = constants.AAAA
 	}
 
-	recordID, err := p.getRecordID(ctx, recordType, client)
+	created, receivedData, err := p.setRecord(ctx, client, recordType, ip.String())
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("getting record id: %w", err)
+		return netip.Addr{}, err
+	} else if created {
+		return ip, nil
+	}
+
+	newIP, err = netip.ParseAddr(receivedData)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("%w: %w", errors.ErrIPReceivedMalformed, err)
+	} else if newIP.Compare(ip) != 0 {
+		return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
+			errors.ErrIPReceivedMismatch, ip, newIP)
+	}
+	return newIP, nil
+}
+
+// UpdateValue sets the record of the type given to the value given.
+func (p *Provider) UpdateValue(ctx context.Context, client *http.Client,
+	recordType, value string) (err error) {
+	created, receivedData, err := p.setRecord(ctx, client, recordType, value)
+	if err != nil {
+		return err
+	} else if !created && !valuesEqual(recordType, value, receivedData) {
+		return fmt.Errorf("%w: sent value %q to update but received %q",
+			errors.ErrUnknownResponse, value, receivedData)
+	}
+	return nil
+}
+
+// valuesEqual compares the value sent with the value received, ignoring
+// the trailing dot of CNAME targets which is not always returned.
+func valuesEqual(recordType, sent, received string) bool {
+	if recordType == constants.CNAME {
+		return strings.TrimSuffix(sent, ".") == strings.TrimSuffix(received, ".")
+	}
+	return sent == received
+}
+
+// setRecord updates the record of the type given with the data given,
+// creating it if it does not exist. It returns the data received in
+// the response if the record was updated instead of created.
+// See https://docs.digitalocean.com/reference/api/api-reference/#operation/domains_update_record
+func (p *Provider) setRecord(ctx context.Context, client *http.Client,
+	recordType, data string) (created bool, receivedData string, err error) {
+	recordID, err := p.getRecordID(ctx, recordType, client)
+	if stderrors.Is(err, errors.ErrRecordNotFound) {
+		err = p.createRecord(ctx, client, recordType, data)
+		if err != nil {
+			return false, "", fmt.Errorf("creating record: %w", err)
+		}
+		return true, "", nil
+	} else if err != nil {
+		return false, "", fmt.Errorf("getting record id: %w", err)
 	}
 
 	u := url.URL{
@@ -174,29 +227,29 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	}{
 		Type: recordType,
 		Name: p.host,
-		Data: ip.String(),
+		Data: data,
 	}
 	err = encoder.Encode(requestData)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("json encoding request data: %w", err)
+		return false, "", fmt.Errorf("json encoding request data: %w", err)
 	}
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), buffer)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+		return false, "", fmt.Errorf("creating http request: %w", err)
 	}
 	p.setCommonHeaders(request)
 	headers.SetContentType(request, "application/json")
 
 	response, err := client.Do(request)
 	if err != nil {
-		return netip.Addr{}, err
+		return false, "", err
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		return netip.Addr{}, fmt.Errorf("%w: %d: %s",
-			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	err = checkStatusCode(response, http.StatusOK)
+	if err != nil {
+		return false, "", err
 	}
 
 	decoder := json.NewDecoder(response.Body)
@@ -207,15 +260,8 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	}
 	err = decoder.Decode(&responseData)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("json decoding response body: %w", err)
+		return false, "", fmt.Errorf("json decoding response body: %w", err)
 	}
 
-	newIP, err = netip.ParseAddr(responseData.DomainRecord.Data)
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("%w: %w", errors.ErrIPReceivedMalformed, err)
-	} else if newIP.Compare(ip) != 0 {
-		return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
-			errors.ErrIPReceivedMismatch, ip, newIP)
-	}
-	return newIP, nil
+	return false, responseData.DomainRecord.Data, nil
 }
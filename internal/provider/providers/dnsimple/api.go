@@ -0,0 +1,196 @@
+package dnsimple
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecord struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// See https://developer.dnsimple.com/v2/identity/#whoami
+func (p *Provider) getAccountID(ctx context.Context, client *http.Client) (
+	accountID string, err error) {
+	var data struct {
+		Account *struct {
+			ID int64 `json:"id"`
+		} `json:"account"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, "/v2/whoami", nil, nil, http.StatusOK, &data)
+	if err != nil {
+		return "", err
+	}
+
+	if data.Account == nil {
+		return "", fmt.Errorf("%w: the token is a user token so "+
+			"the account id must be set", errors.ErrReceivedNoResult)
+	}
+	return strconv.FormatInt(data.Account.ID, 10), nil
+}
+
+// See https://developer.dnsimple.com/v2/zones/records/#listZoneRecords
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	recordType string) (record apiRecord, err error) {
+	values := url.Values{}
+	values.Set("name", utils.BuildRecordName(p.host))
+	values.Set("type", recordType)
+	var records []apiRecord
+	err = p.doRequest(ctx, client, http.MethodGet, p.recordsPath(), values, nil, http.StatusOK, &records)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	recordName := utils.BuildRecordName(p.host)
+	for _, record := range records {
+		if record.Name == recordName && record.Type == recordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w", errors.ErrRecordNotFound)
+}
+
+// See https://developer.dnsimple.com/v2/zones/records/#createZoneRecord
+func (p *Provider) createRecord(ctx context.Context, client *http.Client,
+	recordType string, ip netip.Addr) (err error) {
+	requestData := struct {
+		Name    string `json:"name"`
+		Type    string `json:"type"`
+		Content string `json:"content"`
+	}{
+		Name:    utils.BuildRecordName(p.host),
+		Type:    recordType,
+		Content: ip.String(),
+	}
+	return p.doRequest(ctx, client, http.MethodPost, p.recordsPath(), nil,
+		requestData, http.StatusCreated, nil)
+}
+
+// See https://developer.dnsimple.com/v2/zones/records/#updateZoneRecord
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	recordID int64, ip netip.Addr) (err error) {
+	requestData := struct {
+		Content string `json:"content"`
+	}{
+		Content: ip.String(),
+	}
+	path := p.recordsPath() + "/" + strconv.FormatInt(recordID, 10)
+	return p.doRequest(ctx, client, http.MethodPatch, path, nil,
+		requestData, http.StatusOK, nil)
+}
+
+func (p *Provider) recordsPath() string {
+	return "/v2/" + p.accountID + "/zones/" + p.domain + "/records"
+}
+
+// doRequest sends a request to the DNSimple API, checks the response status
+// code is the expected one and decodes the response data field into
+// responseData if it is not nil.
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	method, path string, values url.Values, requestData any,
+	expectedStatus int, responseData any) (err error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.dnsimple.com",
+		Path:     path,
+		RawQuery: values.Encode(),
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	headers.SetAuthBearer(request, p.token)
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != expectedStatus {
+		return makeStatusError(response)
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	var data struct {
+		Data json.RawMessage `json:"data"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	err = json.Unmarshal(data.Data, responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response data: %w", err)
+	}
+	return nil
+}
+
+// See https://developer.dnsimple.com/v2/#errors
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Message string `json:"message"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && data.Message != "" {
+		message = data.Message
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrZoneNotFound, message)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	case http.StatusTooManyRequests:
+		// See https://developer.dnsimple.com/v2/#rate-limiting
+		return fmt.Errorf("%w: %s: rate limit resets at unix time %s",
+			errors.ErrRateLimited, message, response.Header.Get("X-RateLimit-Reset"))
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
@@ -0,0 +1,133 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	token      string
+	// accountID is discovered from the token if it is left empty.
+	accountID string
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		Token     string `json:"token"`
+		AccountID string `json:"account_id"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding dnsimple extra settings: %w", err)
+	}
+	p = &Provider{
+		domain:     domain,
+		host:       host,
+		ipVersion:  ipVersion,
+		ipv6Suffix: ipv6Suffix,
+		token:      extraSettings.Token,
+		accountID:  extraSettings.AccountID,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	if p.token == "" {
+		return fmt.Errorf("%w", errors.ErrTokenNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.DNSimple, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://dnsimple.com/\">DNSimple</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://developer.dnsimple.com/v2/zones/records/
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	if p.accountID == "" {
+		p.accountID, err = p.getAccountID(ctx, client)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("getting account id: %w", err)
+		}
+	}
+
+	record, err := p.getRecord(ctx, client, recordType)
+	if stderrors.Is(err, errors.ErrRecordNotFound) {
+		err = p.createRecord(ctx, client, recordType, ip)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("creating record: %w", err)
+		}
+		return ip, nil
+	} else if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting record: %w", err)
+	}
+
+	if record.Content == ip.String() {
+		return ip, nil
+	}
+
+	err = p.updateRecord(ctx, client, record.ID, ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating record: %w", err)
+	}
+
+	return ip, nil
+}
@@ -0,0 +1,157 @@
+package dnsmadeeasy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecord struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	TTL         uint32 `json:"ttl"`
+	GtdLocation string `json:"gtdLocation"`
+}
+
+// See https://api-docs.dnsmadeeasy.com/#managed-dns-get-domain-by-name
+func (p *Provider) getDomainID(ctx context.Context, client *http.Client) (
+	domainID int64, err error) {
+	values := url.Values{}
+	values.Set("domainname", p.domain)
+	var data struct {
+		ID int64 `json:"id"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, "/dns/managed/name", values, nil, &data)
+	if err != nil {
+		return 0, err
+	}
+	return data.ID, nil
+}
+
+// See https://api-docs.dnsmadeeasy.com/#managed-dns-records-get
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	domainID int64, recordType string) (record apiRecord, err error) {
+	recordName := utils.BuildRecordName(p.host)
+	values := url.Values{}
+	values.Set("recordName", recordName)
+	values.Set("type", recordType)
+	path := "/dns/managed/" + strconv.FormatInt(domainID, 10) + "/records"
+	var data struct {
+		Data []apiRecord `json:"data"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, path, values, nil, &data)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	for _, record := range data.Data {
+		if record.Name == recordName && record.Type == recordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(data.Data))
+}
+
+// See https://api-docs.dnsmadeeasy.com/#managed-dns-records-update
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	domainID int64, record apiRecord, ip netip.Addr) (err error) {
+	record.Value = ip.String()
+	path := "/dns/managed/" + strconv.FormatInt(domainID, 10) +
+		"/records/" + strconv.FormatInt(record.ID, 10)
+	return p.doRequest(ctx, client, http.MethodPut, path, nil, record, nil)
+}
+
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	method, path string, values url.Values, requestData, responseData any) (err error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.dnsmadeeasy.com",
+		Path:     "/V2.0" + path,
+		RawQuery: values.Encode(),
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+	sign(request, p.apiKey, p.secretKey, p.timeNow())
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return makeStatusError(response)
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
+
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Error []string `json:"error"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && len(data.Error) > 0 {
+		message = strings.Join(data.Error, ", ")
+	}
+
+	switch response.StatusCode {
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, message)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
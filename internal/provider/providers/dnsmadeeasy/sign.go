@@ -0,0 +1,26 @@
+package dnsmadeeasy
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// sign sets the authentication headers on the request.
+// The HMAC header is the hex encoded HMAC-SHA1 of the request date
+// keyed with the secret key.
+// See https://api-docs.dnsmadeeasy.com/#authentication
+func sign(request *http.Request, apiKey, secretKey string, now time.Time) {
+	requestDate := now.UTC().Format(http.TimeFormat)
+	request.Header.Set("x-dnsme-apiKey", apiKey)
+	request.Header.Set("x-dnsme-requestDate", requestDate)
+	request.Header.Set("x-dnsme-hmac", computeHMAC(secretKey, requestDate))
+}
+
+func computeHMAC(secretKey, requestDate string) string {
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	_, _ = mac.Write([]byte(requestDate))
+	return hex.EncodeToString(mac.Sum(nil))
+}
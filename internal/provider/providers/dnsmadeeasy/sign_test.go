@@ -0,0 +1,57 @@
+package dnsmadeeasy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_computeHMAC(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		secretKey   string
+		requestDate string
+		hmac        string
+	}{
+		"empty_secret": {
+			requestDate: "Tue, 14 Nov 2023 22:13:20 GMT",
+			hmac:        "2ecd5a8772ddb968c5a947db9ad9ba170db655ed",
+		},
+		"secret": {
+			secretKey:   "secretkey",
+			requestDate: "Tue, 14 Nov 2023 22:13:20 GMT",
+			hmac:        "88757c8e03b04efa66de184c710cc836b3676fba",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hmac := computeHMAC(testCase.secretKey, testCase.requestDate)
+
+			assert.Equal(t, testCase.hmac, hmac)
+		})
+	}
+}
+
+func Test_sign(t *testing.T) {
+	t.Parallel()
+
+	request, err := http.NewRequest(http.MethodGet, "https://api.dnsmadeeasy.com/V2.0/dns/managed", nil)
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0).In(time.FixedZone("UTC+2", 2*60*60))
+
+	sign(request, "apikey", "secretkey", now)
+
+	expectedHeaders := http.Header{
+		"X-Dnsme-Apikey":      {"apikey"},
+		"X-Dnsme-Requestdate": {"Tue, 14 Nov 2023 22:13:20 GMT"},
+		"X-Dnsme-Hmac":        {"88757c8e03b04efa66de184c710cc836b3676fba"},
+	}
+	assert.Equal(t, expectedHeaders, request.Header)
+}
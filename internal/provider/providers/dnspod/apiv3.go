@@ -0,0 +1,167 @@
+package dnspod
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiV3Record struct {
+	RecordID uint64 `json:"RecordId"`
+	Value    string `json:"Value"`
+	Type     string `json:"Type"`
+	Name     string `json:"Name"`
+	Line     string `json:"Line"`
+}
+
+func (p *Provider) updateWithAPIv3(ctx context.Context, client *http.Client,
+	ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	// See https://www.tencentcloud.com/document/api/1157/49139
+	listRequest := struct {
+		Domain     string `json:"Domain"`
+		Subdomain  string `json:"Subdomain"`
+		RecordType string `json:"RecordType"`
+	}{
+		Domain:     p.domain,
+		Subdomain:  p.host,
+		RecordType: recordType,
+	}
+	var listResponse struct {
+		RecordList []apiV3Record `json:"RecordList"`
+	}
+	err = p.doAPIv3(ctx, client, "DescribeRecordList", listRequest, &listResponse)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("describing record list: %w", err)
+	}
+
+	var record apiV3Record
+	found := false
+	for _, record = range listResponse.RecordList {
+		if record.Type == recordType && record.Name == p.host {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrRecordNotFound)
+	}
+
+	receivedIP, err := netip.ParseAddr(record.Value)
+	if err == nil && ip.Compare(receivedIP) == 0 {
+		return ip, nil
+	}
+
+	// See https://www.tencentcloud.com/document/api/1157/49145
+	modifyRequest := struct {
+		Domain     string `json:"Domain"`
+		SubDomain  string `json:"SubDomain"`
+		RecordType string `json:"RecordType"`
+		RecordLine string `json:"RecordLine"`
+		Value      string `json:"Value"`
+		RecordID   uint64 `json:"RecordId"`
+	}{
+		Domain:     p.domain,
+		SubDomain:  p.host,
+		RecordType: recordType,
+		RecordLine: record.Line,
+		Value:      ip.String(),
+		RecordID:   record.RecordID,
+	}
+	var modifyResponse struct{}
+	err = p.doAPIv3(ctx, client, "ModifyRecord", modifyRequest, &modifyResponse)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("modifying record: %w", err)
+	}
+
+	return ip, nil
+}
+
+// doAPIv3 sends a signed request for the action given to the Tencent
+// Cloud API v3 and decodes the response into the responseData pointer.
+func (p *Provider) doAPIv3(ctx context.Context, client *http.Client, action string,
+	requestData, responseData any) (err error) {
+	payload, err := json.Marshal(requestData)
+	if err != nil {
+		return fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	u := url.URL{
+		Scheme: "https",
+		Host:   apiV3Host,
+		Path:   "/",
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	signTC3(request, action, payload, p.secretID, p.secretKey, p.timeNow())
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	}
+
+	var data struct {
+		Response json.RawMessage `json:"Response"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	var errorData struct {
+		Error *struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"Error"`
+	}
+	err = json.Unmarshal(data.Response, &errorData)
+	if err != nil {
+		return fmt.Errorf("json decoding response error: %w", err)
+	}
+
+	// See https://www.tencentcloud.com/document/api/1157/49152
+	if apiError := errorData.Error; apiError != nil {
+		switch {
+		case strings.HasPrefix(apiError.Code, "AuthFailure"):
+			return fmt.Errorf("%w: %s: %s", errors.ErrAuth, apiError.Code, apiError.Message)
+		case apiError.Code == "ResourceNotFound.NoDataOfRecord":
+			return fmt.Errorf("%w: %s", errors.ErrRecordNotFound, apiError.Message)
+		case apiError.Code == "RequestLimitExceeded":
+			return fmt.Errorf("%w: %s", errors.ErrRateLimited, apiError.Message)
+		default:
+			return fmt.Errorf("%w: %s: %s", errors.ErrUnsuccessful, apiError.Code, apiError.Message)
+		}
+	}
+
+	err = json.Unmarshal(data.Response, responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response data: %w", err)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/netip"
 	"net/url"
+	"time"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
@@ -23,18 +24,25 @@ type Provider struct {
 	host       string
 	ipVersion  ipversion.IPVersion
 	ipv6Suffix netip.Prefix
-	token      string
+	// token is the legacy DNSPod API token. If it is empty,
+	// the Tencent Cloud API v3 is used with the secret id and key.
+	token     string
+	secretID  string
+	secretKey string
+	timeNow   func() time.Time
 }
 
 func New(data json.RawMessage, domain, host string,
 	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
 	p *Provider, err error) {
 	extraSettings := struct {
-		Token string `json:"token"`
+		Token     string `json:"token"`
+		SecretID  string `json:"secret_id"`
+		SecretKey string `json:"secret_key"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding dnspod extra settings: %w", err)
 	}
 	p = &Provider{
 		domain:     domain,
@@ -42,6 +50,9 @@ func New(data json.RawMessage, domain, host string,
 		ipVersion:  ipVersion,
 		ipv6Suffix: ipv6Suffix,
 		token:      extraSettings.Token,
+		secretID:   extraSettings.SecretID,
+		secretKey:  extraSettings.SecretKey,
+		timeNow:    time.Now,
 	}
 	err = p.isValid()
 	if err != nil {
@@ -51,8 +62,14 @@ func New(data json.RawMessage, domain, host string,
 }
 
 func (p *Provider) isValid() error {
-	if p.token == "" {
+	switch {
+	case p.token != "":
+	case p.secretID == "" && p.secretKey == "":
 		return fmt.Errorf("%w", errors.ErrTokenNotSet)
+	case p.secretID == "":
+		return fmt.Errorf("%w: secret id", errors.ErrKeyNotSet)
+	case p.secretKey == "":
+		return fmt.Errorf("%w", errors.ErrSecretNotSet)
 	}
 	return nil
 }
@@ -101,6 +118,14 @@ func setHeaders(request *http.Request) {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	if p.token == "" {
+		return p.updateWithAPIv3(ctx, client, ip)
+	}
+	return p.updateWithToken(ctx, client, ip)
+}
+
+func (p *Provider) updateWithToken(ctx context.Context, client *http.Client, ip netip.Addr) (
+	newIP netip.Addr, err error) {
 	recordType := constants.A
 	if ip.Is6() {
 		recordType = constants.AAAA
@@ -0,0 +1,88 @@
+package dnspod
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiV3Host    = "dnspod.tencentcloudapi.com"
+	apiV3Service = "dnspod"
+	apiV3Version = "2021-03-23"
+	// tc3ContentType is the content type of requests, which is
+	// also used as is in the canonical request to sign.
+	tc3ContentType   = "application/json; charset=utf-8"
+	tc3SignedHeaders = "content-type;host;x-tc-action"
+)
+
+// signTC3 sets the headers and the TC3-HMAC-SHA256 signature
+// of the request for the API action and request payload given.
+// See https://www.tencentcloud.com/document/api/1157/49029
+func signTC3(request *http.Request, action string, payload []byte,
+	secretID, secretKey string, now time.Time) {
+	now = now.UTC()
+	date := now.Format(time.DateOnly)
+
+	request.Header.Set("Content-Type", tc3ContentType)
+	request.Header.Set("Host", apiV3Host)
+	request.Header.Set("X-TC-Action", action)
+	request.Header.Set("X-TC-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	request.Header.Set("X-TC-Version", apiV3Version)
+
+	canonicalRequest := buildCanonicalRequest(action, payload)
+	stringToSign := buildStringToSign(canonicalRequest, now)
+
+	secretDate := hmacSHA256([]byte("TC3"+secretKey), date)
+	secretService := hmacSHA256(secretDate, apiV3Service)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	request.Header.Set("Authorization", "TC3-HMAC-SHA256 "+
+		"Credential="+secretID+"/"+credentialScope(date)+", "+
+		"SignedHeaders="+tc3SignedHeaders+", "+
+		"Signature="+signature)
+}
+
+func buildCanonicalRequest(action string, payload []byte) string {
+	canonicalHeaders := "content-type:" + tc3ContentType + "\n" +
+		"host:" + apiV3Host + "\n" +
+		"x-tc-action:" + strings.ToLower(action) + "\n"
+	return strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"", // canonical query string, empty for POST requests
+		canonicalHeaders,
+		tc3SignedHeaders,
+		sha256Hex(payload),
+	}, "\n")
+}
+
+func buildStringToSign(canonicalRequest string, now time.Time) string {
+	now = now.UTC()
+	return strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		strconv.FormatInt(now.Unix(), 10),
+		credentialScope(now.Format(time.DateOnly)),
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+}
+
+func credentialScope(date string) string {
+	return date + "/" + apiV3Service + "/tc3_request"
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
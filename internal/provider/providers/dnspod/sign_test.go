@@ -0,0 +1,54 @@
+package dnspod
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_signTC3(t *testing.T) {
+	t.Parallel()
+
+	const action = "DescribeRecordList"
+	payload := []byte(`{"Domain":"example.com","Subdomain":"www","RecordType":"A"}`)
+	now := time.Unix(1700000000, 0)
+
+	canonicalRequest := buildCanonicalRequest(action, payload)
+	const expectedCanonicalRequest = "POST\n" +
+		"/\n" +
+		"\n" +
+		"content-type:application/json; charset=utf-8\n" +
+		"host:dnspod.tencentcloudapi.com\n" +
+		"x-tc-action:describerecordlist\n" +
+		"\n" +
+		"content-type;host;x-tc-action\n" +
+		"ef51c35914dc8dc136249d26dd7f9eb4dd7c8bbb0cb678688c593089285e5437"
+	assert.Equal(t, expectedCanonicalRequest, canonicalRequest)
+
+	stringToSign := buildStringToSign(canonicalRequest, now)
+	const expectedStringToSign = "TC3-HMAC-SHA256\n" +
+		"1700000000\n" +
+		"2023-11-14/dnspod/tc3_request\n" +
+		"fb7884ba87990f20a061ac9597bc785a13b45bf84dd8cbde72671b5c6bdcef44"
+	assert.Equal(t, expectedStringToSign, stringToSign)
+
+	request, err := http.NewRequest(http.MethodPost, "https://"+apiV3Host+"/", nil)
+	require.NoError(t, err)
+	signTC3(request, action, payload, "secretid", "secretkey", now)
+
+	expectedHeaders := http.Header{
+		"Content-Type":   {"application/json; charset=utf-8"},
+		"Host":           {"dnspod.tencentcloudapi.com"},
+		"X-Tc-Action":    {"DescribeRecordList"},
+		"X-Tc-Timestamp": {"1700000000"},
+		"X-Tc-Version":   {"2021-03-23"},
+		"Authorization": {"TC3-HMAC-SHA256 " +
+			"Credential=secretid/2023-11-14/dnspod/tc3_request, " +
+			"SignedHeaders=content-type;host;x-tc-action, " +
+			"Signature=5108e957885bbb6b17ec9926930ca3be91baa462c7cfe207476fa4830a2cc814"},
+	}
+	assert.Equal(t, expectedHeaders, request.Header)
+}
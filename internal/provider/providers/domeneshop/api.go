@@ -0,0 +1,174 @@
+package domeneshop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+// defaultTTL is the TTL in seconds of created records if no ttl is configured.
+const defaultTTL = 3600
+
+type apiRecord struct {
+	ID   int64  `json:"id,omitempty"`
+	Host string `json:"host"`
+	TTL  uint32 `json:"ttl"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
+// See https://api.domeneshop.no/docs/#tag/domains/paths/~1domains/get
+func (p *Provider) getDomainID(ctx context.Context, client *http.Client) (
+	domainID int64, err error) {
+	values := url.Values{}
+	values.Set("domain", p.domain)
+	var domains []struct {
+		ID     int64  `json:"id"`
+		Domain string `json:"domain"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, "/v0/domains", values, nil, http.StatusOK, &domains)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, domain := range domains {
+		if domain.Domain == p.domain {
+			return domain.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: in %d domain(s)", errors.ErrDomainNotFound, len(domains))
+}
+
+// See https://api.domeneshop.no/docs/#tag/dns/paths/~1domains~1%7BdomainId%7D~1dns/get
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	domainID int64, recordType string) (record apiRecord, err error) {
+	values := url.Values{}
+	values.Set("host", p.host)
+	values.Set("type", recordType)
+	var records []apiRecord
+	err = p.doRequest(ctx, client, http.MethodGet, recordsPath(domainID), values, nil, http.StatusOK, &records)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	for _, record := range records {
+		if record.Host == p.host && record.Type == recordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(records))
+}
+
+// See https://api.domeneshop.no/docs/#tag/dns/paths/~1domains~1%7BdomainId%7D~1dns/post
+func (p *Provider) createRecord(ctx context.Context, client *http.Client,
+	domainID int64, record apiRecord) (err error) {
+	return p.doRequest(ctx, client, http.MethodPost, recordsPath(domainID), nil,
+		record, http.StatusCreated, nil)
+}
+
+// See https://api.domeneshop.no/docs/#tag/dns/paths/~1domains~1%7BdomainId%7D~1dns~1%7BrecordId%7D/put
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	domainID int64, record apiRecord) (err error) {
+	path := recordsPath(domainID) + "/" + strconv.FormatInt(record.ID, 10)
+	record.ID = 0
+	return p.doRequest(ctx, client, http.MethodPut, path, nil,
+		record, http.StatusNoContent, nil)
+}
+
+func recordsPath(domainID int64) string {
+	return "/v0/domains/" + strconv.FormatInt(domainID, 10) + "/dns"
+}
+
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	method, path string, values url.Values, requestData any,
+	expectedStatus int, responseData any) (err error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.domeneshop.no",
+		Path:     path,
+		RawQuery: values.Encode(),
+		User:     url.UserPassword(p.token, p.secret),
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != expectedStatus {
+		return makeStatusError(response)
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
+
+// See https://api.domeneshop.no/docs/#section/Overview/Errors
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Code string `json:"code"`
+		Help string `json:"help"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && data.Code != "" {
+		message = data.Code + ": " + data.Help
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, message)
+	case http.StatusBadRequest, http.StatusConflict:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
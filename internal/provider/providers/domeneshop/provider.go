@@ -0,0 +1,150 @@
+package domeneshop
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	token      string
+	secret     string
+	ttl        uint32
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		Token  string `json:"token"`
+		Secret string `json:"secret"`
+		TTL    uint32 `json:"ttl"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding domeneshop extra settings: %w", err)
+	}
+	p = &Provider{
+		domain:     domain,
+		host:       host,
+		ipVersion:  ipVersion,
+		ipv6Suffix: ipv6Suffix,
+		token:      extraSettings.Token,
+		secret:     extraSettings.Secret,
+		ttl:        extraSettings.TTL,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	switch {
+	case p.token == "":
+		return fmt.Errorf("%w", errors.ErrTokenNotSet)
+	case p.secret == "":
+		return fmt.Errorf("%w", errors.ErrSecretNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.Domeneshop, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://domene.shop/\">Domeneshop</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://api.domeneshop.no/docs/
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	domainID, err := p.getDomainID(ctx, client)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting domain id: %w", err)
+	}
+
+	record, err := p.getRecord(ctx, client, domainID, recordType)
+	if stderrors.Is(err, errors.ErrRecordNotFound) {
+		ttl := uint32(defaultTTL)
+		if p.ttl != 0 {
+			ttl = p.ttl
+		}
+		record = apiRecord{
+			Host: p.host,
+			TTL:  ttl,
+			Type: recordType,
+			Data: ip.String(),
+		}
+		err = p.createRecord(ctx, client, domainID, record)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("creating record: %w", err)
+		}
+		return ip, nil
+	} else if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting record: %w", err)
+	}
+
+	if record.Data == ip.String() {
+		return ip, nil
+	}
+
+	record.Data = ip.String()
+	if p.ttl != 0 {
+		record.TTL = p.ttl
+	}
+	err = p.updateRecord(ctx, client, domainID, record)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating record: %w", err)
+	}
+
+	return ip, nil
+}
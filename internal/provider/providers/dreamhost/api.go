@@ -0,0 +1,154 @@
+package dreamhost
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type dreamhostRecord struct {
+	Editable string `json:"editable"`
+	Type     string `json:"type"`
+	Record   string `json:"record"`
+	Value    string `json:"value"`
+}
+
+func (p *Provider) getRecords(ctx context.Context, client *http.Client) (
+	records []dreamhostRecord, err error) {
+	values := url.Values{}
+	values.Set("cmd", "dns-list_records")
+	data, err := p.doCommand(ctx, client, values)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &records)
+	if err != nil {
+		return nil, fmt.Errorf("json decoding records: %w", err)
+	}
+	return records, nil
+}
+
+func (p *Provider) removeRecord(ctx context.Context, client *http.Client,
+	recordType, value string) error {
+	values := url.Values{}
+	values.Set("cmd", "dns-remove_record")
+	values.Set("record", utils.BuildURLQueryHostname(p.host, p.domain))
+	values.Set("type", recordType)
+	values.Set("value", value)
+	_, err := p.doCommand(ctx, client, values)
+	if stderrors.Is(err, errors.ErrRecordNotFound) {
+		// The record was removed since it was listed,
+		// for example by another update running concurrently.
+		return nil
+	}
+	return err
+}
+
+func (p *Provider) addRecord(ctx context.Context, client *http.Client,
+	recordType string, ip netip.Addr) error {
+	values := url.Values{}
+	values.Set("cmd", "dns-add_record")
+	values.Set("record", utils.BuildURLQueryHostname(p.host, p.domain))
+	values.Set("type", recordType)
+	values.Set("value", ip.String())
+	_, err := p.doCommand(ctx, client, values)
+	return err
+}
+
+// doCommand runs the API command set in the values and returns the
+// data of the response if its result is success, or an error
+// containing the data otherwise.
+func (p *Provider) doCommand(ctx context.Context, client *http.Client,
+	values url.Values) (data json.RawMessage, err error) {
+	uniqueID, err := makeUniqueID()
+	if err != nil {
+		return nil, fmt.Errorf("making unique id: %w", err)
+	}
+
+	values.Set("key", p.key)
+	values.Set("unique_id", uniqueID)
+	values.Set("format", "json")
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.dreamhost.com",
+		RawQuery: values.Encode(),
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+	setHeaders(request)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	}
+
+	var dhResponse struct {
+		Result string          `json:"result"`
+		Data   json.RawMessage `json:"data"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&dhResponse)
+	if err != nil {
+		return nil, fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	if dhResponse.Result != constants.Success {
+		var message string
+		err = json.Unmarshal(dhResponse.Data, &message)
+		if err != nil {
+			message = string(dhResponse.Data)
+		}
+		return nil, makeError(dhResponse.Result, message)
+	}
+	return dhResponse.Data, nil
+}
+
+// See https://help.dreamhost.com/hc/en-us/articles/217555707-DNS-API-commands
+func makeError(result, message string) error {
+	switch message {
+	case "invalid_api_key", "no_key", "key_expired", "this_key_cannot_access_this_cmd":
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case "no_such_record", "no_such_zone", "no_such_value":
+		return fmt.Errorf("%w: %s", errors.ErrRecordNotFound, message)
+	case "slow_down_bucko":
+		return fmt.Errorf("%w: %s", errors.ErrRateLimited, message)
+	default:
+		return fmt.Errorf("%w: %s: %s", errors.ErrUnsuccessful, result, message)
+	}
+}
+
+// makeUniqueID returns a random version 4 UUID, used by the API
+// to prevent a command from being run twice.
+func makeUniqueID() (uniqueID string, err error) {
+	uuid := make([]byte, 16) //nolint:gomnd
+	_, err = io.ReadFull(rand.Reader, uuid)
+	if err != nil {
+		return "", err
+	}
+	//nolint:gomnd
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // Version 4
+	//nolint:gomnd
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x",
+		uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]), nil
+}
@@ -2,13 +2,10 @@ package dreamhost
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/netip"
-	"net/url"
 	"regexp"
 
 	"github.com/qdm12/ddns-updater/internal/models"
@@ -30,11 +27,15 @@ func New(data json.RawMessage, domain, host string,
 	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
 	p *Provider, err error) {
 	extraSettings := struct {
-		Key string `json:"key"`
+		Key    string `json:"key"`
+		APIKey string `json:"api_key"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding dreamhost extra settings: %w", err)
+	}
+	if extraSettings.Key == "" {
+		extraSettings.Key = extraSettings.APIKey
 	}
 	if host == "" { // TODO-v2 remove default
 		host = "@" // default
@@ -100,6 +101,7 @@ func (p *Provider) HTML() models.HTMLRow {
 	}
 }
 
+// See https://help.dreamhost.com/hc/en-us/articles/217555707-DNS-API-commands
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
 	recordType := constants.A
 	if ip.Is6() {
@@ -111,197 +113,35 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		return netip.Addr{}, fmt.Errorf("listing records: %w", err)
 	}
 
-	var oldIP netip.Addr
-	for _, data := range records.Data {
-		if data.Type == recordType && data.Record == utils.BuildURLQueryHostname(p.host, p.domain) {
-			if data.Editable == "0" {
+	var oldValue string
+	recordName := utils.BuildURLQueryHostname(p.host, p.domain)
+	for _, record := range records {
+		if record.Type == recordType && record.Record == recordName {
+			if record.Editable == "0" {
 				return netip.Addr{}, fmt.Errorf("%w", errors.ErrRecordNotEditable)
 			}
-			oldIP, err = netip.ParseAddr(data.Value)
+			oldIP, err := netip.ParseAddr(record.Value)
 			if err == nil && ip.Compare(oldIP) == 0 { // constants.Success, nothing to change
 				return ip, nil
 			}
+			oldValue = record.Value
 			break
 		}
 	}
 
-	// Create the record with the new IP before removing the old one if it exists.
-	err = p.createRecord(ctx, client, ip)
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating record: %w", err)
-	}
-
-	if oldIP.IsValid() { // Found editable record with a different IP address, so remove it
-		err = p.removeRecord(ctx, client, oldIP)
+	// There is no update command, so the record with the old value is
+	// removed before adding the record with the new value.
+	if oldValue != "" {
+		err = p.removeRecord(ctx, client, recordType, oldValue)
 		if err != nil {
 			return netip.Addr{}, fmt.Errorf("removing record: %w", err)
 		}
 	}
 
-	return ip, nil
-}
-
-type (
-	dreamHostRecords struct {
-		Result string `json:"result"`
-		Data   []struct {
-			Editable string `json:"editable"`
-			Type     string `json:"type"`
-			Record   string `json:"record"`
-			Value    string `json:"value"`
-		} `json:"data"`
-	}
-	dreamhostReponse struct {
-		Result string `json:"result"`
-		Data   string `json:"data"`
-	}
-)
-
-func (p *Provider) defaultURLValues() (values url.Values) {
-	uuid := make([]byte, 16) //nolint:gomnd
-	_, _ = io.ReadFull(rand.Reader, uuid)
-	//nolint:gomnd
-	uuid[6] = (uuid[6] & 0x0f) | 0x40 // Version 4
-	//nolint:gomnd
-	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
-	values = url.Values{}
-	values.Set("key", p.key)
-	values.Set("unique_id", string(uuid))
-	values.Set("format", "json")
-	return values
-}
-
-func (p *Provider) getRecords(ctx context.Context, client *http.Client) (
-	records dreamHostRecords, err error) {
-	u := url.URL{
-		Scheme: "https",
-		Host:   "api.dreamhost.com",
-	}
-	values := p.defaultURLValues()
-	values.Set("cmd", "dns-list_records")
-	u.RawQuery = values.Encode()
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return records, fmt.Errorf("creating http request: %w", err)
-	}
-	setHeaders(request)
-
-	response, err := client.Do(request)
-	if err != nil {
-		return records, err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		return records, fmt.Errorf("%w: %d: %s",
-			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
-	}
-
-	decoder := json.NewDecoder(response.Body)
-	err = decoder.Decode(&records)
-	if err != nil {
-		return records, fmt.Errorf("json decoding response body: %w", err)
-	}
-
-	if records.Result != constants.Success {
-		return records, fmt.Errorf("%w: %s", errors.ErrUnsuccessful, records.Result)
-	}
-	return records, nil
-}
-
-func (p *Provider) removeRecord(ctx context.Context, client *http.Client, ip netip.Addr) error { //nolint:dupl
-	recordType := constants.A
-	if ip.Is6() {
-		recordType = constants.AAAA
-	}
-
-	u := url.URL{
-		Scheme: "https",
-		Host:   "api.dreamhost.com",
-	}
-	values := p.defaultURLValues()
-	values.Set("cmd", "dns-remove_record")
-	values.Set("record", utils.BuildURLQueryHostname(p.host, p.domain))
-	values.Set("type", recordType)
-	values.Set("value", ip.String())
-	u.RawQuery = values.Encode()
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("creating http request: %w", err)
-	}
-	setHeaders(request)
-
-	response, err := client.Do(request)
-	if err != nil {
-		return err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %d: %s",
-			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
-	}
-
-	var dhResponse dreamhostReponse
-	decoder := json.NewDecoder(response.Body)
-	err = decoder.Decode(&dhResponse)
+	err = p.addRecord(ctx, client, recordType, ip)
 	if err != nil {
-		return fmt.Errorf("json decoding response body: %w", err)
-	}
-
-	if dhResponse.Result != constants.Success { // this should not happen
-		return fmt.Errorf("%w: %s - %s",
-			errors.ErrUnsuccessful, dhResponse.Result, dhResponse.Data)
+		return netip.Addr{}, fmt.Errorf("adding record: %w", err)
 	}
-	return nil
-}
 
-func (p *Provider) createRecord(ctx context.Context, client *http.Client, ip netip.Addr) error { //nolint:dupl
-	recordType := constants.A
-	if ip.Is6() {
-		recordType = constants.AAAA
-	}
-
-	u := url.URL{
-		Scheme: "https",
-		Host:   "api.dreamhost.com",
-	}
-	values := p.defaultURLValues()
-	values.Set("cmd", "dns-add_record")
-	values.Set("record", utils.BuildURLQueryHostname(p.host, p.domain))
-	values.Set("type", recordType)
-	values.Set("value", ip.String())
-	u.RawQuery = values.Encode()
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("creating http request: %w", err)
-	}
-	setHeaders(request)
-
-	response, err := client.Do(request)
-	if err != nil {
-		return err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %d: %s",
-			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
-	}
-
-	var dhResponse dreamhostReponse
-	decoder := json.NewDecoder(response.Body)
-	err = decoder.Decode(&dhResponse)
-	if err != nil {
-		return fmt.Errorf("json decoding response body: %w", err)
-	}
-
-	if dhResponse.Result != constants.Success {
-		return fmt.Errorf("%w: %s - %s",
-			errors.ErrUnsuccessful, dhResponse.Result, dhResponse.Data)
-	}
-	return nil
+	return ip, nil
 }
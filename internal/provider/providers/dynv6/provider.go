@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/netip"
 	"net/url"
+	"strings"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
@@ -99,11 +101,30 @@ func (p *Provider) HTML() models.HTMLRow {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
-	isIPv4 := ip.Is4()
+	if ip.Is6() {
+		_, newIP, err = p.update(ctx, client, netip.Addr{}, ip)
+	} else {
+		newIP, _, err = p.update(ctx, client, ip, netip.Addr{})
+	}
+	return newIP, err
+}
+
+// UpdateBoth updates the A and AAAA records in a single request.
+func (p *Provider) UpdateBoth(ctx context.Context, client *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
+	return p.update(ctx, client, ipv4, ipv6)
+}
+
+func (p *Provider) update(ctx context.Context, client *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
+	// The ipv4 and ipv6 subdomains force the IP family used for the
+	// request, so a value of "auto" detects the address of that family.
 	host := "dynv6.com"
-	if isIPv4 {
+	switch {
+	case ipv4.IsValid() && ipv6.IsValid():
+	case ipv4.IsValid():
 		host = "ipv4." + host
-	} else {
+	default:
 		host = "ipv6." + host
 	}
 	u := url.URL{
@@ -114,33 +135,57 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	values := url.Values{}
 	values.Set("token", p.token)
 	values.Set("zone", utils.BuildURLQueryHostname(p.host, p.domain))
-	ipValue := ip.String()
-	useProviderIP := p.useProviderIP && (ip.Is4() || !p.ipv6Suffix.IsValid())
-	if useProviderIP {
-		ipValue = "auto"
-	}
-	if isIPv4 {
+	if ipv4.IsValid() {
+		ipValue := ipv4.String()
+		if p.useProviderIP && !ipv6.IsValid() {
+			ipValue = "auto"
+		}
 		values.Set("ipv4", ipValue)
-	} else {
+	}
+	if ipv6.IsValid() {
+		ipValue := ipv6.String()
+		if p.useProviderIP && !ipv4.IsValid() && !p.ipv6Suffix.IsValid() {
+			ipValue = "auto"
+		}
 		values.Set("ipv6", ipValue)
 	}
 	u.RawQuery = values.Encode()
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("creating http request: %w", err)
 	}
 	headers.SetUserAgent(request)
 
 	response, err := client.Do(request)
 	if err != nil {
-		return netip.Addr{}, err
+		return netip.Addr{}, netip.Addr{}, err
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode == http.StatusOK {
-		return ip, nil
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("reading response body: %w", err)
+	}
+	s := strings.ToLower(strings.TrimSpace(string(b)))
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrAuth, utils.ToSingleLine(s))
+	case http.StatusNotFound:
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrZoneNotFound, utils.ToSingleLine(s))
+	default:
+		return netip.Addr{}, netip.Addr{}, errors.HTTPStatus(response.StatusCode, utils.ToSingleLine(s))
+	}
+
+	switch {
+	case strings.HasPrefix(s, "addresses updated"),
+		strings.HasPrefix(s, "addresses unchanged"):
+		return ipv4, ipv6, nil
+	case strings.Contains(s, "invalid authentication token"):
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
+	default:
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, utils.ToSingleLine(s))
 	}
-	return netip.Addr{}, fmt.Errorf("%w: %d: %s",
-		errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
 }
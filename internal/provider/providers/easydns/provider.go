@@ -25,6 +25,7 @@ type Provider struct {
 	ipv6Suffix    netip.Prefix
 	username      string
 	token         string
+	wildcard      bool
 	useProviderIP bool
 }
 
@@ -34,11 +35,12 @@ func New(data json.RawMessage, domain, host string,
 	extraSettings := struct {
 		Username      string `json:"username"`
 		Token         string `json:"token"`
+		Wildcard      bool   `json:"wildcard"`
 		UseProviderIP bool   `json:"provider_ip"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding easydns extra settings: %w", err)
 	}
 	p = &Provider{
 		domain:        domain,
@@ -47,6 +49,7 @@ func New(data json.RawMessage, domain, host string,
 		ipv6Suffix:    ipv6Suffix,
 		username:      extraSettings.Username,
 		token:         extraSettings.Token,
+		wildcard:      extraSettings.Wildcard,
 		useProviderIP: extraSettings.UseProviderIP,
 	}
 	err = p.isValid()
@@ -117,7 +120,7 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	if !useProviderIP {
 		values.Set("myip", ip.String())
 	}
-	if p.host == "*" {
+	if p.wildcard || p.host == "*" {
 		values.Set("wildcard", "ON")
 	}
 	u.RawQuery = values.Encode()
@@ -150,11 +153,15 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrReceivedNoResult)
 	case strings.Contains(s, "NO_SERVICE"):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrNoService)
-	case strings.Contains(s, "NO_ACCESS"):
+	case strings.Contains(s, "NO_ACCESS"), strings.HasPrefix(s, "noaccess"),
+		strings.HasPrefix(s, constants.Badauth):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
-	case strings.Contains(s, "ILLEGAL_INPUT"), strings.Contains(s, "TOO_SOON"):
+	case strings.Contains(s, "TOO_SOON"):
+		return netip.Addr{}, fmt.Errorf("%w: too soon since the last update", errors.ErrRateLimited)
+	case strings.Contains(s, "ILLEGAL_INPUT"):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBannedAbuse)
-	case strings.Contains(s, "NO_ERROR"), strings.Contains(s, "OK"):
+	case strings.Contains(s, "NO_ERROR"), strings.Contains(s, "OK"),
+		strings.HasPrefix(s, "good"), strings.HasPrefix(s, constants.Nochg):
 		return ip, nil
 	default:
 		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, utils.ToSingleLine(s))
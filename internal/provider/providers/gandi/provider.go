@@ -5,9 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/netip"
 	"net/url"
+	"strings"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
@@ -37,12 +39,16 @@ func New(data json.RawMessage, domain, host string,
 	p *Provider, err error) {
 	extraSettings := struct {
 		PersonalAccessToken string `json:"personal_access_token"`
-		APIKey              string `json:"key"`
+		APIKey              string `json:"api_key"`
+		DeprecatedKey       string `json:"key"`
 		TTL                 int    `json:"ttl"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding gandi extra settings: %w", err)
+	}
+	if extraSettings.APIKey == "" {
+		extraSettings.APIKey = extraSettings.DeprecatedKey
 	}
 	p = &Provider{
 		domain:              domain,
@@ -110,10 +116,32 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		recordType = constants.AAAA
 	}
 
+	err = p.setRecord(ctx, client, recordType, ip.Unmap().String())
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return ip, nil
+}
+
+// UpdateValue sets the record of the type given to the value given.
+func (p *Provider) UpdateValue(ctx context.Context, client *http.Client,
+	recordType, value string) (err error) {
+	if recordType == constants.TXT && !strings.HasPrefix(value, `"`) {
+		// Gandi TXT record values must be enclosed in double quotes
+		value = `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+	}
+	return p.setRecord(ctx, client, recordType, value)
+}
+
+// See https://api.gandi.net/docs/livedns/#put-v5-livedns-domains-fqdn-records-rrset_name-rrset_type
+func (p *Provider) setRecord(ctx context.Context, client *http.Client,
+	recordType, value string) (err error) {
+	// The rrset name is the host relative to the domain, and
+	// the apex of the domain is designated with "@".
 	u := url.URL{
 		Scheme: "https",
-		Host:   "dns.api.gandi.net",
-		Path:   fmt.Sprintf("/api/v5/domains/%s/records/%s/%s", p.domain, p.host, recordType),
+		Host:   "api.gandi.net",
+		Path:   fmt.Sprintf("/v5/livedns/domains/%s/records/%s/%s", p.domain, p.host, recordType),
 	}
 
 	buffer := bytes.NewBuffer(nil)
@@ -127,38 +155,71 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		Values [1]string `json:"rrset_values"`
 		TTL    int       `json:"rrset_ttl"`
 	}{
-		Values: [1]string{ip.Unmap().String()},
+		Values: [1]string{value},
 		TTL:    ttl,
 	}
 	err = encoder.Encode(requestData)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("json encoding request data: %w", err)
+		return fmt.Errorf("json encoding request data: %w", err)
 	}
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), buffer)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+		return fmt.Errorf("creating http request: %w", err)
 	}
 	headers.SetUserAgent(request)
 	headers.SetContentType(request, "application/json")
 	headers.SetAccept(request, "application/json")
 	if p.personalAccessToken != "" {
-		request.Header.Set("Authorization", "Bearer "+p.personalAccessToken)
+		headers.SetAuthBearer(request, p.personalAccessToken)
 	} else {
 		// Note the API key is deprecated.
-		request.Header.Set("X-Api-Key", p.apiKey)
+		request.Header.Set("Authorization", "Apikey "+p.apiKey)
 	}
 
 	response, err := client.Do(request)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("doing http request: %w", err)
+		return fmt.Errorf("doing http request: %w", err)
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusCreated {
-		return netip.Addr{}, fmt.Errorf("%w: %d: %s",
-			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, decodeError(response.Body))
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, decodeError(response.Body))
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, decodeError(response.Body))
 	}
+}
 
-	return ip, nil
+// decodeError returns the cause and message of the Gandi JSON error
+// response body, or the body as a single line if it cannot be decoded.
+// See https://api.gandi.net/docs/reference/#errors
+func decodeError(body io.Reader) (message string) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return "reading response body: " + err.Error()
+	}
+
+	var data struct {
+		Cause   string `json:"cause"`
+		Message string `json:"message"`
+	}
+	err = json.Unmarshal(b, &data)
+	if err != nil || (data.Cause == "" && data.Message == "") {
+		return utils.ToSingleLine(string(b))
+	}
+
+	switch {
+	case data.Cause == "":
+		return data.Message
+	case data.Message == "":
+		return data.Cause
+	default:
+		return data.Cause + ": " + data.Message
+	}
 }
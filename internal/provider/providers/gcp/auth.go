@@ -0,0 +1,309 @@
+package gcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ddnserrors "github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+const (
+	defaultTokenURI = "https://oauth2.googleapis.com/token"
+	scope           = "https://www.googleapis.com/auth/ndev.clouddns.readwrite"
+	// assertionLifetime is the lifetime of the signed JWT assertion,
+	// which is the maximum allowed by Google.
+	assertionLifetime = time.Hour
+	// tokenRenewMargin is the duration before the token expiry
+	// from which a new token is requested.
+	tokenRenewMargin = time.Minute
+)
+
+// tokenRequester requests OAuth2 access tokens using credentials.
+type tokenRequester interface {
+	requestToken(ctx context.Context, client *http.Client, now time.Time) (
+		token string, expiresIn time.Duration, err error)
+}
+
+// parseCredentials parses the credentials JSON file content, which can be
+// a service account key or authorized user credentials, as created by
+// `gcloud auth application-default login`.
+func parseCredentials(credentials json.RawMessage) (requester tokenRequester, err error) {
+	var data struct {
+		Type string `json:"type"`
+	}
+	err = json.Unmarshal(credentials, &data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ddnserrors.ErrCredentialsNotValid, err)
+	}
+
+	switch data.Type {
+	case "":
+		return nil, fmt.Errorf("%w: 'type' JSON field value missing",
+			ddnserrors.ErrCredentialsNotValid)
+	case "service_account":
+		var account serviceAccount
+		account, err = parseServiceAccount(credentials)
+		if err != nil {
+			return nil, err
+		}
+		return account, nil
+	case "authorized_user":
+		var user authorizedUser
+		user, err = parseAuthorizedUser(credentials)
+		if err != nil {
+			return nil, err
+		}
+		return user, nil
+	case "external_account":
+		return nil, fmt.Errorf("%w: type external_account (workload identity federation) "+
+			"is no longer supported, please use a service account key or authorized user "+
+			"credentials instead", ddnserrors.ErrCredentialsNotValid)
+	default:
+		return nil, fmt.Errorf("%w: type %q is not supported, "+
+			"only service_account and authorized_user are",
+			ddnserrors.ErrCredentialsNotValid, data.Type)
+	}
+}
+
+type serviceAccount struct {
+	clientEmail  string
+	privateKeyID string
+	privateKey   *rsa.PrivateKey
+	tokenURI     string
+}
+
+func parseServiceAccount(credentials json.RawMessage) (
+	account serviceAccount, err error) {
+	var data struct {
+		ClientEmail  string `json:"client_email"`
+		PrivateKeyID string `json:"private_key_id"`
+		PrivateKey   string `json:"private_key"`
+		TokenURI     string `json:"token_uri"`
+	}
+	err = json.Unmarshal(credentials, &data)
+	if err != nil {
+		return account, fmt.Errorf("%w: %w", ddnserrors.ErrCredentialsNotValid, err)
+	}
+
+	switch {
+	case data.ClientEmail == "":
+		return account, fmt.Errorf("%w: 'client_email' JSON field value missing",
+			ddnserrors.ErrCredentialsNotValid)
+	}
+
+	block, _ := pem.Decode([]byte(data.PrivateKey))
+	if block == nil {
+		return account, fmt.Errorf("%w: no PEM block found in private key",
+			ddnserrors.ErrCredentialsNotValid)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return account, fmt.Errorf("%w: parsing private key: %w",
+			ddnserrors.ErrCredentialsNotValid, err)
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return account, fmt.Errorf("%w: private key is not an RSA key",
+			ddnserrors.ErrCredentialsNotValid)
+	}
+
+	account = serviceAccount{
+		clientEmail:  data.ClientEmail,
+		privateKeyID: data.PrivateKeyID,
+		privateKey:   privateKey,
+		tokenURI:     data.TokenURI,
+	}
+	if account.tokenURI == "" {
+		account.tokenURI = defaultTokenURI
+	}
+	return account, nil
+}
+
+type authorizedUser struct {
+	clientID     string
+	clientSecret string
+	refreshToken string
+	tokenURI     string
+}
+
+func parseAuthorizedUser(credentials json.RawMessage) (
+	user authorizedUser, err error) {
+	var data struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+		RefreshToken string `json:"refresh_token"`
+		TokenURI     string `json:"token_uri"`
+	}
+	err = json.Unmarshal(credentials, &data)
+	if err != nil {
+		return user, fmt.Errorf("%w: %w", ddnserrors.ErrCredentialsNotValid, err)
+	}
+
+	switch {
+	case data.ClientID == "":
+		return user, fmt.Errorf("%w: 'client_id' JSON field value missing",
+			ddnserrors.ErrCredentialsNotValid)
+	case data.ClientSecret == "":
+		return user, fmt.Errorf("%w: 'client_secret' JSON field value missing",
+			ddnserrors.ErrCredentialsNotValid)
+	case data.RefreshToken == "":
+		return user, fmt.Errorf("%w: 'refresh_token' JSON field value missing",
+			ddnserrors.ErrCredentialsNotValid)
+	}
+
+	user = authorizedUser{
+		clientID:     data.ClientID,
+		clientSecret: data.ClientSecret,
+		refreshToken: data.RefreshToken,
+		tokenURI:     data.TokenURI,
+	}
+	if user.tokenURI == "" {
+		user.tokenURI = defaultTokenURI
+	}
+	return user, nil
+}
+
+// getToken returns the cached access token if it is not near its expiry,
+// and otherwise requests a new access token and caches it.
+func (p *Provider) getToken(ctx context.Context, client *http.Client) (
+	token string, err error) {
+	now := p.timeNow()
+	if p.token != "" && now.Before(p.tokenExpiry.Add(-tokenRenewMargin)) {
+		return p.token, nil
+	}
+
+	token, expiresIn, err := p.tokenRequester.requestToken(ctx, client, now)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = token
+	p.tokenExpiry = now.Add(expiresIn)
+	return token, nil
+}
+
+// See https://developers.google.com/identity/protocols/oauth2/service-account#httprest
+func (s serviceAccount) requestToken(ctx context.Context, client *http.Client,
+	now time.Time) (token string, expiresIn time.Duration, err error) {
+	assertion, err := makeAssertion(s, now)
+	if err != nil {
+		return "", 0, fmt.Errorf("making JWT assertion: %w", err)
+	}
+
+	values := url.Values{}
+	values.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	values.Set("assertion", assertion)
+	return exchangeToken(ctx, client, s.tokenURI, values)
+}
+
+// See https://developers.google.com/identity/protocols/oauth2/web-server#offline
+func (u authorizedUser) requestToken(ctx context.Context, client *http.Client,
+	_ time.Time) (token string, expiresIn time.Duration, err error) {
+	values := url.Values{}
+	values.Set("grant_type", "refresh_token")
+	values.Set("client_id", u.clientID)
+	values.Set("client_secret", u.clientSecret)
+	values.Set("refresh_token", u.refreshToken)
+	return exchangeToken(ctx, client, u.tokenURI, values)
+}
+
+// exchangeToken posts the form values given to the token URI
+// and returns the access token received.
+func exchangeToken(ctx context.Context, client *http.Client,
+	tokenURI string, values url.Values) (token string, expiresIn time.Duration, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		tokenURI, strings.NewReader(values.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/x-www-form-urlencoded")
+	headers.SetAccept(request, "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("%w: %d: %s", ddnserrors.ErrAuth,
+			response.StatusCode, utils.BodyToSingleLine(response.Body))
+	}
+
+	var data struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return "", 0, fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	if data.AccessToken == "" {
+		return "", 0, fmt.Errorf("%w: no access token", ddnserrors.ErrAuth)
+	}
+	return data.AccessToken, time.Duration(data.ExpiresIn) * time.Second, nil
+}
+
+// makeAssertion returns a JWT signed with the service account
+// private key using RS256.
+func makeAssertion(account serviceAccount, now time.Time) (
+	assertion string, err error) {
+	header := struct {
+		Algorithm string `json:"alg"`
+		Type      string `json:"typ"`
+		KeyID     string `json:"kid,omitempty"`
+	}{
+		Algorithm: "RS256",
+		Type:      "JWT",
+		KeyID:     account.privateKeyID,
+	}
+	claims := struct {
+		Issuer   string `json:"iss"`
+		Scope    string `json:"scope"`
+		Audience string `json:"aud"`
+		IssuedAt int64  `json:"iat"`
+		Expiry   int64  `json:"exp"`
+	}{
+		Issuer:   account.clientEmail,
+		Scope:    scope,
+		Audience: account.tokenURI,
+		IssuedAt: now.Unix(),
+		Expiry:   now.Add(assertionLifetime).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("json encoding header: %w", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("json encoding claims: %w", err)
+	}
+
+	encoding := base64.RawURLEncoding
+	signingInput := encoding.EncodeToString(headerJSON) + "." +
+		encoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(nil, account.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("signing: %w", err)
+	}
+
+	return signingInput + "." + encoding.EncodeToString(signature), nil
+}
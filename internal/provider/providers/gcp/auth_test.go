@@ -0,0 +1,157 @@
+package gcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ddnserrors "github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_makeAssertion(t *testing.T) {
+	t.Parallel()
+
+	const keyBits = 2048
+	privateKey, err := rsa.GenerateKey(rand.Reader, keyBits)
+	require.NoError(t, err)
+
+	account := serviceAccount{
+		clientEmail:  "ddns@project.iam.gserviceaccount.com",
+		privateKeyID: "keyid",
+		privateKey:   privateKey,
+		tokenURI:     defaultTokenURI,
+	}
+	now := time.Unix(1700000000, 0)
+
+	assertion, err := makeAssertion(account, now)
+	require.NoError(t, err)
+
+	parts := strings.Split(assertion, ".")
+	require.Len(t, parts, 3)
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	assert.Equal(t, `{"alg":"RS256","typ":"JWT","kid":"keyid"}`, string(header))
+
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	assert.Equal(t, `{"iss":"ddns@project.iam.gserviceaccount.com",`+
+		`"scope":"https://www.googleapis.com/auth/ndev.clouddns.readwrite",`+
+		`"aud":"https://oauth2.googleapis.com/token",`+
+		`"iat":1700000000,"exp":1700003600}`, string(claims))
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	err = rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA256, digest[:], signature)
+	assert.NoError(t, err)
+}
+
+func Test_parseCredentials(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		credentials string
+		requester   tokenRequester
+		errWrapped  error
+		errMessage  string
+	}{
+		"type_missing": {
+			credentials: `{}`,
+			errWrapped:  ddnserrors.ErrCredentialsNotValid,
+			errMessage:  "credentials are not valid: 'type' JSON field value missing",
+		},
+		"authorized_user": {
+			credentials: `{"type":"authorized_user","client_id":"id",` +
+				`"client_secret":"secret","refresh_token":"refresh"}`,
+			requester: authorizedUser{
+				clientID:     "id",
+				clientSecret: "secret",
+				refreshToken: "refresh",
+				tokenURI:     defaultTokenURI,
+			},
+		},
+		"authorized_user_refresh_token_missing": {
+			credentials: `{"type":"authorized_user","client_id":"id","client_secret":"secret"}`,
+			errWrapped:  ddnserrors.ErrCredentialsNotValid,
+			errMessage:  "credentials are not valid: 'refresh_token' JSON field value missing",
+		},
+		"external_account": {
+			credentials: `{"type":"external_account"}`,
+			errWrapped:  ddnserrors.ErrCredentialsNotValid,
+			errMessage: "credentials are not valid: type external_account " +
+				"(workload identity federation) is no longer supported, " +
+				"please use a service account key or authorized user credentials instead",
+		},
+		"unknown_type": {
+			credentials: `{"type":"other"}`,
+			errWrapped:  ddnserrors.ErrCredentialsNotValid,
+			errMessage: `credentials are not valid: type "other" is not supported, ` +
+				"only service_account and authorized_user are",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			requester, err := parseCredentials(json.RawMessage(testCase.credentials))
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.requester, requester)
+		})
+	}
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func Test_authorizedUser_requestToken(t *testing.T) {
+	t.Parallel()
+
+	user := authorizedUser{
+		clientID:     "id",
+		clientSecret: "secret",
+		refreshToken: "refresh",
+		tokenURI:     defaultTokenURI,
+	}
+	client := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, defaultTokenURI, r.URL.String())
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "id", r.PostForm.Get("client_id"))
+			assert.Equal(t, "secret", r.PostForm.Get("client_secret"))
+			assert.Equal(t, "refresh", r.PostForm.Get("refresh_token"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"access_token":"token","expires_in":3599}`)),
+			}, nil
+		}),
+	}
+
+	token, expiresIn, err := user.requestToken(context.Background(), client, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, 3599*time.Second, expiresIn)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/netip"
+	"time"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
@@ -20,6 +21,12 @@ type Provider struct {
 	project     string
 	zone        string
 	credentials json.RawMessage
+	ttl         uint32
+	// tokenRequester requests access tokens using the credentials.
+	tokenRequester tokenRequester
+	token          string
+	tokenExpiry    time.Time
+	timeNow        func() time.Time
 }
 
 func New(data json.RawMessage, domain, host string,
@@ -29,12 +36,18 @@ func New(data json.RawMessage, domain, host string,
 		Project     string          `json:"project"`
 		Zone        string          `json:"zone"`
 		Credentials json.RawMessage `json:"credentials"`
+		// ServiceAccountKey is an alias for Credentials.
+		ServiceAccountKey json.RawMessage `json:"service_account_key"`
+		TTL               uint32          `json:"ttl"`
 	}
 
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
 		return nil, fmt.Errorf("JSON decoding extra settings: %w", err)
 	}
+	if len(extraSettings.Credentials) == 0 {
+		extraSettings.Credentials = extraSettings.ServiceAccountKey
+	}
 
 	p = &Provider{
 		domain:      domain,
@@ -44,6 +57,8 @@ func New(data json.RawMessage, domain, host string,
 		project:     extraSettings.Project,
 		zone:        extraSettings.Zone,
 		credentials: extraSettings.Credentials,
+		ttl:         extraSettings.TTL,
+		timeNow:     time.Now,
 	}
 
 	err = p.isValid()
@@ -66,13 +81,9 @@ func (p *Provider) isValid() (err error) {
 	if len(p.credentials) == 0 {
 		return fmt.Errorf("%w", ddnserrors.ErrCredentialsNotSet)
 	}
-	var creds struct {
-		Type string `json:"type"`
-	}
-	err = json.Unmarshal(p.credentials, &creds)
-	if err != nil || creds.Type == "" {
-		return fmt.Errorf("%w: 'type' JSON field value missing",
-			ddnserrors.ErrCredentialsNotValid)
+	p.tokenRequester, err = parseCredentials(p.credentials)
+	if err != nil {
+		return err
 	}
 
 	return nil
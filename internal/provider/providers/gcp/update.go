@@ -1,36 +1,45 @@
 package gcp
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/netip"
+	"net/url"
 
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
 	ddnserrors "github.com/qdm12/ddns-updater/internal/provider/errors"
-	clouddns "google.golang.org/api/dns/v1"
-	"google.golang.org/api/googleapi"
-	"google.golang.org/api/option"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
 )
 
+const defaultTTL = 300
+
+type resourceRecordSet struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     uint32   `json:"ttl"`
+	Rrdatas []string `json:"rrdatas"`
+}
+
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
 	recordType := constants.A
 	if ip.Is6() {
 		recordType = constants.AAAA
 	}
 
-	ddnsService, err := clouddns.NewService(ctx,
-		option.WithCredentialsJSON(p.credentials),
-		option.WithHTTPClient(client))
+	token, err := p.getToken(ctx, client)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating GCP DDNS service: %w", err)
+		return netip.Addr{}, fmt.Errorf("getting access token: %w", err)
 	}
-	rrSetsService := clouddns.NewResourceRecordSetsService(ddnsService)
 
-	fqdn := fmt.Sprintf("%s.%s.", p.host, p.domain)
+	fqdn := utils.BuildURLQueryHostname(p.host, p.domain) + "."
 
-	recordResourceSet, err := p.getResourceRecordSet(rrSetsService, fqdn, recordType)
+	existing, err := p.getResourceRecordSet(ctx, client, token, fqdn, recordType)
 	rrSetFound := true
 	if err != nil {
 		if errors.Is(err, ddnserrors.ErrRecordResourceSetNotFound) {
@@ -40,64 +49,159 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		}
 	}
 
-	for _, rrdata := range recordResourceSet.Rrdatas {
-		if rrdata == ip.String() {
-			// already up to date
-			return ip, nil
-		}
+	addition := resourceRecordSet{
+		Name:    fqdn,
+		Type:    recordType,
+		TTL:     defaultTTL,
+		Rrdatas: []string{ip.String()},
 	}
-
-	if !rrSetFound {
-		err = p.createRecord(rrSetsService, fqdn, recordType, ip)
-		if err != nil {
-			return netip.Addr{}, fmt.Errorf("creating record: %w", err)
+	if p.ttl != 0 {
+		addition.TTL = p.ttl
+	}
+	var deletions []resourceRecordSet
+	if rrSetFound {
+		for _, rrdata := range existing.Rrdatas {
+			if rrdata == ip.String() {
+				// already up to date
+				return ip, nil
+			}
+		}
+		if p.ttl == 0 {
+			addition.TTL = existing.TTL
 		}
-		return ip, nil
+		deletions = []resourceRecordSet{existing}
 	}
 
-	err = p.updateRecord(rrSetsService, fqdn, recordType, ip)
+	err = p.createChange(ctx, client, token, []resourceRecordSet{addition}, deletions)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("updating record: %w", err)
+		return netip.Addr{}, fmt.Errorf("creating change: %w", err)
 	}
 
 	return ip, nil
 }
 
-func (p *Provider) getResourceRecordSet(rrSetsService *clouddns.ResourceRecordSetsService,
-	fqdn, recordType string) (resourceRecordSet *clouddns.ResourceRecordSet, err error) {
-	call := rrSetsService.Get(p.project, p.zone, fqdn, recordType)
-	resourceRecordSet, err = call.Do()
+// See https://cloud.google.com/dns/docs/reference/v1/resourceRecordSets/get
+func (p *Provider) getResourceRecordSet(ctx context.Context, client *http.Client,
+	token, fqdn, recordType string) (rrSet resourceRecordSet, err error) {
+	path := "/rrsets/" + url.PathEscape(fqdn) + "/" + recordType
+	err = p.doRequest(ctx, client, token, http.MethodGet, path, nil, &rrSet)
 	if err != nil {
-		googleAPIError := new(googleapi.Error)
-		if errors.As(err, &googleAPIError) && googleAPIError.Code == http.StatusNotFound {
-			return nil, fmt.Errorf("%w: %w", ddnserrors.ErrRecordResourceSetNotFound, err)
+		if errors.Is(err, errNotFound) {
+			// only a missing resource record set is fine, the project
+			// or zone missing fails creating the change.
+			return rrSet, fmt.Errorf("%w: %w", ddnserrors.ErrRecordResourceSetNotFound, err)
 		}
-		return nil, err
+		return rrSet, err
 	}
+	return rrSet, nil
+}
 
-	return resourceRecordSet, nil
+// See https://cloud.google.com/dns/docs/reference/v1/changes/create
+func (p *Provider) createChange(ctx context.Context, client *http.Client,
+	token string, additions, deletions []resourceRecordSet) (err error) {
+	requestData := struct {
+		Additions []resourceRecordSet `json:"additions"`
+		Deletions []resourceRecordSet `json:"deletions,omitempty"`
+	}{
+		Additions: additions,
+		Deletions: deletions,
+	}
+	var change struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	err = p.doRequest(ctx, client, token, http.MethodPost, "/changes", requestData, &change)
+	if err != nil {
+		return err
+	}
+	if change.ID == "" {
+		return fmt.Errorf("%w: no change id", ddnserrors.ErrReceivedNoResult)
+	}
+	return nil
 }
 
-func (p *Provider) createRecord(rrSetsService *clouddns.ResourceRecordSetsService,
-	fqdn, recordType string, ip netip.Addr) (err error) {
-	rrSet := &clouddns.ResourceRecordSet{
-		Name:    fqdn,
-		Rrdatas: []string{ip.String()},
-		Type:    recordType,
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	token, method, path string, requestData, responseData any) (err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "dns.googleapis.com",
+		Path: "/dns/v1/projects/" + url.PathEscape(p.project) +
+			"/managedZones/" + url.PathEscape(p.zone) + path,
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
 	}
-	rrSetCall := rrSetsService.Create(p.project, p.zone, rrSet)
-	_, err = rrSetCall.Do()
-	return err
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAuthBearer(request, token)
+	headers.SetAccept(request, "application/json")
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusUnauthorized {
+			p.token = "" // force requesting a new token on the next update
+		}
+		return makeStatusError(response)
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
 }
 
-func (p *Provider) updateRecord(rrSetsService *clouddns.ResourceRecordSetsService,
-	fqdn, recordType string, ip netip.Addr) (err error) {
-	rrSet := &clouddns.ResourceRecordSet{
-		Name:    fqdn,
-		Rrdatas: []string{ip.String()},
-		Type:    recordType,
+var errNotFound = errors.New("not found")
+
+func makeStatusError(response *http.Response) error {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading body: %w",
+			ddnserrors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var errorData struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	message := string(b)
+	if json.Unmarshal(b, &errorData) == nil && errorData.Error.Message != "" {
+		message = errorData.Error.Message
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", ddnserrors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errNotFound, message)
+	case http.StatusBadRequest, http.StatusConflict:
+		return fmt.Errorf("%w: %s", ddnserrors.ErrBadRequest, message)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", ddnserrors.ErrRateLimited, message)
+	default:
+		return fmt.Errorf("%w: %d: %s", ddnserrors.ErrHTTPStatusNotValid,
+			response.StatusCode, message)
 	}
-	rrSetCall := rrSetsService.Patch(p.project, p.zone, fqdn, recordType, rrSet)
-	_, err = rrSetCall.Do()
-	return err
 }
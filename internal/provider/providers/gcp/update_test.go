@@ -0,0 +1,90 @@
+package gcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	ddnserrors "github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticToken struct{}
+
+func (staticToken) requestToken(context.Context, *http.Client, time.Time) (
+	token string, expiresIn time.Duration, err error) {
+	return "token", time.Hour, nil
+}
+
+func Test_Provider_Update_notFound(t *testing.T) {
+	t.Parallel()
+
+	const notFoundBody = `{"error":{"message":"not found"}}`
+
+	testCases := map[string]struct {
+		changeStatus int
+		changeBody   string
+		errWrapped   error
+		errMessage   string
+	}{
+		"record_set_created": {
+			changeStatus: http.StatusOK,
+			changeBody:   `{"id":"1","status":"pending"}`,
+		},
+		"zone_not_found": {
+			changeStatus: http.StatusNotFound,
+			changeBody:   notFoundBody,
+			errWrapped:   errNotFound,
+			errMessage:   "creating change: not found: not found",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &http.Client{
+				Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					status, body := http.StatusNotFound, notFoundBody
+					if r.Method == http.MethodPost {
+						assert.Equal(t, "/dns/v1/projects/project/managedZones/zone/changes", r.URL.Path)
+						status, body = testCase.changeStatus, testCase.changeBody
+					} else {
+						assert.Equal(t, "/dns/v1/projects/project/managedZones/zone/rrsets/domain.com./A",
+							r.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: status,
+						Body:       io.NopCloser(strings.NewReader(body)),
+					}, nil
+				}),
+			}
+			provider := &Provider{
+				domain:         "domain.com",
+				host:           "@",
+				project:        "project",
+				zone:           "zone",
+				tokenRequester: staticToken{},
+				timeNow:        time.Now,
+			}
+			ip := netip.MustParseAddr("1.2.3.4")
+
+			newIP, err := provider.Update(context.Background(), client, ip)
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.NotErrorIs(t, err, ddnserrors.ErrRecordResourceSetNotFound)
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			assert.Equal(t, ip, newIP)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package glesys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecord struct {
+	ID   int64  `json:"recordid"`
+	Host string `json:"host"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
+// See https://github.com/GleSYS/API/wiki/functions_domain#domainlistrecords
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	recordType string) (record apiRecord, err error) {
+	requestData := struct {
+		DomainName string `json:"domainname"`
+	}{
+		DomainName: p.domain,
+	}
+	var data struct {
+		Records []apiRecord `json:"records"`
+	}
+	err = p.doRequest(ctx, client, "/domain/listrecords", requestData, &data)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	for _, record := range data.Records {
+		if record.Host == p.host && record.Type == recordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(data.Records))
+}
+
+// See https://github.com/GleSYS/API/wiki/functions_domain#domainupdaterecord
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	recordID int64, ip netip.Addr) (err error) {
+	requestData := struct {
+		RecordID int64  `json:"recordid"`
+		Data     string `json:"data"`
+	}{
+		RecordID: recordID,
+		Data:     ip.String(),
+	}
+	var data struct {
+		Record apiRecord `json:"record"`
+	}
+	err = p.doRequest(ctx, client, "/domain/updaterecord", requestData, &data)
+	if err != nil {
+		return err
+	}
+
+	if data.Record.Data != ip.String() {
+		return fmt.Errorf("%w: sent ip %s to update but received %s",
+			errors.ErrIPReceivedMismatch, ip, data.Record.Data)
+	}
+	return nil
+}
+
+// doRequest sends the request data to the API path and decodes the
+// inner response object into responseData. It returns an error if
+// the status code of the inner response object is not 200.
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	path string, requestData, responseData any) (err error) {
+	buffer := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buffer)
+	err = encoder.Encode(requestData)
+	if err != nil {
+		return fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"https://api.glesys.com"+path, buffer)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/json")
+	headers.SetAccept(request, "application/json")
+	request.SetBasicAuth(p.project, p.apiKey)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	var data struct {
+		Response json.RawMessage `json:"response"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return fmt.Errorf("%w: %d: json decoding response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var status struct {
+		Status struct {
+			Code int    `json:"code"`
+			Text string `json:"text"`
+		} `json:"status"`
+	}
+	err = json.Unmarshal(data.Response, &status)
+	if err != nil {
+		return fmt.Errorf("json decoding response status: %w", err)
+	}
+
+	err = makeStatusError(status.Status.Code, status.Status.Text)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data.Response, responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response data: %w", err)
+	}
+	return nil
+}
+
+func makeStatusError(code int, text string) (err error) {
+	text = utils.ToSingleLine(text)
+	switch code {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, text)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, text)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, text)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", errors.ErrRateLimited, text)
+	default:
+		return fmt.Errorf("%w: %d: %s", errors.ErrUnsuccessful, code, text)
+	}
+}
@@ -104,21 +104,22 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		Scheme: "https",
 		Host:   "dyn.dns.he.net",
 		Path:   "/nic/update",
-		User:   url.UserPassword(fqdn, p.password),
 	}
 	values := url.Values{}
 	values.Set("hostname", fqdn)
+	values.Set("password", p.password)
 	useProviderIP := p.useProviderIP && (ip.Is4() || !p.ipv6Suffix.IsValid())
 	if !useProviderIP {
 		values.Set("myip", ip.String())
 	}
-	u.RawQuery = values.Encode()
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(),
+		strings.NewReader(values.Encode()))
 	if err != nil {
 		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
 	}
 	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/x-www-form-urlencoded")
 
 	response, err := client.Do(request)
 	if err != nil {
@@ -130,13 +131,22 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	if err != nil {
 		return netip.Addr{}, fmt.Errorf("reading response body: %w", err)
 	}
-	s := string(b)
+	s := strings.TrimSpace(string(b))
 
 	switch s {
 	case "":
 		return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid, response.StatusCode, s)
 	case constants.Badauth:
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
+		// Each record has its own dynamic DNS key, so a bad authentication
+		// is most likely due to a wrong key for this record.
+		return netip.Addr{}, fmt.Errorf("%w: the password is likely not the dynamic DNS key "+
+			"of the record %s, which is different from your account password", errors.ErrAuth, fqdn)
+	case constants.Abuse:
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBannedAbuse)
+	case constants.Nohost, constants.Notfqdn:
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrHostnameNotExists, fqdn)
+	case constants.Nineoneone:
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrDNSServerSide)
 	}
 
 	if !strings.Contains(s, "nochg") && !strings.Contains(s, "good") {
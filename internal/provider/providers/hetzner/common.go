@@ -1,8 +1,13 @@
 package hetzner
 
 import (
+	"fmt"
 	"net/http"
+	"net/netip"
+	"strings"
 
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
 	"github.com/qdm12/ddns-updater/internal/provider/headers"
 )
 
@@ -12,3 +17,41 @@ func (p *Provider) setHeaders(request *http.Request) {
 	headers.SetAccept(request, "application/json")
 	request.Header.Set("Auth-API-Token", p.token)
 }
+
+func isIPRecordType(recordType string) bool {
+	return recordType == constants.A || recordType == constants.AAAA
+}
+
+// valuesEqual compares two record values, comparing IP addresses
+// in their canonical form for A and AAAA records, and ignoring the
+// trailing dot of CNAME targets.
+func valuesEqual(recordType, a, b string) bool {
+	switch {
+	case recordType == constants.CNAME:
+		return strings.TrimSuffix(a, ".") == strings.TrimSuffix(b, ".")
+	case !isIPRecordType(recordType):
+		return a == b
+	}
+	ipA, errA := netip.ParseAddr(a)
+	ipB, errB := netip.ParseAddr(b)
+	return errA == nil && errB == nil && ipA.Compare(ipB) == 0
+}
+
+func checkReceivedValue(recordType, sent, received string) (err error) {
+	if !isIPRecordType(recordType) {
+		if !valuesEqual(recordType, sent, received) {
+			return fmt.Errorf("%w: sent value %q to update but received %q",
+				errors.ErrUnknownResponse, sent, received)
+		}
+		return nil
+	}
+
+	receivedIP, err := netip.ParseAddr(received)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrIPReceivedMalformed, err)
+	} else if !valuesEqual(recordType, sent, received) {
+		return fmt.Errorf("%w: sent ip %s to update but received %s",
+			errors.ErrIPReceivedMismatch, sent, receivedIP)
+	}
+	return nil
+}
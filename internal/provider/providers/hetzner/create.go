@@ -6,20 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/netip"
 	"net/url"
 
-	"github.com/qdm12/ddns-updater/internal/provider/constants"
 	"github.com/qdm12/ddns-updater/internal/provider/errors"
 	"github.com/qdm12/ddns-updater/internal/provider/utils"
 )
 
-func (p *Provider) createRecord(ctx context.Context, client *http.Client, ip netip.Addr) (err error) {
-	recordType := constants.A
-	if ip.Is6() {
-		recordType = constants.AAAA
-	}
-
+func (p *Provider) createRecord(ctx context.Context, client *http.Client,
+	recordType, value string) (err error) {
 	u := url.URL{
 		Scheme: "https",
 		Host:   "dns.hetzner.com",
@@ -35,7 +29,7 @@ func (p *Provider) createRecord(ctx context.Context, client *http.Client, ip net
 	}{
 		Type:           recordType,
 		Name:           p.host,
-		Value:          ip.String(),
+		Value:          value,
 		ZoneIdentifier: p.zoneIdentifier,
 		TTL:            p.ttl,
 	}
@@ -60,7 +54,11 @@ func (p *Provider) createRecord(ctx context.Context, client *http.Client, ip net
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, utils.BodyToSingleLine(response.Body))
+	default:
 		return fmt.Errorf("%w: %d: %s",
 			errors.ErrHTTPStatusNotValid, response.StatusCode,
 			utils.BodyToSingleLine(response.Body))
@@ -69,17 +67,17 @@ func (p *Provider) createRecord(ctx context.Context, client *http.Client, ip net
 	decoder := json.NewDecoder(response.Body)
 	var parsedJSON struct {
 		Record struct {
-			ID    string     `json:"id"`
-			Value netip.Addr `json:"value"`
+			ID    string `json:"id"`
+			Value string `json:"value"`
 		} `json:"record"`
 	}
 	err = decoder.Decode(&parsedJSON)
-	newIP := parsedJSON.Record.Value
 	if err != nil {
 		return fmt.Errorf("json decoding response body: %w", err)
-	} else if newIP.Compare(ip) != 0 {
-		return fmt.Errorf("%w: sent ip %s to update but received %s",
-			errors.ErrIPReceivedMismatch, ip, newIP)
+	}
+	err = checkReceivedValue(recordType, value, parsedJSON.Record.Value)
+	if err != nil {
+		return err
 	}
 
 	if parsedJSON.Record.ID == "" {
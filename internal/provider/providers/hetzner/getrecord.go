@@ -5,22 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/netip"
 	"net/url"
 
-	"github.com/qdm12/ddns-updater/internal/provider/constants"
 	"github.com/qdm12/ddns-updater/internal/provider/errors"
 	"github.com/qdm12/ddns-updater/internal/provider/utils"
 )
 
 // See https://dns.hetzner.com/api-docs#operation/GetZones.
-func (p *Provider) getRecordID(ctx context.Context, client *http.Client, ip netip.Addr) (
-	identifier string, upToDate bool, err error) {
-	recordType := constants.A
-	if ip.Is6() {
-		recordType = constants.AAAA
-	}
-
+func (p *Provider) getRecordID(ctx context.Context, client *http.Client,
+	recordType, value string) (identifier string, upToDate bool, err error) {
 	u := url.URL{
 		Scheme: "https",
 		Host:   "dns.hetzner.com",
@@ -49,6 +42,8 @@ func (p *Provider) getRecordID(ctx context.Context, client *http.Client, ip neti
 
 	switch response.StatusCode {
 	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", false, fmt.Errorf("%w: %s", errors.ErrAuth, utils.BodyToSingleLine(response.Body))
 	case http.StatusNotFound:
 		return "", false, fmt.Errorf("%w", errors.ErrReceivedNoResult)
 	default:
@@ -59,8 +54,8 @@ func (p *Provider) getRecordID(ctx context.Context, client *http.Client, ip neti
 	decoder := json.NewDecoder(response.Body)
 	listRecordsResponse := struct {
 		Records []struct {
-			ID    string     `json:"id"`
-			Value netip.Addr `json:"value"`
+			ID    string `json:"id"`
+			Value string `json:"value"`
 		} `json:"records"`
 	}{}
 	err = decoder.Decode(&listRecordsResponse)
@@ -76,6 +71,6 @@ func (p *Provider) getRecordID(ctx context.Context, client *http.Client, ip neti
 			errors.ErrResultsCountReceived, len(listRecordsResponse.Records))
 	}
 	identifier = listRecordsResponse.Records[0].ID
-	upToDate = listRecordsResponse.Records[0].Value.Compare(ip) == 0
+	upToDate = valuesEqual(recordType, listRecordsResponse.Records[0].Value, value)
 	return identifier, upToDate, nil
 }
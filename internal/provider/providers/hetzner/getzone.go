@@ -0,0 +1,67 @@
+package hetzner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+// See https://dns.hetzner.com/api-docs#operation/GetZones.
+func (p *Provider) getZoneID(ctx context.Context, client *http.Client) (
+	identifier string, err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "dns.hetzner.com",
+		Path:   "/api/v1/zones",
+	}
+	values := url.Values{}
+	values.Set("name", p.domain)
+	u.RawQuery = values.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("creating http request: %w", err)
+	}
+	p.setHeaders(request)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", fmt.Errorf("%w: %s", errors.ErrAuth, utils.BodyToSingleLine(response.Body))
+	case http.StatusNotFound:
+		return "", fmt.Errorf("%w: for domain %s", errors.ErrZoneNotFound, p.domain)
+	default:
+		return "", fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	var listZonesResponse struct {
+		Zones []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"zones"`
+	}
+	err = decoder.Decode(&listZonesResponse)
+	if err != nil {
+		return "", fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	for _, zone := range listZonesResponse.Zones {
+		if zone.Name == p.domain {
+			return zone.ID, nil
+		}
+	}
+	return "", fmt.Errorf("%w: for domain %s", errors.ErrZoneNotFound, p.domain)
+}
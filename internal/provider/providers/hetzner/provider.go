@@ -57,10 +57,7 @@ func New(data json.RawMessage, domain, host string,
 }
 
 func (p *Provider) isValid() error {
-	switch {
-	case p.zoneIdentifier == "":
-		return fmt.Errorf("%w", errors.ErrZoneIdentifierNotSet)
-	case p.token == "":
+	if p.token == "" {
 		return fmt.Errorf("%w", errors.ErrTokenNotSet)
 	}
 	return nil
@@ -104,24 +101,50 @@ func (p *Provider) HTML() models.HTMLRow {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
-	recordID, upToDate, err := p.getRecordID(ctx, client, ip)
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	err = p.setRecord(ctx, client, recordType, ip.String())
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return ip, nil
+}
+
+// UpdateValue sets the record of the type given to the value given.
+func (p *Provider) UpdateValue(ctx context.Context, client *http.Client,
+	recordType, value string) (err error) {
+	return p.setRecord(ctx, client, recordType, value)
+}
+
+func (p *Provider) setRecord(ctx context.Context, client *http.Client,
+	recordType, value string) (err error) {
+	if p.zoneIdentifier == "" {
+		p.zoneIdentifier, err = p.getZoneID(ctx, client)
+		if err != nil {
+			return fmt.Errorf("getting zone id: %w", err)
+		}
+	}
+
+	recordID, upToDate, err := p.getRecordID(ctx, client, recordType, value)
 	switch {
 	case stderrors.Is(err, errors.ErrReceivedNoResult):
-		err = p.createRecord(ctx, client, ip)
+		err = p.createRecord(ctx, client, recordType, value)
 		if err != nil {
-			return netip.Addr{}, fmt.Errorf("creating record: %w", err)
+			return fmt.Errorf("creating record: %w", err)
 		}
-		return ip, nil
+		return nil
 	case err != nil:
-		return netip.Addr{}, fmt.Errorf("getting record id: %w", err)
+		return fmt.Errorf("getting record id: %w", err)
 	case upToDate:
-		return ip, nil
+		return nil
 	}
 
-	ip, err = p.updateRecord(ctx, client, recordID, ip)
+	err = p.updateRecord(ctx, client, recordID, recordType, value)
 	if err != nil {
-		return newIP, fmt.Errorf("updating record: %w", err)
+		return fmt.Errorf("updating record: %w", err)
 	}
-
-	return ip, nil
+	return nil
 }
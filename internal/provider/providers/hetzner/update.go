@@ -6,21 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/netip"
 	"net/url"
 
-	"github.com/qdm12/ddns-updater/internal/provider/constants"
 	"github.com/qdm12/ddns-updater/internal/provider/errors"
 	"github.com/qdm12/ddns-updater/internal/provider/utils"
 )
 
 func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
-	recordID string, ip netip.Addr) (newIP netip.Addr, err error) {
-	recordType := constants.A
-	if ip.Is6() {
-		recordType = constants.AAAA
-	}
-
+	recordID, recordType, value string) (err error) {
 	u := url.URL{
 		Scheme: "https",
 		Host:   "dns.hetzner.com",
@@ -36,7 +29,7 @@ func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
 	}{
 		Type:           recordType,
 		Name:           p.host,
-		Value:          ip.String(),
+		Value:          value,
 		ZoneIdentifier: p.zoneIdentifier,
 		TTL:            p.ttl,
 	}
@@ -45,24 +38,28 @@ func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
 	encoder := json.NewEncoder(buffer)
 	err = encoder.Encode(requestData)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("json encoding request data: %w", err)
+		return fmt.Errorf("json encoding request data: %w", err)
 	}
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), buffer)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+		return fmt.Errorf("creating http request: %w", err)
 	}
 
 	p.setHeaders(request)
 
 	response, err := client.Do(request)
 	if err != nil {
-		return netip.Addr{}, err
+		return err
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		return ip, fmt.Errorf("%w: %d: %s",
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, utils.BodyToSingleLine(response.Body))
+	default:
+		return fmt.Errorf("%w: %d: %s",
 			errors.ErrHTTPStatusNotValid, response.StatusCode,
 			utils.BodyToSingleLine(response.Body))
 	}
@@ -70,20 +67,13 @@ func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
 	decoder := json.NewDecoder(response.Body)
 	var parsedJSON struct {
 		Record struct {
-			Value netip.Addr `json:"value"`
+			Value string `json:"value"`
 		} `json:"record"`
 	}
 	err = decoder.Decode(&parsedJSON)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("json decoding response body: %w", err)
+		return fmt.Errorf("json decoding response body: %w", err)
 	}
 
-	newIP = parsedJSON.Record.Value
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("%w: %w", errors.ErrIPReceivedMalformed, err)
-	} else if newIP.Compare(ip) != 0 {
-		return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
-			errors.ErrIPReceivedMismatch, ip, newIP)
-	}
-	return newIP, nil
+	return checkReceivedValue(recordType, value, parsedJSON.Record.Value)
 }
@@ -0,0 +1,221 @@
+package hosttech
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+// defaultTTL is the TTL in seconds of created records if no ttl is configured.
+const defaultTTL = 3600
+
+type apiRecord struct {
+	ID   int64  `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+	TTL  uint32 `json:"ttl"`
+}
+
+func (r apiRecord) ip() string {
+	if r.IPv6 != "" {
+		return r.IPv6
+	}
+	return r.IPv4
+}
+
+func newAPIRecord(recordType, name string, ip netip.Addr, ttl uint32) (record apiRecord) {
+	record = apiRecord{
+		Type: recordType,
+		Name: name,
+		TTL:  ttl,
+	}
+	if ip.Is6() {
+		record.IPv6 = ip.String()
+	} else {
+		record.IPv4 = ip.String()
+	}
+	return record
+}
+
+func (p *Provider) getZoneID(ctx context.Context, client *http.Client) (
+	zoneID int64, err error) {
+	values := url.Values{}
+	values.Set("query", p.domain)
+	var zones []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, "/zones", values, nil, http.StatusOK, &zones)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, zone := range zones {
+		if zone.Name == p.domain {
+			return zone.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: in %d zone(s)", errors.ErrZoneNotFound, len(zones))
+}
+
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	zoneID int64, recordType string) (record apiRecord, err error) {
+	values := url.Values{}
+	values.Set("type", recordType)
+	var records []apiRecord
+	err = p.doRequest(ctx, client, http.MethodGet, recordsPath(zoneID), values, nil, http.StatusOK, &records)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	recordName := utils.BuildRecordName(p.host)
+	for _, record := range records {
+		if record.Name == recordName && record.Type == recordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(records))
+}
+
+func (p *Provider) createRecord(ctx context.Context, client *http.Client,
+	zoneID int64, recordType string, ip netip.Addr) (err error) {
+	ttl := uint32(defaultTTL)
+	if p.ttl != 0 {
+		ttl = p.ttl
+	}
+	record := newAPIRecord(recordType, utils.BuildRecordName(p.host), ip, ttl)
+	return p.doRequest(ctx, client, http.MethodPost, recordsPath(zoneID), nil,
+		record, http.StatusCreated, nil)
+}
+
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	zoneID int64, record apiRecord, ip netip.Addr) (err error) {
+	path := recordsPath(zoneID) + "/" + strconv.FormatInt(record.ID, 10)
+	ttl := record.TTL
+	if p.ttl != 0 {
+		ttl = p.ttl
+	}
+	requestData := newAPIRecord("", record.Name, ip, ttl)
+	return p.doRequest(ctx, client, http.MethodPut, path, nil,
+		requestData, http.StatusOK, nil)
+}
+
+func recordsPath(zoneID int64) string {
+	return "/zones/" + strconv.FormatInt(zoneID, 10) + "/records"
+}
+
+// doRequest sends a request to the API and decodes the data field
+// of the response body into responseData if it is not nil.
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	method, path string, values url.Values, requestData any,
+	expectedStatus int, responseData any) (err error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.ns1.hosttech.eu",
+		Path:     "/api/user/v1" + path,
+		RawQuery: values.Encode(),
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	headers.SetAuthBearer(request, p.apiToken)
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != expectedStatus {
+		return makeStatusError(response)
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	var data struct {
+		Data json.RawMessage `json:"data"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	err = json.Unmarshal(data.Data, responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response data: %w", err)
+	}
+	return nil
+}
+
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Message string              `json:"message"`
+		Errors  map[string][]string `json:"errors"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && data.Message != "" {
+		message = data.Message
+		fields := make([]string, 0, len(data.Errors))
+		for field := range data.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			message += ", " + field + ": " + strings.Join(data.Errors[field], ", ")
+		}
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrZoneNotFound, message)
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
@@ -0,0 +1,176 @@
+package infomaniak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecord struct {
+	ID     int64  `json:"id"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	TTL    uint32 `json:"ttl"`
+}
+
+// See https://developer.infomaniak.com/docs/api/get/2/zones/%7Bzone%7D/records
+func (p *Provider) updateWithAPI(ctx context.Context, client *http.Client,
+	ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	record, err := p.getAPIRecord(ctx, client, recordType)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting record: %w", err)
+	}
+
+	if record.Target == ip.String() {
+		return ip, nil
+	}
+
+	err = p.updateAPIRecord(ctx, client, record, ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating record: %w", err)
+	}
+
+	return ip, nil
+}
+
+func (p *Provider) getAPIRecord(ctx context.Context, client *http.Client,
+	recordType string) (record apiRecord, err error) {
+	var records []apiRecord
+	err = p.doAPIRequest(ctx, client, http.MethodGet, p.recordsPath(), nil, &records)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	source := p.recordSource()
+	for _, record := range records {
+		if record.Source == source && record.Type == recordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(records))
+}
+
+// See https://developer.infomaniak.com/docs/api/put/2/zones/%7Bzone%7D/records/%7Brecord%7D
+func (p *Provider) updateAPIRecord(ctx context.Context, client *http.Client,
+	record apiRecord, ip netip.Addr) (err error) {
+	requestData := struct {
+		Target string `json:"target"`
+		TTL    uint32 `json:"ttl,omitempty"`
+	}{
+		Target: ip.String(),
+		TTL:    record.TTL,
+	}
+	path := p.recordsPath() + "/" + strconv.FormatInt(record.ID, 10)
+	var data apiRecord
+	return p.doAPIRequest(ctx, client, http.MethodPut, path, requestData, &data)
+}
+
+func (p *Provider) recordsPath() string {
+	return "/2/zones/" + p.domain + "/records"
+}
+
+// recordSource returns the record source as expected by the API,
+// which is "." for the apex of the domain.
+func (p *Provider) recordSource() string {
+	if p.host == "@" {
+		return "."
+	}
+	return p.host
+}
+
+// doAPIRequest sends a request to the Infomaniak API and decodes the
+// JSON data field of the response body into responseData.
+func (p *Provider) doAPIRequest(ctx context.Context, client *http.Client,
+	method, path string, requestData, responseData any) (err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.infomaniak.com",
+		Path:   path,
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	headers.SetAuthBearer(request, p.token)
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	var data struct {
+		Result string          `json:"result"`
+		Data   json.RawMessage `json:"data"`
+		Error  struct {
+			Code        string `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	}
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		return fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
+			response.StatusCode, utils.ToSingleLine(string(b)))
+	}
+
+	if response.StatusCode != http.StatusOK || data.Result != "success" {
+		message := data.Error.Code + ": " + data.Error.Description
+		switch response.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %s", errors.ErrZoneNotFound, message)
+		case http.StatusTooManyRequests:
+			return fmt.Errorf("%w: %s", errors.ErrRateLimited, message)
+		default:
+			return fmt.Errorf("%w: %d: %s", errors.ErrUnsuccessful,
+				response.StatusCode, message)
+		}
+	}
+
+	err = json.Unmarshal(data.Data, responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response data: %w", err)
+	}
+	return nil
+}
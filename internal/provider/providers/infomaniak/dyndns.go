@@ -0,0 +1,90 @@
+package infomaniak
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+func (p *Provider) updateWithDynDNS(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "infomaniak.com",
+		Path:   "/nic/update",
+		User:   url.UserPassword(p.username, p.password),
+	}
+	values := url.Values{}
+	values.Set("hostname", utils.BuildURLQueryHostname(p.host, p.domain))
+	useProviderIP := p.useProviderIP && (ip.Is4() || !p.ipv6Suffix.IsValid())
+	if !useProviderIP {
+		values.Set("myip", ip.String())
+	}
+	u.RawQuery = values.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("doing http request: %w", err)
+	}
+	defer response.Body.Close()
+
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("reading response body: %w", err)
+	}
+	s := string(b)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		prefixFound := ""
+		for _, prefix := range []string{
+			"successfully_changed", "no_change",
+			"good", "nochg", // old prefixes
+		} {
+			if strings.HasPrefix(s, prefix) {
+				prefixFound = prefix
+				break
+			}
+		}
+
+		if prefixFound == "" {
+			return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, s)
+		}
+
+		ipString := strings.TrimPrefix(s, prefixFound+" ")
+		newIP, err = netip.ParseAddr(ipString)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("%w: for response %q: %w",
+				errors.ErrIPReceivedMalformed, ipString, err)
+		} else if !useProviderIP && ip.Compare(newIP) != 0 {
+			return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
+				errors.ErrIPReceivedMismatch, ip, newIP)
+		}
+		return newIP, nil
+	case http.StatusBadRequest:
+		switch s {
+		case constants.Nohost:
+			return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
+		case constants.Badauth:
+			return netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
+		default:
+			return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid, response.StatusCode, s)
+		}
+	default:
+		return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid, response.StatusCode, s)
+	}
+}
@@ -4,16 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/netip"
-	"net/url"
-	"strings"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
 	"github.com/qdm12/ddns-updater/internal/provider/errors"
-	"github.com/qdm12/ddns-updater/internal/provider/headers"
 	"github.com/qdm12/ddns-updater/internal/provider/utils"
 	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
 )
@@ -26,6 +22,9 @@ type Provider struct {
 	username      string
 	password      string
 	useProviderIP bool
+	// token is used to update the record through the Infomaniak API
+	// instead of the dyndns protocol if it is set.
+	token string
 }
 
 func New(data json.RawMessage, domain, host string,
@@ -35,10 +34,11 @@ func New(data json.RawMessage, domain, host string,
 		Username      string `json:"username"`
 		Password      string `json:"password"`
 		UseProviderIP bool   `json:"provider_ip"`
+		Token         string `json:"token"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding infomaniak extra settings: %w", err)
 	}
 	p = &Provider{
 		domain:        domain,
@@ -48,6 +48,7 @@ func New(data json.RawMessage, domain, host string,
 		username:      extraSettings.Username,
 		password:      extraSettings.Password,
 		useProviderIP: extraSettings.UseProviderIP,
+		token:         extraSettings.Token,
 	}
 	err = p.isValid()
 	if err != nil {
@@ -58,8 +59,10 @@ func New(data json.RawMessage, domain, host string,
 
 func (p *Provider) isValid() error {
 	switch {
+	case p.token != "":
+		return nil
 	case p.username == "":
-		return fmt.Errorf("%w", errors.ErrUsernameNotSet)
+		return fmt.Errorf("%w: and token not set", errors.ErrUsernameNotSet)
 	case p.password == "":
 		return fmt.Errorf("%w", errors.ErrPasswordNotSet)
 	case p.host == "*":
@@ -106,75 +109,8 @@ func (p *Provider) HTML() models.HTMLRow {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
-	u := url.URL{
-		Scheme: "https",
-		Host:   "infomaniak.com",
-		Path:   "/nic/update",
-		User:   url.UserPassword(p.username, p.password),
-	}
-	values := url.Values{}
-	values.Set("hostname", utils.BuildURLQueryHostname(p.host, p.domain))
-	useProviderIP := p.useProviderIP && (ip.Is4() || !p.ipv6Suffix.IsValid())
-	if !useProviderIP {
-		values.Set("myip", ip.String())
-	}
-	u.RawQuery = values.Encode()
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
-	}
-	headers.SetUserAgent(request)
-
-	response, err := client.Do(request)
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("doing http request: %w", err)
-	}
-	defer response.Body.Close()
-
-	b, err := io.ReadAll(response.Body)
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("reading response body: %w", err)
-	}
-	s := string(b)
-
-	switch response.StatusCode {
-	case http.StatusOK:
-		prefixFound := ""
-		for _, prefix := range []string{
-			"successfully_changed", "no_change",
-			"good", "nochg", // old prefixes
-		} {
-			if strings.HasPrefix(s, prefix) {
-				prefixFound = prefix
-				break
-			}
-		}
-
-		if prefixFound == "" {
-			return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, s)
-		}
-
-		ipString := strings.TrimPrefix(s, prefixFound+" ")
-		newIP, err = netip.ParseAddr(ipString)
-		if err != nil {
-			return netip.Addr{}, fmt.Errorf("%w: for response %q: %w",
-				errors.ErrIPReceivedMalformed, ipString, err)
-		} else if !useProviderIP && ip.Compare(newIP) != 0 {
-			return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
-				errors.ErrIPReceivedMismatch, ip, newIP)
-		}
-		return newIP, nil
-	case http.StatusBadRequest:
-		switch s {
-		case constants.Nohost:
-			return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
-		case constants.Badauth:
-			return netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
-		default:
-			return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid, response.StatusCode, s)
-		}
-	default:
-		return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid, response.StatusCode, s)
+	if p.token != "" {
+		return p.updateWithAPI(ctx, client, ip)
 	}
+	return p.updateWithDynDNS(ctx, client, ip)
 }
@@ -0,0 +1,231 @@
+package inwx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+// See https://www.inwx.com/en/help/apidoc
+func (p *Provider) updateWithAPI(ctx context.Context, client *http.Client,
+	ip netip.Addr) (newIP netip.Addr, err error) {
+	sessionCookies, err := p.login(ctx, client)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("logging in: %w", err)
+	}
+	defer func() {
+		logoutErr := p.callAPI(ctx, client, sessionCookies, "account.logout", struct{}{}, nil)
+		// The session expires on its own, so a logout failure
+		// does not fail an otherwise successful update.
+		if logoutErr != nil && err != nil {
+			err = fmt.Errorf("%w; logging out: %w", err, logoutErr)
+		}
+	}()
+
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	infoParams := struct {
+		Domain string `json:"domain"`
+		Name   string `json:"name"`
+		Type   string `json:"type"`
+	}{
+		Domain: p.domain,
+		Name:   utils.BuildURLQueryHostname(p.host, p.domain),
+		Type:   recordType,
+	}
+	var infoData struct {
+		Records []struct {
+			ID      int64  `json:"id"`
+			Name    string `json:"name"`
+			Type    string `json:"type"`
+			Content string `json:"content"`
+		} `json:"record"`
+	}
+	err = p.callAPI(ctx, client, sessionCookies, "nameserver.info", infoParams, &infoData)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting nameserver info: %w", err)
+	}
+
+	switch len(infoData.Records) {
+	case 0:
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrRecordNotFound)
+	case 1:
+	default:
+		return netip.Addr{}, fmt.Errorf("%w: %d records instead of 1",
+			errors.ErrResultsCountReceived, len(infoData.Records))
+	}
+	record := infoData.Records[0]
+
+	if record.Content == ip.String() {
+		return ip, nil
+	}
+
+	updateParams := struct {
+		ID      int64  `json:"id"`
+		Content string `json:"content"`
+	}{
+		ID:      record.ID,
+		Content: ip.String(),
+	}
+	err = p.callAPI(ctx, client, sessionCookies, "nameserver.updateRecord", updateParams, nil)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating record: %w", err)
+	}
+
+	return ip, nil
+}
+
+// login logs in to the INWX API and returns the session cookies to use
+// for subsequent calls. If the account has two factor authentication
+// enabled, the session is unlocked using the TOTP code computed from
+// the shared secret.
+func (p *Provider) login(ctx context.Context, client *http.Client) (
+	sessionCookies []*http.Cookie, err error) {
+	params := struct {
+		User string `json:"user"`
+		Pass string `json:"pass"`
+	}{
+		User: p.username,
+		Pass: p.password,
+	}
+	var data struct {
+		TFA string `json:"tfa"`
+	}
+	sessionCookies, err = p.doJSONRPC(ctx, client, nil, "account.login", params, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.TFA == "" || data.TFA == "0" {
+		return sessionCookies, nil
+	}
+
+	if p.sharedSecret == "" {
+		return nil, fmt.Errorf("%w: two factor authentication is enabled "+
+			"but no shared secret is set", errors.ErrAuth)
+	}
+
+	tan, err := totp(p.sharedSecret, p.timeNow())
+	if err != nil {
+		return nil, fmt.Errorf("computing TOTP code: %w", err)
+	}
+
+	unlockParams := struct {
+		TAN string `json:"tan"`
+	}{
+		TAN: tan,
+	}
+	err = p.callAPI(ctx, client, sessionCookies, "account.unlock", unlockParams, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unlocking account: %w", err)
+	}
+
+	return sessionCookies, nil
+}
+
+func (p *Provider) callAPI(ctx context.Context, client *http.Client,
+	sessionCookies []*http.Cookie, method string, params, resData any) (err error) {
+	_, err = p.doJSONRPC(ctx, client, sessionCookies, method, params, resData)
+	return err
+}
+
+// doJSONRPC calls the JSON-RPC method given with the params, decodes the
+// resData field into resData if it is not nil, and returns the cookies
+// set by the response.
+func (p *Provider) doJSONRPC(ctx context.Context, client *http.Client,
+	sessionCookies []*http.Cookie, method string, params, resData any) (
+	responseCookies []*http.Cookie, err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.domrobot.com",
+		Path:   "/jsonrpc/",
+	}
+
+	requestData := struct {
+		Method string `json:"method"`
+		Params any    `json:"params"`
+	}{
+		Method: method,
+		Params: params,
+	}
+	buffer := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buffer)
+	err = encoder.Encode(requestData)
+	if err != nil {
+		return nil, fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), buffer)
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/json")
+	headers.SetAccept(request, "application/json")
+	for _, cookie := range sessionCookies {
+		request.AddCookie(cookie)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("doing http request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	}
+
+	var responseData struct {
+		Code    int             `json:"code"`
+		Message string          `json:"msg"`
+		Reason  string          `json:"reason"`
+		ResData json.RawMessage `json:"resData"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&responseData)
+	if err != nil {
+		return nil, fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	// See https://www.inwx.com/en/help/apidoc/f/ch04.html
+	const (
+		codeSuccess        = 1000
+		codeSuccessPending = 1001
+		codeAuthError      = 2200
+		codeAuthorization  = 2201
+		codeObjectNotFound = 2303
+	)
+	switch responseData.Code {
+	case codeSuccess, codeSuccessPending:
+	case codeAuthError, codeAuthorization:
+		return nil, fmt.Errorf("%w: %s: %s", errors.ErrAuth, responseData.Message, responseData.Reason)
+	case codeObjectNotFound:
+		return nil, fmt.Errorf("%w: %s: %s", errors.ErrDomainNotFound, responseData.Message, responseData.Reason)
+	default:
+		return nil, fmt.Errorf("%w: code %d: %s: %s", errors.ErrUnsuccessful,
+			responseData.Code, responseData.Message, responseData.Reason)
+	}
+
+	if resData != nil && len(responseData.ResData) > 0 {
+		err = json.Unmarshal(responseData.ResData, resData)
+		if err != nil {
+			return nil, fmt.Errorf("json decoding response data: %w", err)
+		}
+	}
+
+	return response.Cookies(), nil
+}
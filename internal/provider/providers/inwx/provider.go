@@ -9,6 +9,7 @@ import (
 	"net/netip"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
@@ -25,26 +26,35 @@ type Provider struct {
 	ipv6Suffix netip.Prefix
 	username   string
 	password   string
+	// mode is "dyndns" by default or "api" to use the JSON-RPC API.
+	mode         string
+	sharedSecret string
+	timeNow      func() time.Time
 }
 
 func New(data json.RawMessage, domain, host string,
 	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
 	p *Provider, err error) {
 	extraSettings := struct {
-		Username string `json:"username"`
-		Password string `json:"password"`
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		Mode         string `json:"mode"`
+		SharedSecret string `json:"shared_secret"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
 		return nil, fmt.Errorf("decoding inwx extra settings: %w", err)
 	}
 	p = &Provider{
-		domain:     domain,
-		host:       host,
-		ipVersion:  ipVersion,
-		ipv6Suffix: ipv6Suffix,
-		username:   extraSettings.Username,
-		password:   extraSettings.Password,
+		domain:       domain,
+		host:         host,
+		ipVersion:    ipVersion,
+		ipv6Suffix:   ipv6Suffix,
+		username:     extraSettings.Username,
+		password:     extraSettings.Password,
+		mode:         extraSettings.Mode,
+		sharedSecret: extraSettings.SharedSecret,
+		timeNow:      time.Now,
 	}
 	err = p.isValid()
 	if err != nil {
@@ -59,6 +69,8 @@ func (p *Provider) isValid() error {
 		return fmt.Errorf("%w", errors.ErrUsernameNotSet)
 	case p.password == "":
 		return fmt.Errorf("%w", errors.ErrPasswordNotSet)
+	case p.mode != "" && p.mode != "dyndns" && p.mode != "api":
+		return fmt.Errorf("%w: %q", errors.ErrModeNotValid, p.mode)
 	}
 	return nil
 }
@@ -101,6 +113,14 @@ func (p *Provider) HTML() models.HTMLRow {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	if p.mode == "api" {
+		return p.updateWithAPI(ctx, client, ip)
+	}
+	return p.updateWithDynDNS(ctx, client, ip)
+}
+
+func (p *Provider) updateWithDynDNS(ctx context.Context, client *http.Client, ip netip.Addr) (
+	newIP netip.Addr, err error) {
 	u := url.URL{
 		Scheme: "https",
 		User:   url.UserPassword(p.username, p.password),
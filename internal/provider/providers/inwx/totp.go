@@ -0,0 +1,38 @@
+package inwx
+
+//nolint:gosec
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// totp computes the 6 digits time based one time password
+// for the base32 encoded secret given, as defined in RFC 6238.
+func totp(secret string, now time.Time) (code string, err error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	secret = strings.TrimRight(secret, "=")
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", fmt.Errorf("base32 decoding shared secret: %w", err)
+	}
+
+	const period = 30
+	counter := uint64(now.Unix() / period)
+	message := make([]byte, 8) //nolint:gomnd
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	_, _ = mac.Write(message)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation, see RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f                                //nolint:gomnd
+	truncated := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff //nolint:gomnd
+	const digits = 1000000
+	return fmt.Sprintf("%06d", truncated%digits), nil
+}
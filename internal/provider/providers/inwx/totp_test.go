@@ -0,0 +1,38 @@
+package inwx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_totp(t *testing.T) {
+	t.Parallel()
+
+	// Base32 encoding of the RFC 6238 SHA1 secret "12345678901234567890".
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	// Test vectors from RFC 6238 Appendix B, truncated to 6 digits.
+	testCases := map[string]struct {
+		unixTime int64
+		code     string
+	}{
+		"59":         {unixTime: 59, code: "287082"},
+		"1111111109": {unixTime: 1111111109, code: "081804"},
+		"1234567890": {unixTime: 1234567890, code: "005924"},
+		"2000000000": {unixTime: 2000000000, code: "279037"},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := totp(secret, time.Unix(testCase.unixTime, 0))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.code, code)
+		})
+	}
+}
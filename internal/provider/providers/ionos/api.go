@@ -27,7 +27,7 @@ func (p *Provider) setHeaders(request *http.Request) {
 	headers.SetAccept(request, "application/json")
 	headers.SetXAPIKey(request, p.apiKey)
 	switch request.Method {
-	case http.MethodPost, http.MethodPut:
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
 		headers.SetContentType(request, "application/json")
 	}
 }
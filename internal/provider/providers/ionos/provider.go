@@ -20,6 +20,7 @@ type Provider struct {
 	ipVersion  ipversion.IPVersion
 	ipv6Suffix netip.Prefix
 	apiKey     string
+	ttl        uint32
 }
 
 func New(data json.RawMessage, domain, host string,
@@ -27,16 +28,24 @@ func New(data json.RawMessage, domain, host string,
 	p *Provider, err error) {
 	extraSettings := struct {
 		APIKey string `json:"api_key"`
+		Prefix string `json:"prefix"`
+		Secret string `json:"secret"`
+		TTL    uint32 `json:"ttl"`
 	}{}
 	if err := json.Unmarshal(data, &extraSettings); err != nil {
 		return nil, fmt.Errorf("decoding ionos extra settings: %w", err)
 	}
+	if extraSettings.APIKey == "" && extraSettings.Prefix != "" && extraSettings.Secret != "" {
+		// The API key used in the X-API-Key header is in the format prefix.secret
+		extraSettings.APIKey = extraSettings.Prefix + "." + extraSettings.Secret
+	}
 	p = &Provider{
 		domain:     domain,
 		host:       host,
 		ipVersion:  ipVersion,
 		ipv6Suffix: ipv6Suffix,
 		apiKey:     extraSettings.APIKey,
+		ttl:        extraSettings.TTL,
 	}
 	if err := p.isValid(); err != nil {
 		return nil, err
@@ -136,15 +145,20 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		return ip, nil
 	}
 
+	upToDate := true
 	for _, matchingRecord := range matchingRecords {
-		if matchingRecord.Content == ip.String() {
-			continue // already up to date
+		if matchingRecord.Content != ip.String() {
+			upToDate = false
+			break
 		}
+	}
+	if upToDate {
+		return ip, nil
+	}
 
-		err = p.updateRecord(ctx, client, zoneID, matchingRecord, ip)
-		if err != nil {
-			return netip.Addr{}, fmt.Errorf("updating record: %w", err)
-		}
+	err = p.patchRecords(ctx, client, zoneID, matchingRecords[0], ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("patching records: %w", err)
 	}
 
 	return ip, nil
@@ -12,34 +12,40 @@ import (
 	"github.com/qdm12/ddns-updater/internal/provider/errors"
 )
 
-func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+// patchRecords replaces all the records matching the existing record
+// name and type in the zone with a single record having the new IP address.
+// See https://developer.hosting.ionos.com/docs/dns#/Zones/patchZone
+func (p *Provider) patchRecords(ctx context.Context, client *http.Client,
 	zoneID string, existingRecord apiRecord, ip netip.Addr) (err error) {
 	u := url.URL{
 		Scheme: "https",
 		Host:   "api.hosting.ionos.com",
-		Path:   "/dns/v1/zones/" + zoneID + "/records/" + existingRecord.ID,
+		Path:   "/dns/v1/zones/" + zoneID,
 	}
 
-	recordUpdate := struct {
-		Content  string `json:"content"`
-		TTL      uint32 `json:"ttl"`
-		Prio     uint32 `json:"prio"`
-		Disabled bool   `json:"disabled"`
-	}{
-		Content:  ip.String(),
-		TTL:      existingRecord.TTL,
-		Prio:     existingRecord.Prio,
-		Disabled: existingRecord.Disabled,
+	ttl := existingRecord.TTL
+	if p.ttl != 0 {
+		ttl = p.ttl
+	}
+	recordsPatch := []apiRecord{
+		{
+			Name:     existingRecord.Name,
+			Type:     existingRecord.Type,
+			Content:  ip.String(),
+			TTL:      ttl,
+			Prio:     existingRecord.Prio,
+			Disabled: existingRecord.Disabled,
+		},
 	}
 
 	buffer := bytes.NewBuffer(nil)
 	encoder := json.NewEncoder(buffer)
-	err = encoder.Encode(recordUpdate)
+	err = encoder.Encode(recordsPatch)
 	if err != nil {
 		return fmt.Errorf("encoding request data to JSON: %w", err)
 	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), buffer)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPatch, u.String(), buffer)
 	if err != nil {
 		return fmt.Errorf("creating http request: %w", err)
 	}
@@ -65,7 +71,7 @@ func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
 		return fmt.Errorf("%w: %s", errors.ErrAuth,
 			decodeErrorMessage(response.Body))
 	case http.StatusNotFound:
-		return fmt.Errorf("%w: %s", errors.ErrRecordNotFound,
+		return fmt.Errorf("%w: %s", errors.ErrZoneNotFound,
 			decodeErrorMessage(response.Body))
 	default:
 		return fmt.Errorf("%w: %s: %s", errors.ErrHTTPStatusNotValid,
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/netip"
 	"net/url"
+	"strings"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
@@ -161,8 +162,7 @@ func (p *Provider) getDomainID(ctx context.Context, client *http.Client) (domain
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		err = fmt.Errorf("%w: %d", errors.ErrHTTPStatusNotValid, response.StatusCode)
-		return 0, fmt.Errorf("%w: %s", err, p.getErrorMessage(response.Body))
+		return 0, p.makeStatusError(response)
 	}
 
 	decoder := json.NewDecoder(response.Body)
@@ -221,8 +221,7 @@ func (p *Provider) getRecordID(ctx context.Context, client *http.Client,
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		err = fmt.Errorf("%w: %d", errors.ErrHTTPStatusNotValid, response.StatusCode)
-		return 0, fmt.Errorf("%w: %s", err, p.getErrorMessage(response.Body))
+		return 0, p.makeStatusError(response)
 	}
 
 	decoder := json.NewDecoder(response.Body)
@@ -238,8 +237,9 @@ func (p *Provider) getRecordID(ctx context.Context, client *http.Client,
 		return 0, fmt.Errorf("json decoding response body: %w", err)
 	}
 
+	recordName := utils.BuildRecordName(p.host)
 	for _, domainRecord := range obj.Data {
-		if domainRecord.Type == recordType && domainRecord.Host == p.host {
+		if domainRecord.Type == recordType && domainRecord.Host == recordName {
 			return domainRecord.ID, nil
 		}
 	}
@@ -263,7 +263,7 @@ func (p *Provider) createRecord(ctx context.Context, client *http.Client,
 
 	requestData := domainRecord{
 		Type: recordType,
-		Host: p.BuildDomainName(),
+		Host: utils.BuildRecordName(p.host),
 		IP:   ip.String(),
 	}
 	buffer := bytes.NewBuffer(nil)
@@ -287,8 +287,7 @@ func (p *Provider) createRecord(ctx context.Context, client *http.Client,
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		err = fmt.Errorf("%w: %d", errors.ErrHTTPStatusNotValid, response.StatusCode)
-		return fmt.Errorf("%w: %s", err, p.getErrorMessage(response.Body))
+		return p.makeStatusError(response)
 	}
 
 	var responseData domainRecord
@@ -343,8 +342,7 @@ func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		err = fmt.Errorf("%w: %d", errors.ErrHTTPStatusNotValid, response.StatusCode)
-		return fmt.Errorf("%w: %s", err, p.getErrorMessage(response.Body))
+		return p.makeStatusError(response)
 	}
 
 	data.IP = ""
@@ -365,6 +363,18 @@ func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
 	return nil
 }
 
+func (p *Provider) makeStatusError(response *http.Response) (err error) {
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		err = fmt.Errorf("%w: %d", errors.ErrAuth, response.StatusCode)
+	case http.StatusBadRequest:
+		err = fmt.Errorf("%w: %d", errors.ErrBadRequest, response.StatusCode)
+	default:
+		err = fmt.Errorf("%w: %d", errors.ErrHTTPStatusNotValid, response.StatusCode)
+	}
+	return fmt.Errorf("%w: %s", err, p.getErrorMessage(response.Body))
+}
+
 func (p *Provider) getErrorMessage(body io.Reader) (message string) {
 	var errorObj linodeErrors
 	b, err := io.ReadAll(body)
@@ -372,8 +382,16 @@ func (p *Provider) getErrorMessage(body io.Reader) (message string) {
 		return fmt.Sprintf("reading body: %s", err)
 	}
 	err = json.Unmarshal(b, &errorObj)
-	if err != nil {
+	if err != nil || len(errorObj.Errors) == 0 {
 		return utils.ToSingleLine(string(b))
 	}
-	return fmt.Sprintf("%v", errorObj)
+
+	messages := make([]string, len(errorObj.Errors))
+	for i, linodeError := range errorObj.Errors {
+		messages[i] = linodeError.Reason
+		if linodeError.Field != "" {
+			messages[i] = linodeError.Field + ": " + linodeError.Reason
+		}
+	}
+	return strings.Join(messages, "; ")
 }
@@ -0,0 +1,171 @@
+package loopia
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type zoneRecord struct {
+	recordType string
+	ttl        int
+	priority   int
+	rdata      string
+	recordID   int
+}
+
+func (r zoneRecord) toValue() value {
+	members := []member{
+		{Name: "type", Value: stringValue(r.recordType)},
+		{Name: "ttl", Value: intValue(r.ttl)},
+		{Name: "priority", Value: intValue(r.priority)},
+		{Name: "rdata", Value: stringValue(r.rdata)},
+	}
+	if r.recordID != 0 {
+		members = append(members, member{Name: "record_id", Value: intValue(r.recordID)})
+	}
+	return structValue(members...)
+}
+
+// See https://www.loopia.com/api/getzonerecords/
+func (p *Provider) getZoneRecord(ctx context.Context, client *http.Client,
+	recordType string) (record zoneRecord, err error) {
+	result, err := p.call(ctx, client, "getZoneRecords",
+		stringValue(p.domain), stringValue(p.host))
+	if err != nil {
+		return zoneRecord{}, err
+	}
+
+	if result.isString() {
+		return zoneRecord{}, makeStatusError(result.asString())
+	} else if result.Array == nil {
+		return zoneRecord{}, fmt.Errorf("%w: expected an array of records", errors.ErrUnknownResponse)
+	}
+
+	for _, recordValue := range result.Array.Values {
+		if recordValue.member("type").asString() != recordType {
+			continue
+		}
+
+		record = zoneRecord{
+			recordType: recordType,
+			rdata:      recordValue.member("rdata").asString(),
+		}
+		record.ttl, err = recordValue.member("ttl").asInt()
+		if err != nil {
+			return zoneRecord{}, fmt.Errorf("parsing record ttl: %w", err)
+		}
+		record.priority, err = recordValue.member("priority").asInt()
+		if err != nil {
+			return zoneRecord{}, fmt.Errorf("parsing record priority: %w", err)
+		}
+		record.recordID, err = recordValue.member("record_id").asInt()
+		if err != nil {
+			return zoneRecord{}, fmt.Errorf("parsing record id: %w", err)
+		}
+		return record, nil
+	}
+
+	return zoneRecord{}, fmt.Errorf("%w", errors.ErrRecordNotFound)
+}
+
+// See https://www.loopia.com/api/addzonerecord/
+func (p *Provider) addZoneRecord(ctx context.Context, client *http.Client,
+	recordType string, ip netip.Addr) (err error) {
+	const defaultTTL = 3600
+	ttl := defaultTTL
+	if p.ttl != 0 {
+		ttl = int(p.ttl)
+	}
+	record := zoneRecord{
+		recordType: recordType,
+		ttl:        ttl,
+		rdata:      ip.String(),
+	}
+	result, err := p.call(ctx, client, "addZoneRecord",
+		stringValue(p.domain), stringValue(p.host), record.toValue())
+	if err != nil {
+		return err
+	}
+	return makeStatusError(result.asString())
+}
+
+// See https://www.loopia.com/api/updatezonerecord/
+func (p *Provider) updateZoneRecord(ctx context.Context, client *http.Client,
+	record zoneRecord) (err error) {
+	result, err := p.call(ctx, client, "updateZoneRecord",
+		stringValue(p.domain), stringValue(p.host), record.toValue())
+	if err != nil {
+		return err
+	}
+	return makeStatusError(result.asString())
+}
+
+// call calls the XML-RPC method with the username and password
+// followed by the params given, and returns the result value.
+func (p *Provider) call(ctx context.Context, client *http.Client,
+	methodName string, params ...value) (result value, err error) {
+	params = append([]value{stringValue(p.username), stringValue(p.password)}, params...)
+	body, err := encodeMethodCall(methodName, params...)
+	if err != nil {
+		return value{}, err
+	}
+
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.loopia.se",
+		Path:   "/RPCSERV",
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return value{}, fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "text/xml")
+	headers.SetAccept(request, "text/xml")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return value{}, fmt.Errorf("doing http request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return value{}, fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return value{}, fmt.Errorf("reading response body: %w", err)
+	}
+
+	return decodeMethodResponse(responseBody)
+}
+
+// makeStatusError returns an error for the status string returned by
+// the Loopia API, or nil if the status is OK.
+func makeStatusError(status string) error {
+	switch status {
+	case "OK":
+		return nil
+	case "AUTH_ERROR":
+		return fmt.Errorf("%w", errors.ErrAuth)
+	case "RATE_LIMITED":
+		return fmt.Errorf("%w", errors.ErrRateLimited)
+	case "BAD_INDATA":
+		return fmt.Errorf("%w", errors.ErrBadRequest)
+	case "UNKNOWN_ERROR":
+		return fmt.Errorf("%w: unknown error", errors.ErrUnsuccessful)
+	default:
+		return fmt.Errorf("%w: %s", errors.ErrUnknownResponse, status)
+	}
+}
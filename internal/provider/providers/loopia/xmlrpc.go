@@ -0,0 +1,141 @@
+package loopia
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+)
+
+// Minimal XML-RPC types supporting the value types used by the Loopia API.
+// See http://xmlrpc.com/spec.md
+
+type methodCall struct {
+	XMLName    xml.Name `xml:"methodCall"`
+	MethodName string   `xml:"methodName"`
+	Params     []param  `xml:"params>param"`
+}
+
+type methodResponse struct {
+	XMLName xml.Name `xml:"methodResponse"`
+	Params  []param  `xml:"params>param"`
+	Fault   *value   `xml:"fault>value"`
+}
+
+type param struct {
+	Value value `xml:"value"`
+}
+
+type value struct {
+	String  *string `xml:"string,omitempty"`
+	Int     *int    `xml:"int,omitempty"`
+	I4      *int    `xml:"i4,omitempty"`
+	Boolean *int    `xml:"boolean,omitempty"`
+	Struct  *struct {
+		Members []member `xml:"member"`
+	} `xml:"struct,omitempty"`
+	Array *struct {
+		Values []value `xml:"data>value"`
+	} `xml:"array,omitempty"`
+	// Untyped is the character data of a value without type
+	// element, which defaults to a string as per the specification.
+	Untyped string `xml:",chardata"`
+}
+
+type member struct {
+	Name  string `xml:"name"`
+	Value value  `xml:"value"`
+}
+
+func stringValue(s string) value {
+	return value{String: &s}
+}
+
+func intValue(i int) value {
+	return value{Int: &i}
+}
+
+func structValue(members ...member) value {
+	v := value{}
+	v.Struct = &struct {
+		Members []member `xml:"member"`
+	}{Members: members}
+	return v
+}
+
+func encodeMethodCall(methodName string, params ...value) (body []byte, err error) {
+	call := methodCall{
+		MethodName: methodName,
+		Params:     make([]param, len(params)),
+	}
+	for i, v := range params {
+		call.Params[i].Value = v
+	}
+	body, err = xml.Marshal(call)
+	if err != nil {
+		return nil, fmt.Errorf("xml encoding method call: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// decodeMethodResponse decodes the XML-RPC response body and returns its
+// single parameter value, or an error if the response is a fault.
+func decodeMethodResponse(body []byte) (v value, err error) {
+	var response methodResponse
+	err = xml.Unmarshal(body, &response)
+	if err != nil {
+		return value{}, fmt.Errorf("xml decoding method response: %w", err)
+	}
+
+	if response.Fault != nil {
+		faultCode, _ := response.Fault.member("faultCode").asInt()
+		faultString := response.Fault.member("faultString").asString()
+		return value{}, fmt.Errorf("%w: fault code %d: %s",
+			errors.ErrUnsuccessful, faultCode, faultString)
+	}
+
+	if len(response.Params) != 1 {
+		return value{}, fmt.Errorf("%w: %d parameters instead of 1",
+			errors.ErrResultsCountReceived, len(response.Params))
+	}
+	return response.Params[0].Value, nil
+}
+
+func (v value) isString() bool {
+	return v.String != nil ||
+		(v.Int == nil && v.I4 == nil && v.Boolean == nil && v.Struct == nil && v.Array == nil)
+}
+
+func (v value) asString() string {
+	if v.String != nil {
+		return *v.String
+	}
+	return strings.TrimSpace(v.Untyped)
+}
+
+func (v value) asInt() (i int, err error) {
+	switch {
+	case v.Int != nil:
+		return *v.Int, nil
+	case v.I4 != nil:
+		return *v.I4, nil
+	default:
+		return strconv.Atoi(v.asString())
+	}
+}
+
+// member returns the value of the struct member with the given name,
+// or an empty value if the value is not a struct or the member is not found.
+func (v value) member(name string) value {
+	if v.Struct == nil {
+		return value{}
+	}
+	for _, m := range v.Struct.Members {
+		if m.Name == name {
+			return m.Value
+		}
+	}
+	return value{}
+}
@@ -0,0 +1,118 @@
+package loopia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_encodeMethodCall(t *testing.T) {
+	t.Parallel()
+
+	body, err := encodeMethodCall("updateZoneRecord",
+		stringValue("user@loopiaapi"),
+		stringValue("password"),
+		stringValue("example.com"),
+		stringValue("www"),
+		structValue(
+			member{Name: "type", Value: stringValue("A")},
+			member{Name: "ttl", Value: intValue(3600)},
+			member{Name: "rdata", Value: stringValue("1.2.3.4")},
+		),
+	)
+	require.NoError(t, err)
+
+	const expected = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<methodCall><methodName>updateZoneRecord</methodName><params>` +
+		`<param><value><string>user@loopiaapi</string></value></param>` +
+		`<param><value><string>password</string></value></param>` +
+		`<param><value><string>example.com</string></value></param>` +
+		`<param><value><string>www</string></value></param>` +
+		`<param><value><struct>` +
+		`<member><name>type</name><value><string>A</string></value></member>` +
+		`<member><name>ttl</name><value><int>3600</int></value></member>` +
+		`<member><name>rdata</name><value><string>1.2.3.4</string></value></member>` +
+		`</struct></value></param>` +
+		`</params></methodCall>`
+	assert.Equal(t, expected, string(body))
+}
+
+func Test_decodeMethodResponse(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		body       string
+		check      func(t *testing.T, v value)
+		errMessage string
+	}{
+		"records": {
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+  <params>
+    <param>
+      <value>
+        <array>
+          <data>
+            <value>
+              <struct>
+                <member><name>type</name><value><string>A</string></value></member>
+                <member><name>ttl</name><value><int>3600</int></value></member>
+                <member><name>rdata</name><value>1.2.3.4</value></member>
+                <member><name>record_id</name><value><int>12345</int></value></member>
+              </struct>
+            </value>
+          </data>
+        </array>
+      </value>
+    </param>
+  </params>
+</methodResponse>`,
+			check: func(t *testing.T, v value) {
+				t.Helper()
+				require.NotNil(t, v.Array)
+				require.Len(t, v.Array.Values, 1)
+				record := v.Array.Values[0]
+				assert.Equal(t, "A", record.member("type").asString())
+				assert.True(t, record.member("rdata").isString())
+				assert.Equal(t, "1.2.3.4", record.member("rdata").asString())
+				recordID, err := record.member("record_id").asInt()
+				require.NoError(t, err)
+				assert.Equal(t, 12345, recordID)
+			},
+		},
+		"status_string": {
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><string>AUTH_ERROR</string></value></param></params></methodResponse>`,
+			check: func(t *testing.T, v value) {
+				t.Helper()
+				assert.True(t, v.isString())
+				assert.Equal(t, "AUTH_ERROR", v.asString())
+			},
+		},
+		"fault": {
+			body: `<?xml version="1.0"?>
+<methodResponse><fault><value><struct>
+<member><name>faultCode</name><value><int>623</int></value></member>
+<member><name>faultString</name><value><string>Method not found</string></value></member>
+</struct></value></fault></methodResponse>`,
+			errMessage: "unsuccessful result: fault code 623: Method not found",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := decodeMethodResponse([]byte(testCase.body))
+
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			testCase.check(t, v)
+		})
+	}
+}
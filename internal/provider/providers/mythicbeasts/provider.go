@@ -0,0 +1,190 @@
+package mythicbeasts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	keyID      string
+	secret     string
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		KeyID  string `json:"key_id"`
+		Secret string `json:"secret"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding mythic beasts extra settings: %w", err)
+	}
+	p = &Provider{
+		domain:     domain,
+		host:       host,
+		ipVersion:  ipVersion,
+		ipv6Suffix: ipv6Suffix,
+		keyID:      extraSettings.KeyID,
+		secret:     extraSettings.Secret,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	switch {
+	case p.keyID == "":
+		return fmt.Errorf("%w", errors.ErrKeyNotSet)
+	case p.secret == "":
+		return fmt.Errorf("%w", errors.ErrSecretNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.MythicBeasts, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://www.mythic-beasts.com/\">Mythic Beasts</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://www.mythic-beasts.com/support/api/dnsv2
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	u := url.URL{
+		Scheme: "https",
+		User:   url.UserPassword(p.keyID, p.secret),
+		Host:   "api.mythic-beasts.com",
+		Path:   "/dns/v2/zones/" + p.domain + "/records/" + p.host + "/" + recordType,
+	}
+
+	type record struct {
+		Data string `json:"data"`
+	}
+	requestData := struct {
+		Records []record `json:"records"`
+	}{
+		Records: []record{{Data: ip.String()}},
+	}
+	buffer := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buffer)
+	err = encoder.Encode(requestData)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), buffer)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/json")
+	headers.SetAccept(request, "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer response.Body.Close()
+
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("reading response body: %w", err)
+	}
+
+	var responseData struct {
+		RecordsAdded   *uint  `json:"records_added"`
+		RecordsRemoved *uint  `json:"records_removed"`
+		Message        string `json:"message"`
+		Error          string `json:"error"`
+	}
+	_ = json.Unmarshal(b, &responseData)
+	message := responseData.Error
+	if message == "" {
+		message = utils.ToSingleLine(string(b))
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return netip.Addr{}, fmt.Errorf("%w: %s is not a record of zone %s "+
+			"that the API key can access: %s", errors.ErrRecordNotFound,
+			p.BuildDomainName(), p.domain, message)
+	case http.StatusBadRequest:
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	default:
+		return netip.Addr{}, fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+
+	if responseData.RecordsAdded == nil || responseData.RecordsRemoved == nil {
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, message)
+	}
+
+	// records_added is 0 if the record already had the IP address,
+	// and should otherwise be 1 since we only send one record.
+	if *responseData.RecordsAdded > 1 {
+		return netip.Addr{}, fmt.Errorf("%w: %d records added instead of 1",
+			errors.ErrResultsCountReceived, *responseData.RecordsAdded)
+	}
+
+	return ip, nil
+}
@@ -53,8 +53,9 @@ func (p *Provider) getRecordID(ctx context.Context, client *http.Client,
 		return 0, fmt.Errorf("json decoding response body: %w", err)
 	}
 
+	recordHost := p.recordHost()
 	for _, record := range data.Records {
-		if record.Host == p.host && record.Type == recordType {
+		if record.Host == recordHost && record.Type == recordType {
 			return record.RecordID, nil
 		}
 	}
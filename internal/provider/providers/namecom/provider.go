@@ -124,3 +124,12 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 
 	return ip, nil
 }
+
+// recordHost returns the host as expected by the Name.com API,
+// which uses an empty host for the apex of the domain instead of "@".
+func (p *Provider) recordHost() string {
+	if p.host == "@" {
+		return ""
+	}
+	return p.host
+}
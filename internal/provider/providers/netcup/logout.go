@@ -0,0 +1,32 @@
+package netcup
+
+import (
+	"context"
+	"net/http"
+)
+
+func (p *Provider) logout(ctx context.Context, client *http.Client,
+	session string) (err error) {
+	type jsonParams struct {
+		APIKey         string `json:"apikey"`
+		APISessionID   string `json:"apisessionid"`
+		CustomerNumber string `json:"customernumber"`
+	}
+
+	type jsonRequest struct {
+		Action string     `json:"action"`
+		Param  jsonParams `json:"param"`
+	}
+
+	request := jsonRequest{
+		Action: "logout",
+		Param: jsonParams{
+			APIKey:         p.apiKey,
+			APISessionID:   session,
+			CustomerNumber: p.customerNumber,
+		},
+	}
+
+	var responseData struct{}
+	return doJSONHTTP(ctx, client, request, &responseData)
+}
@@ -31,11 +31,15 @@ func New(data json.RawMessage, domain, host string,
 		CustomerNumber string `json:"customer_number"`
 		APIKey         string `json:"api_key"`
 		Password       string `json:"password"`
+		APIPassword    string `json:"api_password"`
 	}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
 		return nil, fmt.Errorf("JSON decoding provider specific settings: %w", err)
 	}
+	if extraSettings.Password == "" {
+		extraSettings.Password = extraSettings.APIPassword
+	}
 
 	p = &Provider{
 		domain:         domain,
@@ -106,11 +110,20 @@ func (p *Provider) HTML() models.HTMLRow {
 	}
 }
 
+// See https://ccp.netcup.net/run/webservice/servers/endpoint.php
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
 	session, err := p.login(ctx, client)
 	if err != nil {
 		return netip.Addr{}, fmt.Errorf("logging in: %w", err)
 	}
+	defer func() {
+		logoutErr := p.logout(ctx, client, session)
+		// The session expires on its own after 15 minutes, so a
+		// logout failure does not fail an otherwise successful update.
+		if logoutErr != nil && err != nil {
+			err = fmt.Errorf("%w; logging out: %w", err, logoutErr)
+		}
+	}()
 
 	record, err := p.getRecordToUpdate(ctx, client, session, ip)
 	if err != nil {
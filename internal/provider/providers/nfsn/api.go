@@ -0,0 +1,142 @@
+package nfsn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+// defaultTTL is the TTL in seconds of added records if no ttl is configured.
+const defaultTTL = 3600
+
+type apiRecord struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+	TTL  uint32 `json:"ttl"`
+}
+
+// See https://members.nearlyfreespeech.net/wiki/API/DNSListRRs
+func (p *Provider) listRecords(ctx context.Context, client *http.Client,
+	recordType string) (records []apiRecord, err error) {
+	values := url.Values{}
+	values.Set("name", utils.BuildRecordName(p.host))
+	values.Set("type", recordType)
+	err = p.doRequest(ctx, client, "listRRs", values, &records)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// See https://members.nearlyfreespeech.net/wiki/API/DNSRemoveRR
+func (p *Provider) removeRecord(ctx context.Context, client *http.Client,
+	record apiRecord) (err error) {
+	values := url.Values{}
+	values.Set("name", record.Name)
+	values.Set("type", record.Type)
+	values.Set("data", record.Data)
+	return p.doRequest(ctx, client, "removeRR", values, nil)
+}
+
+// See https://members.nearlyfreespeech.net/wiki/API/DNSAddRR
+func (p *Provider) addRecord(ctx context.Context, client *http.Client,
+	recordType string, ip netip.Addr) (err error) {
+	values := url.Values{}
+	values.Set("name", utils.BuildRecordName(p.host))
+	values.Set("type", recordType)
+	values.Set("data", ip.String())
+	values.Set("ttl", strconv.FormatUint(uint64(p.ttl), 10))
+	return p.doRequest(ctx, client, "addRR", values, nil)
+}
+
+// doRequest sends the values as a form to the DNS method of the domain,
+// and decodes the JSON response body into responseData if it is not nil.
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	method string, values url.Values, responseData any) (err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.nearlyfreespeech.net",
+		Path:   "/dns/" + p.domain + "/" + method,
+	}
+	body := []byte(values.Encode())
+
+	salt, err := makeSalt()
+	if err != nil {
+		return fmt.Errorf("making salt: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(),
+		bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/x-www-form-urlencoded")
+	headers.SetAccept(request, "application/json")
+	request.Header.Set("X-NFSN-Authentication",
+		makeAuthHeader(p.login, p.apiKey, u.Path, body, p.timeNow(), salt))
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return makeStatusError(response)
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
+
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Error string `json:"error"`
+		Debug string `json:"debug"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && data.Error != "" {
+		message = data.Error
+		if data.Debug != "" {
+			message += ": " + data.Debug
+		}
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, message)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
@@ -0,0 +1,139 @@
+package nfsn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	login      string
+	apiKey     string
+	timeNow    func() time.Time
+	ttl        uint32
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		Login  string `json:"login"`
+		APIKey string `json:"api_key"`
+		TTL    uint32 `json:"ttl"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding nfsn extra settings: %w", err)
+	}
+	if extraSettings.TTL == 0 {
+		extraSettings.TTL = defaultTTL
+	}
+	p = &Provider{
+		domain:     domain,
+		host:       host,
+		ipVersion:  ipVersion,
+		ipv6Suffix: ipv6Suffix,
+		login:      extraSettings.Login,
+		apiKey:     extraSettings.APIKey,
+		timeNow:    time.Now,
+		ttl:        extraSettings.TTL,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	switch {
+	case p.login == "":
+		return fmt.Errorf("%w", errors.ErrUsernameNotSet)
+	case p.apiKey == "":
+		return fmt.Errorf("%w", errors.ErrAPIKeyNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.NFSN, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://www.nearlyfreespeech.net/\">NearlyFreeSpeech.net</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://members.nearlyfreespeech.net/wiki/API/DNS
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	records, err := p.listRecords(ctx, client, recordType)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("listing records: %w", err)
+	}
+
+	if len(records) == 1 && records[0].Data == ip.String() {
+		return ip, nil
+	}
+
+	// The API has no update call, so existing records are
+	// removed and a new record is added instead.
+	for _, record := range records {
+		err = p.removeRecord(ctx, client, record)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("removing record: %w", err)
+		}
+	}
+
+	err = p.addRecord(ctx, client, recordType, ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("adding record: %w", err)
+	}
+
+	return ip, nil
+}
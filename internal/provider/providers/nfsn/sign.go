@@ -0,0 +1,49 @@
+package nfsn
+
+import (
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// makeAuthHeader returns the value of the X-NFSN-Authentication header
+// in the format login;timestamp;salt;hash where hash is the SHA1 hex
+// digest of login;timestamp;salt;apiKey;requestURI;bodyHash with
+// bodyHash the SHA1 hex digest of the request body.
+// See https://members.nearlyfreespeech.net/wiki/API/Introduction
+func makeAuthHeader(login, apiKey, requestURI string, body []byte,
+	now time.Time, salt string) string {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	bodyHash := sha1Hex(body)
+	hash := sha1Hex([]byte(strings.Join([]string{
+		login, timestamp, salt, apiKey, requestURI, bodyHash,
+	}, ";")))
+	return strings.Join([]string{login, timestamp, salt, hash}, ";")
+}
+
+func sha1Hex(data []byte) string {
+	sum := sha1.Sum(data) //nolint:gosec
+	return hex.EncodeToString(sum[:])
+}
+
+// makeSalt returns a random alphanumeric string of 16 characters.
+func makeSalt() (salt string, err error) {
+	const (
+		length   = 16
+		alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	)
+	b := make([]byte, length)
+	alphabetLength := big.NewInt(int64(len(alphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, alphabetLength)
+		if err != nil {
+			return "", err
+		}
+		b[i] = alphabet[n.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,66 @@
+package nfsn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_makeAuthHeader(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		requestURI string
+		body       []byte
+		header     string
+	}{
+		"empty_body": {
+			requestURI: "/site/example/getInfo",
+			header: "testuser;1700000000;0123456789abcdef;" +
+				"19cb853ad78c0d4054e7f79453daac584a5640ee",
+		},
+		"form_body": {
+			requestURI: "/dns/example.com/listRRs",
+			body:       []byte("name=www&type=A"),
+			header: "testuser;1700000000;0123456789abcdef;" +
+				"0d9f5bd936cf0ab2bc4e79b0b289f83dbb941ac5",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			const (
+				login  = "testuser"
+				apiKey = "p3kxmRKf9dk3l6ls"
+				salt   = "0123456789abcdef"
+			)
+			now := time.Unix(1700000000, 0)
+
+			header := makeAuthHeader(login, apiKey, testCase.requestURI,
+				testCase.body, now, salt)
+
+			assert.Equal(t, testCase.header, header)
+		})
+	}
+}
+
+func Test_sha1Hex(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "da39a3ee5e6b4b0d3255bfef95601890afd80709", sha1Hex(nil))
+	assert.Equal(t, "fd8c2cb6a9e279fb5d2ae720cd518b761f592fc0",
+		sha1Hex([]byte("name=www&type=A")))
+}
+
+func Test_makeSalt(t *testing.T) {
+	t.Parallel()
+
+	salt, err := makeSalt()
+
+	require.NoError(t, err)
+	assert.Regexp(t, "^[a-zA-Z0-9]{16}$", salt)
+}
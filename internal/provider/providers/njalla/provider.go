@@ -54,6 +54,9 @@ func New(data json.RawMessage, domain, host string,
 func (p *Provider) isValid() error {
 	if p.key == "" {
 		return fmt.Errorf("%w", errors.ErrKeyNotSet)
+	} else if p.host == "*" && p.useProviderIP {
+		// Njalla rejects wildcard updates using the request IP address
+		return fmt.Errorf("%w: with provider_ip enabled", errors.ErrHostWildcard)
 	}
 	return nil
 }
@@ -96,6 +99,22 @@ func (p *Provider) HTML() models.HTMLRow {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	if ip.Is6() {
+		_, newIP, err = p.update(ctx, client, netip.Addr{}, ip)
+	} else {
+		newIP, _, err = p.update(ctx, client, ip, netip.Addr{})
+	}
+	return newIP, err
+}
+
+// UpdateBoth updates the A and AAAA records in a single request.
+func (p *Provider) UpdateBoth(ctx context.Context, client *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
+	return p.update(ctx, client, ipv4, ipv6)
+}
+
+func (p *Provider) update(ctx context.Context, client *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
 	u := url.URL{
 		Scheme: "https",
 		Host:   "njal.la",
@@ -104,29 +123,33 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	values := url.Values{}
 	values.Set("h", utils.BuildURLQueryHostname(p.host, p.domain))
 	values.Set("k", p.key)
-	updatingIP6 := ip.Is6()
-	useProviderIP := p.useProviderIP && (ip.Is4() || !p.ipv6Suffix.IsValid())
+	// Njalla can only detect the IP address the request comes from,
+	// so both addresses are sent explicitly when updating both records.
+	dualStack := ipv4.IsValid() && ipv6.IsValid()
+	useProviderIP := p.useProviderIP && !dualStack &&
+		(ipv4.IsValid() || !p.ipv6Suffix.IsValid())
 	if useProviderIP {
 		values.Set("auto", "")
 	} else {
-		if updatingIP6 {
-			values.Set("aaaa", ip.String())
-		} else {
-			values.Set("a", ip.String())
+		if ipv4.IsValid() {
+			values.Set("a", ipv4.String())
+		}
+		if ipv6.IsValid() {
+			values.Set("aaaa", ipv6.String())
 		}
 	}
 	u.RawQuery = values.Encode()
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("creating http request: %w", err)
 	}
 	headers.SetUserAgent(request)
 	headers.SetAccept(request, "application/json")
 
 	response, err := client.Do(request)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("doing http request: %w", err)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("doing http request: %w", err)
 	}
 	defer response.Body.Close()
 
@@ -140,31 +163,68 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	}
 	err = decoder.Decode(&respBody)
 	if err != nil {
-		return netip.Addr{}, fmt.Errorf("json decoding response body: %w", err)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: json decoding response body: %w",
+			errors.ErrUnmarshalResponse, err)
 	}
 
 	switch response.StatusCode {
 	case http.StatusOK:
-		if respBody.Message != "record updated" {
-			return netip.Addr{}, fmt.Errorf("%w: message received: %s", errors.ErrUnknownResponse, respBody.Message)
+		unchanged := respBody.Message == messageUnchanged
+		if respBody.Message != messageUpdated && !unchanged {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: message received: %s",
+				errors.ErrUnknownResponse, respBody.Message)
+		}
+		noIPReceived := respBody.Value.A == "" && respBody.Value.AAAA == ""
+		if noIPReceived && (p.host == "*" || unchanged) {
+			// Njalla does not echo the IP addresses of wildcard records,
+			// and may not echo them if the record is unchanged.
+			return ipv4, ipv6, nil
 		}
-		ipString := respBody.Value.A
-		if updatingIP6 {
-			ipString = respBody.Value.AAAA
+		if ipv4.IsValid() {
+			newIPv4, err = parseReceivedIP(respBody.Value.A, ipv4, useProviderIP)
+			if err != nil {
+				return netip.Addr{}, netip.Addr{}, err
+			}
 		}
-		newIP, err = netip.ParseAddr(ipString)
-		if err != nil {
-			return netip.Addr{}, fmt.Errorf("%w: %w", errors.ErrIPReceivedMalformed, err)
-		} else if !useProviderIP && ip.Compare(newIP) != 0 {
-			return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
-				errors.ErrIPReceivedMismatch, ip, newIP)
+		if ipv6.IsValid() {
+			newIPv6, err = parseReceivedIP(respBody.Value.AAAA, ipv6, useProviderIP)
+			if err != nil {
+				return netip.Addr{}, netip.Addr{}, err
+			}
 		}
-		return newIP, nil
+		return newIPv4, newIPv6, nil
 	case http.StatusUnauthorized:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrAuth, respBody.Message)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrAuth, respBody.Message)
 	case http.StatusInternalServerError:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrBadRequest, respBody.Message)
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrBadRequest, respBody.Message)
 	}
 
-	return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid, response.StatusCode, respBody.Message)
+	return netip.Addr{}, netip.Addr{}, errors.HTTPStatus(response.StatusCode, respBody.Message)
+}
+
+// Messages of successful responses. The updated message is documented at
+// https://njal.la/docs/ddns/ and the unchanged message is the one returned
+// by Njalla when the record already has the IP addresses sent, similarly
+// to the nochg response of dyndns providers.
+const (
+	messageUpdated   = "record updated"
+	messageUnchanged = "record unchanged"
+)
+
+func parseReceivedIP(ipString string, sentIP netip.Addr, useProviderIP bool) (
+	newIP netip.Addr, err error) {
+	newIP, err = netip.ParseAddr(ipString)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("%w: %w", errors.ErrIPReceivedMalformed, err)
+	}
+	// Njalla may echo an IPv4 address as an IPv4-mapped IPv6 address,
+	// so both addresses are compared in their canonical form.
+	if sentIP.Is4() || sentIP.Is4In6() {
+		newIP = newIP.Unmap()
+	}
+	if !useProviderIP && sentIP.Unmap().Compare(newIP) != 0 {
+		return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
+			errors.ErrIPReceivedMismatch, sentIP, newIP)
+	}
+	return newIP, nil
 }
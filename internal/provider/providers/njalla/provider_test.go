@@ -0,0 +1,268 @@
+package njalla
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		data       string
+		host       string
+		errWrapped error
+		errMessage string
+	}{
+		"valid": {
+			data: `{"key":"key"}`,
+			host: "@",
+		},
+		"wildcard": {
+			data: `{"key":"key"}`,
+			host: "*",
+		},
+		"provider_ip": {
+			data: `{"key":"key","provider_ip":true}`,
+			host: "@",
+		},
+		"key_not_set": {
+			data:       `{}`,
+			host:       "@",
+			errWrapped: errors.ErrKeyNotSet,
+			errMessage: "key is not set",
+		},
+		"wildcard_with_provider_ip": {
+			data:       `{"key":"key","provider_ip":true}`,
+			host:       "*",
+			errWrapped: errors.ErrHostWildcard,
+			errMessage: `host cannot be a "*": with provider_ip enabled`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := New(json.RawMessage(testCase.data), "domain.com",
+				testCase.host, ipversion.IP4, netip.Prefix{})
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				assert.Nil(t, provider)
+			} else {
+				assert.NotNil(t, provider)
+			}
+		})
+	}
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func Test_Provider_Update(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		host         string
+		ip           netip.Addr
+		responseBody string
+		newIP        netip.Addr
+		errWrapped   error
+		errMessage   string
+	}{
+		"record_updated": {
+			host:         "@",
+			ip:           netip.MustParseAddr("1.2.3.4"),
+			responseBody: `{"message":"record updated","value":{"A":"1.2.3.4"}}`,
+			newIP:        netip.MustParseAddr("1.2.3.4"),
+		},
+		"record_updated_ipv4_mapped": {
+			host:         "@",
+			ip:           netip.MustParseAddr("1.2.3.4"),
+			responseBody: `{"message":"record updated","value":{"A":"::ffff:1.2.3.4"}}`,
+			newIP:        netip.MustParseAddr("1.2.3.4"),
+		},
+		"record_updated_mismatch": {
+			host:         "@",
+			ip:           netip.MustParseAddr("1.2.3.4"),
+			responseBody: `{"message":"record updated","value":{"A":"5.6.7.8"}}`,
+			errWrapped:   errors.ErrIPReceivedMismatch,
+			errMessage:   "mismatching IP address received: sent ip 1.2.3.4 to update but received 5.6.7.8",
+		},
+		"record_unchanged": {
+			host:         "@",
+			ip:           netip.MustParseAddr("1.2.3.4"),
+			responseBody: `{"message":"record unchanged","value":{}}`,
+			newIP:        netip.MustParseAddr("1.2.3.4"),
+		},
+		"record_unchanged_with_ip": {
+			host:         "@",
+			ip:           netip.MustParseAddr("1.2.3.4"),
+			responseBody: `{"message":"record unchanged","value":{"A":"1.2.3.4"}}`,
+			newIP:        netip.MustParseAddr("1.2.3.4"),
+		},
+		"unchanged_message_not_exact": {
+			host:         "@",
+			ip:           netip.MustParseAddr("1.2.3.4"),
+			responseBody: `{"message":"record not changed: invalid value","value":{}}`,
+			errWrapped:   errors.ErrUnknownResponse,
+			errMessage:   "unknown response received: message received: record not changed: invalid value",
+		},
+		"wildcard_record_updated": {
+			host:         "*",
+			ip:           netip.MustParseAddr("1.2.3.4"),
+			responseBody: `{"message":"record updated","value":{}}`,
+			newIP:        netip.MustParseAddr("1.2.3.4"),
+		},
+		"unknown_message": {
+			host:         "@",
+			ip:           netip.MustParseAddr("1.2.3.4"),
+			responseBody: `{"message":"something else","value":{}}`,
+			errWrapped:   errors.ErrUnknownResponse,
+			errMessage:   "unknown response received: message received: something else",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &http.Client{
+				Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, "njal.la", r.URL.Host)
+					assert.Equal(t, "/update", r.URL.Path)
+					assert.Equal(t, "key", r.URL.Query().Get("k"))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(testCase.responseBody)),
+					}, nil
+				}),
+			}
+
+			provider := &Provider{
+				domain:    "domain.com",
+				host:      testCase.host,
+				ipVersion: ipversion.IP4,
+				key:       "key",
+			}
+
+			newIP, err := provider.Update(context.Background(), client, testCase.ip)
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.newIP, newIP)
+		})
+	}
+}
+
+func Test_Provider_update_query(t *testing.T) {
+	t.Parallel()
+
+	ipv4 := netip.MustParseAddr("1.2.3.4")
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+
+	testCases := map[string]struct {
+		useProviderIP bool
+		ipv6Suffix    netip.Prefix
+		ipv4          netip.Addr
+		ipv6          netip.Addr
+		query         url.Values
+	}{
+		"ipv4": {
+			ipv4:  ipv4,
+			query: url.Values{"h": {"domain.com"}, "k": {"key"}, "a": {"1.2.3.4"}},
+		},
+		"ipv6": {
+			ipv6:  ipv6,
+			query: url.Values{"h": {"domain.com"}, "k": {"key"}, "aaaa": {"2001:db8::1"}},
+		},
+		"ipv4_provider_ip": {
+			useProviderIP: true,
+			ipv4:          ipv4,
+			query:         url.Values{"h": {"domain.com"}, "k": {"key"}, "auto": {""}},
+		},
+		"ipv6_provider_ip": {
+			useProviderIP: true,
+			ipv6:          ipv6,
+			query:         url.Values{"h": {"domain.com"}, "k": {"key"}, "auto": {""}},
+		},
+		"ipv6_provider_ip_with_suffix": {
+			useProviderIP: true,
+			ipv6Suffix:    netip.MustParsePrefix("::1/64"),
+			ipv6:          ipv6,
+			query:         url.Values{"h": {"domain.com"}, "k": {"key"}, "aaaa": {"2001:db8::1"}},
+		},
+		"dual_stack": {
+			ipv4:  ipv4,
+			ipv6:  ipv6,
+			query: url.Values{"h": {"domain.com"}, "k": {"key"}, "a": {"1.2.3.4"}, "aaaa": {"2001:db8::1"}},
+		},
+		"dual_stack_provider_ip": {
+			useProviderIP: true,
+			ipv4:          ipv4,
+			ipv6:          ipv6,
+			query:         url.Values{"h": {"domain.com"}, "k": {"key"}, "a": {"1.2.3.4"}, "aaaa": {"2001:db8::1"}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var received []string
+			if testCase.ipv4.IsValid() {
+				received = append(received, `"A":"`+testCase.ipv4.String()+`"`)
+			}
+			if testCase.ipv6.IsValid() {
+				received = append(received, `"AAAA":"`+testCase.ipv6.String()+`"`)
+			}
+			responseBody := `{"message":"record updated","value":{` + strings.Join(received, ",") + `}}`
+
+			client := &http.Client{
+				Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, testCase.query, r.URL.Query())
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(responseBody)),
+					}, nil
+				}),
+			}
+
+			provider := &Provider{
+				domain:        "domain.com",
+				host:          "@",
+				ipv6Suffix:    testCase.ipv6Suffix,
+				key:           "key",
+				useProviderIP: testCase.useProviderIP,
+			}
+
+			newIPv4, newIPv6, err := provider.update(context.Background(), client,
+				testCase.ipv4, testCase.ipv6)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ipv4, newIPv4)
+			assert.Equal(t, testCase.ipv6, newIPv6)
+		})
+	}
+}
@@ -27,6 +27,15 @@ func extractAPIError(response *http.Response) (err error) {
 
 	_ = response.Body.Close()
 
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s: %s: for query ID: %s",
+			errors.ErrAuth, response.Status, apiError.Message, queryID)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s: %s: for query ID: %s",
+			errors.ErrZoneNotFound, response.Status, apiError.Message, queryID)
+	}
+
 	return fmt.Errorf("%w: %s: %s: for query ID: %s",
 		errors.ErrHTTPStatusNotValid, response.Status, apiError.Message, queryID)
 }
@@ -33,7 +33,10 @@ type Provider struct {
 	appSecret     string
 	consumerKey   string
 	timeNow       func() time.Time
-	serverDelta   time.Duration
+	// serverDelta is the cached delta between this machine time and
+	// the OVH server time, and is only valid if serverDeltaSet is true.
+	serverDelta    time.Duration
+	serverDeltaSet bool
 }
 
 func New(data json.RawMessage, domain, host string,
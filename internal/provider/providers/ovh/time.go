@@ -25,7 +25,7 @@ func (p *Provider) getAdjustedUnixTimestamp(ctx context.Context, client *http.Cl
 // If it is the first time executing, it fetches the time from OVH servers to calculate the
 // delta. Otherwise, it uses the delta calculated previously.
 func (p *Provider) getTimeDelta(ctx context.Context, client *http.Client) (delta time.Duration, err error) {
-	if p.serverDelta > 0 {
+	if p.serverDeltaSet {
 		return p.serverDelta, nil
 	}
 
@@ -36,6 +36,7 @@ func (p *Provider) getTimeDelta(ctx context.Context, client *http.Client) (delta
 
 	now := p.timeNow()
 	p.serverDelta = now.Sub(ovhTime) // server delta should not change
+	p.serverDeltaSet = true
 	return p.serverDelta, nil
 }
 
@@ -16,7 +16,7 @@ func (p *Provider) getRecordIDs(ctx context.Context, client *http.Client, record
 	recordIDs []string, err error) {
 	u := url.URL{
 		Scheme: "https",
-		Host:   "porkbun.com",
+		Host:   "api.porkbun.com",
 		Path:   "/api/json/v3/dns/retrieveByNameType/" + p.domain + "/" + recordType + "/",
 	}
 	if p.host != "@" {
@@ -55,6 +55,7 @@ func (p *Provider) getRecordIDs(ctx context.Context, client *http.Client, record
 	}
 
 	var responseData struct {
+		Status  string `json:"status"`
 		Records []struct {
 			ID string `json:"id"`
 		} `json:"records"`
@@ -63,6 +64,8 @@ func (p *Provider) getRecordIDs(ctx context.Context, client *http.Client, record
 	err = decoder.Decode(&responseData)
 	if err != nil {
 		return nil, fmt.Errorf("json decoding response body: %w", err)
+	} else if responseData.Status != statusSuccess {
+		return nil, fmt.Errorf("%w: status %q", errors.ErrUnsuccessful, responseData.Status)
 	}
 
 	for _, record := range responseData.Records {
@@ -77,7 +80,7 @@ func (p *Provider) createRecord(ctx context.Context, client *http.Client,
 	recordType string, ipStr string) (err error) {
 	u := url.URL{
 		Scheme: "https",
-		Host:   "porkbun.com",
+		Host:   "api.porkbun.com",
 		Path:   "/api/json/v3/dns/create/" + p.domain,
 	}
 	postRecordsParams := struct {
@@ -118,31 +121,30 @@ func (p *Provider) createRecord(ctx context.Context, client *http.Client,
 		return fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
 			response.StatusCode, makeErrorMessage(response.Body))
 	}
-	return nil
+	return checkSuccess(response.Body)
 }
 
-// See https://porkbun.com/api/json/v3/documentation#DNS%20Edit%20Record%20by%20Domain%20and%20ID
-func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
-	recordType string, ipStr string, recordID string) (err error) {
+// See https://porkbun.com/api/json/v3/documentation#DNS%20Edit%20Record%20by%20Domain,%20Subdomain%20and%20Type
+func (p *Provider) updateRecords(ctx context.Context, client *http.Client,
+	recordType string, ipStr string) (err error) {
 	u := url.URL{
 		Scheme: "https",
-		Host:   "porkbun.com",
-		Path:   "/api/json/v3/dns/edit/" + p.domain + "/" + recordID,
+		Host:   "api.porkbun.com",
+		Path:   "/api/json/v3/dns/editByNameType/" + p.domain + "/" + recordType + "/",
+	}
+	if p.host != "@" {
+		u.Path += p.host
 	}
 	postRecordsParams := struct {
 		SecretAPIKey string `json:"secretapikey"`
 		APIKey       string `json:"apikey"`
 		Content      string `json:"content"`
-		Type         string `json:"type"`
 		TTL          string `json:"ttl"`
-		Name         string `json:"name,omitempty"`
 	}{
 		SecretAPIKey: p.secretAPIKey,
 		APIKey:       p.apiKey,
 		Content:      ipStr,
-		Type:         recordType,
 		TTL:          fmt.Sprint(p.ttl),
-		Name:         p.host,
 	}
 	buffer := bytes.NewBuffer(nil)
 	encoder := json.NewEncoder(buffer)
@@ -167,7 +169,7 @@ func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
 		return fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
 			response.StatusCode, makeErrorMessage(response.Body))
 	}
-	return nil
+	return checkSuccess(response.Body)
 }
 
 // See https://porkbun.com/api/json/v3/documentation#DNS%20Delete%20Records%20by%20Domain,%20Subdomain%20and%20Type
@@ -178,7 +180,7 @@ func (p *Provider) deleteAliasRecord(ctx context.Context, client *http.Client) (
 	}
 	u := url.URL{
 		Scheme: "https",
-		Host:   "porkbun.com",
+		Host:   "api.porkbun.com",
 		Path:   "/api/json/v3/dns/deleteByNameType/" + p.domain + "/ALIAS/" + subdomain,
 	}
 	postRecordsParams := struct {
@@ -211,5 +213,5 @@ func (p *Provider) deleteAliasRecord(ctx context.Context, client *http.Client) (
 		return fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
 			response.StatusCode, makeErrorMessage(response.Body))
 	}
-	return nil
+	return checkSuccess(response.Body)
 }
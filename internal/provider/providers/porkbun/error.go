@@ -5,9 +5,29 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
 	"github.com/qdm12/ddns-updater/internal/provider/utils"
 )
 
+const statusSuccess = "SUCCESS"
+
+func checkSuccess(body io.Reader) (err error) {
+	var data struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	decoder := json.NewDecoder(body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	if data.Status != statusSuccess {
+		return fmt.Errorf("%w: status %q: %s", errors.ErrUnsuccessful, data.Status, data.Message)
+	}
+	return nil
+}
+
 func makeErrorMessage(body io.Reader) (message string) {
 	bytes, err := io.ReadAll(body)
 	if err != nil {
@@ -37,6 +37,11 @@ func New(data json.RawMessage, domain, host string,
 	if err != nil {
 		return nil, err
 	}
+	if extraSettings.TTL == 0 {
+		// Porkbun's minimum TTL
+		const defaultTTL = 600
+		extraSettings.TTL = defaultTTL
+	}
 	p = &Provider{
 		domain:       domain,
 		host:         host,
@@ -132,11 +137,9 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		return ip, nil
 	}
 
-	for _, recordID := range recordIDs {
-		err = p.updateRecord(ctx, client, recordType, ipStr, recordID)
-		if err != nil {
-			return netip.Addr{}, fmt.Errorf("updating record: %w", err)
-		}
+	err = p.updateRecords(ctx, client, recordType, ipStr)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating records: %w", err)
 	}
 
 	return ip, nil
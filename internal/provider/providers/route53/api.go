@@ -0,0 +1,218 @@
+package route53
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+const (
+	apiHost    = "route53.amazonaws.com"
+	apiVersion = "2013-04-01"
+	xmlNS      = "https://route53.amazonaws.com/doc/2013-04-01/"
+)
+
+type changeResourceRecordSetsRequest struct {
+	XMLName     xml.Name    `xml:"ChangeResourceRecordSetsRequest"`
+	XMLNS       string      `xml:"xmlns,attr"`
+	ChangeBatch changeBatch `xml:"ChangeBatch"`
+}
+
+type changeBatch struct {
+	Changes []change `xml:"Changes>Change"`
+}
+
+type change struct {
+	Action            string            `xml:"Action"`
+	ResourceRecordSet resourceRecordSet `xml:"ResourceRecordSet"`
+}
+
+type resourceRecordSet struct {
+	Name            string   `xml:"Name"`
+	Type            string   `xml:"Type"`
+	TTL             uint32   `xml:"TTL"`
+	ResourceRecords []string `xml:"ResourceRecords>ResourceRecord>Value"`
+}
+
+type changeInfo struct {
+	ID     string `xml:"Id"`
+	Status string `xml:"Status"`
+}
+
+// See https://docs.aws.amazon.com/Route53/latest/APIReference/API_ChangeResourceRecordSets.html
+func (p *Provider) upsertRecord(ctx context.Context, client *http.Client,
+	ip netip.Addr) (changeID string, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	u := url.URL{
+		Scheme: "https",
+		Host:   apiHost,
+		Path:   "/" + apiVersion + "/hostedzone/" + strings.TrimPrefix(p.hostedZoneID, "/hostedzone/") + "/rrset",
+	}
+
+	requestData := changeResourceRecordSetsRequest{
+		XMLNS: xmlNS,
+		ChangeBatch: changeBatch{
+			Changes: []change{{
+				Action: "UPSERT",
+				ResourceRecordSet: resourceRecordSet{
+					Name:            utils.BuildURLQueryHostname(p.host, p.domain),
+					Type:            recordType,
+					TTL:             p.ttl,
+					ResourceRecords: []string{ip.String()},
+				},
+			}},
+		},
+	}
+	body, err := xml.Marshal(requestData)
+	if err != nil {
+		return "", fmt.Errorf("xml encoding request data: %w", err)
+	}
+	body = append([]byte(xml.Header), body...)
+
+	var responseData struct {
+		ChangeInfo changeInfo `xml:"ChangeInfo"`
+	}
+	err = p.doRequest(ctx, client, http.MethodPost, u, body, &responseData)
+	if err != nil {
+		return "", err
+	}
+
+	return responseData.ChangeInfo.ID, nil
+}
+
+// See https://docs.aws.amazon.com/Route53/latest/APIReference/API_GetChange.html
+func (p *Provider) getChangeStatus(ctx context.Context, client *http.Client,
+	changeID string) (status string, err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   apiHost,
+		Path:   "/" + apiVersion + "/change/" + strings.TrimPrefix(changeID, "/change/"),
+	}
+
+	var responseData struct {
+		ChangeInfo changeInfo `xml:"ChangeInfo"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, u, nil, &responseData)
+	if err != nil {
+		return "", err
+	}
+
+	return responseData.ChangeInfo.Status, nil
+}
+
+// waitForChange polls the change status until it is INSYNC or until
+// the maximum number of tries is reached, in which case it returns
+// without error since the change was accepted and is still propagating.
+func (p *Provider) waitForChange(ctx context.Context, client *http.Client,
+	changeID string) (err error) {
+	const (
+		maxTries     = 10
+		pollInterval = 2 * time.Second
+	)
+
+	for try := 0; try < maxTries; try++ {
+		status, err := p.getChangeStatus(ctx, client, changeID)
+		if err != nil {
+			return fmt.Errorf("getting change status: %w", err)
+		}
+
+		if status == "INSYNC" {
+			return nil
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) doRequest(ctx context.Context, client *http.Client, method string,
+	u url.URL, body []byte, responseData any) (err error) {
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	if body != nil {
+		headers.SetContentType(request, "application/xml")
+	}
+	sign(request, body, p.accessKeyID, p.secretAccessKey, p.timeNow())
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return extractAPIError(response)
+	}
+
+	decoder := xml.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("xml decoding response body: %w", err)
+	}
+
+	return nil
+}
+
+// See https://docs.aws.amazon.com/Route53/latest/APIReference/CommonErrors.html
+func extractAPIError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var errorResponse struct {
+		Error struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		} `xml:"Error"`
+	}
+	err = xml.Unmarshal(b, &errorResponse)
+	if err != nil {
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.ToSingleLine(string(b)))
+	}
+	code, message := errorResponse.Error.Code, errorResponse.Error.Message
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized,
+		response.StatusCode == http.StatusForbidden,
+		code == "InvalidClientTokenId", code == "SignatureDoesNotMatch",
+		code == "AccessDenied", code == "IncompleteSignature":
+		return fmt.Errorf("%w: %s: %s", errors.ErrAuth, code, message)
+	case response.StatusCode == http.StatusTooManyRequests,
+		code == "Throttling", code == "ThrottlingException", code == "PriorRequestNotComplete":
+		return fmt.Errorf("%w: %s: %s", errors.ErrRateLimited, code, message)
+	case code == "NoSuchHostedZone":
+		return fmt.Errorf("%w: %s", errors.ErrZoneNotFound, message)
+	default:
+		return fmt.Errorf("%w: %d: %s: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, code, message)
+	}
+}
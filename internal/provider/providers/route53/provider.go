@@ -0,0 +1,130 @@
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain          string
+	host            string
+	ipVersion       ipversion.IPVersion
+	ipv6Suffix      netip.Prefix
+	accessKeyID     string
+	secretAccessKey string
+	hostedZoneID    string
+	ttl             uint32
+	timeNow         func() time.Time
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		AccessKeyID     string `json:"access_key_id"`
+		SecretAccessKey string `json:"secret_access_key"`
+		HostedZoneID    string `json:"hosted_zone_id"`
+		TTL             uint32 `json:"ttl"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding route53 extra settings: %w", err)
+	}
+
+	const defaultTTL = 300
+	ttl := uint32(defaultTTL)
+	if extraSettings.TTL > 0 {
+		ttl = extraSettings.TTL
+	}
+
+	p = &Provider{
+		domain:          domain,
+		host:            host,
+		ipVersion:       ipVersion,
+		ipv6Suffix:      ipv6Suffix,
+		accessKeyID:     extraSettings.AccessKeyID,
+		secretAccessKey: extraSettings.SecretAccessKey,
+		hostedZoneID:    extraSettings.HostedZoneID,
+		ttl:             ttl,
+		timeNow:         time.Now,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	switch {
+	case p.accessKeyID == "":
+		return fmt.Errorf("%w", errors.ErrAccessKeyIDNotSet)
+	case p.secretAccessKey == "":
+		return fmt.Errorf("%w", errors.ErrAccessKeySecretNotSet)
+	case p.hostedZoneID == "":
+		return fmt.Errorf("%w", errors.ErrZoneIdentifierNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.Route53, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://aws.amazon.com/route53/\">Route53</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://docs.aws.amazon.com/Route53/latest/APIReference/API_ChangeResourceRecordSets.html
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	changeID, err := p.upsertRecord(ctx, client, ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("upserting record: %w", err)
+	}
+
+	err = p.waitForChange(ctx, client, changeID)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("waiting for change %s: %w", changeID, err)
+	}
+
+	return ip, nil
+}
@@ -0,0 +1,239 @@
+package scaleway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+// defaultTTL is the TTL in seconds of records set if no ttl is configured.
+const defaultTTL = 3600
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	secretKey  string
+	// dnsZone defaults to the domain if left empty.
+	dnsZone string
+	ttl     uint32
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		SecretKey string `json:"secret_key"`
+		DNSZone   string `json:"dns_zone"`
+		TTL       uint32 `json:"ttl"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding scaleway extra settings: %w", err)
+	}
+	if extraSettings.DNSZone == "" {
+		extraSettings.DNSZone = domain
+	}
+
+	if extraSettings.TTL == 0 {
+		extraSettings.TTL = defaultTTL
+	}
+	p = &Provider{
+		domain:     domain,
+		host:       host,
+		ipVersion:  ipVersion,
+		ipv6Suffix: ipv6Suffix,
+		secretKey:  extraSettings.SecretKey,
+		dnsZone:    extraSettings.DNSZone,
+		ttl:        extraSettings.TTL,
+	}
+	err = p.isValid()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) isValid() error {
+	if p.secretKey == "" {
+		return fmt.Errorf("%w", errors.ErrSecretNotSet)
+	}
+	return nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.Scaleway, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://www.scaleway.com/\">Scaleway</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://www.scaleway.com/en/developers/api/domains-and-dns/#path-records-update-records-within-a-dns-zone
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.scaleway.com",
+		Path:   "/domain/v2beta1/dns-zones/" + p.dnsZone + "/records",
+	}
+
+	type record struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+		Data string `json:"data"`
+		TTL  uint32 `json:"ttl"`
+	}
+	type idFields struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	type setChange struct {
+		IDFields idFields `json:"id_fields"`
+		Records  []record `json:"records"`
+	}
+	type change struct {
+		Set setChange `json:"set"`
+	}
+	requestData := struct {
+		Changes          []change `json:"changes"`
+		ReturnAllRecords bool     `json:"return_all_records"`
+	}{
+		Changes: []change{{
+			Set: setChange{
+				IDFields: idFields{
+					Name: utils.BuildRecordName(p.host),
+					Type: recordType,
+				},
+				Records: []record{{
+					Name: utils.BuildRecordName(p.host),
+					Type: recordType,
+					Data: ip.String(),
+					TTL:  p.ttl,
+				}},
+			},
+		}},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buffer)
+	err = encoder.Encode(requestData)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPatch, u.String(), buffer)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/json")
+	headers.SetAccept(request, "application/json")
+	request.Header.Set("X-Auth-Token", p.secretKey)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return netip.Addr{}, makeStatusError(response)
+	}
+
+	var responseData struct {
+		Records []record `json:"records"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&responseData)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	for _, record := range responseData.Records {
+		receivedIP, err := netip.ParseAddr(record.Data)
+		if err == nil && receivedIP.Compare(ip) == 0 {
+			return ip, nil
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("%w: ip %s not found in %d record(s) received",
+		errors.ErrIPReceivedMismatch, ip, len(responseData.Records))
+}
+
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Message string `json:"message"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && data.Message != "" {
+		message = data.Message
+	}
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized,
+		response.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case response.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrZoneNotFound, message)
+	case response.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", errors.ErrRateLimited, message)
+	case response.StatusCode >= http.StatusBadRequest &&
+		response.StatusCode < http.StatusInternalServerError:
+		return fmt.Errorf("%w: %d: %s", errors.ErrBadRequest, response.StatusCode, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/qdm12/ddns-updater/internal/provider/errors"
 	"github.com/qdm12/ddns-updater/internal/provider/headers"
 	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/ipextract"
 	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
 )
 
@@ -163,13 +164,36 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	switch {
 	case strings.HasPrefix(s, constants.Notfqdn):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
-	case strings.HasPrefix(s, "abuse"):
+	case strings.HasPrefix(s, constants.Abuse):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBannedAbuse)
 	case strings.HasPrefix(s, "badrequest"):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBadRequest)
-	case strings.HasPrefix(s, "good"), strings.HasPrefix(s, "nochg"):
-		return ip, nil
+	case strings.HasPrefix(s, constants.Badauth):
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
+	case strings.HasPrefix(s, constants.Nohost):
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
+	case strings.HasPrefix(s, "good"), strings.HasPrefix(s, constants.Nochg):
 	default:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, s)
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, utils.ToSingleLine(s))
+	}
+
+	// Selfhost.de may return the IP address of the record in the
+	// response body, in which case it should match the IP sent.
+	var ips []netip.Addr
+	if ip.Is4() {
+		ips = ipextract.IPv4(s)
+	} else {
+		ips = ipextract.IPv6(s)
+	}
+
+	if len(ips) == 0 {
+		return ip, nil
+	}
+
+	newIP = ips[0]
+	if !useProviderIP && ip.Compare(newIP) != 0 {
+		return netip.Addr{}, fmt.Errorf("%w: sent ip %s to update but received %s",
+			errors.ErrIPReceivedMismatch, ip, newIP)
 	}
+	return newIP, nil
 }
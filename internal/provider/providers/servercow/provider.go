@@ -39,9 +39,13 @@ func New(data json.RawMessage, domain, host string, ipVersion ipversion.IPVersio
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding servercow extra settings: %w", err)
 	}
 
+	if extraSettings.TTL == 0 {
+		const defaultTTL = 120
+		extraSettings.TTL = defaultTTL
+	}
 	p = &Provider{
 		domain:        domain,
 		host:          host,
@@ -72,7 +76,7 @@ func (p *Provider) isValid() error {
 }
 
 func (p *Provider) String() string {
-	return utils.ToString("servercow.de", p.host, constants.Servercow, p.ipVersion)
+	return utils.ToString(p.domain, p.host, constants.Servercow, p.ipVersion)
 }
 
 func (p *Provider) Domain() string {
@@ -119,11 +123,6 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		Path:   "/dns/v1/domains/" + p.domain,
 	}
 
-	updateHost := p.host
-	if updateHost == "@" {
-		updateHost = ""
-	}
-
 	requestData := struct {
 		Type    string `json:"type"`    // constants.A or constants.AAAA depending on ip address given
 		Name    string `json:"name"`    // DNS record name (only the subdomain part)
@@ -131,7 +130,7 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		TTL     uint   `json:"ttl"`
 	}{
 		Type:    recordType,
-		Name:    updateHost,
+		Name:    p.recordName(),
 		Content: ip.String(),
 		TTL:     p.ttl,
 	}
@@ -159,7 +158,12 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode > http.StatusUnsupportedMediaType {
+	switch {
+	case response.StatusCode == http.StatusUnauthorized,
+		response.StatusCode == http.StatusForbidden:
+		return netip.Addr{}, fmt.Errorf("%w: %s",
+			errors.ErrAuth, utils.BodyToSingleLine(response.Body))
+	case response.StatusCode > http.StatusUnsupportedMediaType:
 		return netip.Addr{}, fmt.Errorf("%w: %d: %s",
 			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.BodyToSingleLine(response.Body))
 	}
@@ -182,3 +186,19 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 
 	return ip, nil
 }
+
+// recordName returns the record name relative to the zone as expected
+// by the Servercow API, which is the empty string for the zone apex.
+// A host given as a fully qualified name within the zone is converted
+// to its relative subdomain.
+func (p *Provider) recordName() string {
+	name := strings.TrimSuffix(p.host, ".")
+	switch {
+	case name == "@", name == p.domain:
+		return ""
+	case strings.HasSuffix(name, "."+p.domain):
+		return strings.TrimSuffix(name, "."+p.domain)
+	default:
+		return name
+	}
+}
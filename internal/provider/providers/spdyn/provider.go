@@ -35,12 +35,16 @@ func New(data json.RawMessage, domain, host string,
 	extraSettings := struct {
 		User          string `json:"user"`
 		Password      string `json:"password"`
+		Pass          string `json:"pass"`
 		Token         string `json:"token"`
 		UseProviderIP bool   `json:"provider_ip"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding spdyn extra settings: %w", err)
+	}
+	if extraSettings.Password == "" {
+		extraSettings.Password = extraSettings.Pass
 	}
 	p = &Provider{
 		domain:        domain,
@@ -152,28 +156,38 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	if err != nil {
 		return netip.Addr{}, fmt.Errorf("reading response body: %w", err)
 	}
-	bodyString := string(b)
+	bodyString := strings.TrimSpace(string(b))
 
 	if response.StatusCode != http.StatusOK {
 		return netip.Addr{}, fmt.Errorf("%w: %d: %s",
 			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.ToSingleLine(bodyString))
 	}
 
+	// see https://wiki.securepoint.de/SPDyn/Rueckgabecodes
 	switch {
-	case isAny(bodyString, constants.Abuse, "numhost"):
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBannedAbuse)
-	case isAny(bodyString, constants.Badauth, "!yours"):
+	case bodyString == constants.Abuse:
+		return netip.Addr{}, fmt.Errorf("%w: too many update requests were sent "+
+			"without the IP address changing, the host is temporarily blocked", errors.ErrBannedAbuse)
+	case bodyString == "numhost":
+		return netip.Addr{}, fmt.Errorf("%w: too many hosts updated in one request", errors.ErrBadRequest)
+	case bodyString == "!donator":
+		return netip.Addr{}, fmt.Errorf("%w: requires a donator account", errors.ErrFeatureUnavailable)
+	case bodyString == constants.Badauth:
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
+	case bodyString == "!yours":
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrRecordNotOwned)
 	case strings.HasPrefix(bodyString, "good"):
 		return ip, nil
 	case bodyString == constants.Notfqdn:
 		return netip.Addr{}, fmt.Errorf("%w: not fqdn", errors.ErrBadRequest)
-	case strings.HasPrefix(bodyString, "nochg"):
+	case strings.HasPrefix(bodyString, constants.Nochg):
 		return ip, nil
-	case isAny(bodyString, "nohost", "fatal"):
+	case isAny(bodyString, constants.Nohost, "fatal"):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
+	case bodyString == constants.Nineoneone:
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrDNSServerSide)
 	default:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, bodyString)
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, utils.ToSingleLine(bodyString))
 	}
 }
 
@@ -133,22 +133,33 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 	}
 	str := string(b)
 
-	if response.StatusCode != http.StatusOK {
-		return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid, response.StatusCode, str)
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrAuth, utils.ToSingleLine(str))
+	default:
+		return netip.Addr{}, fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
+			response.StatusCode, utils.ToSingleLine(str))
 	}
 
 	switch {
 	case strings.HasPrefix(str, constants.Notfqdn):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
+	case strings.HasPrefix(str, constants.Nohost):
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrRecordNotOwned)
 	case strings.HasPrefix(str, constants.Abuse):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBannedAbuse)
+	case strings.HasPrefix(str, constants.Badagent):
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBannedUserAgent)
 	case strings.HasPrefix(str, "badrequest"):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBadRequest)
-	case strings.HasPrefix(str, "constants.Badauth"):
+	case strings.HasPrefix(str, constants.Badauth):
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrAuth)
-	case strings.HasPrefix(str, "good"), strings.HasPrefix(str, "nochg"):
+	case strings.HasPrefix(str, constants.Nineoneone):
+		return netip.Addr{}, fmt.Errorf("%w", errors.ErrDNSServerSide)
+	case strings.HasPrefix(str, "good"), strings.HasPrefix(str, constants.Nochg):
 		return ip, nil
 	default:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, str)
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, utils.ToSingleLine(str))
 	}
 }
@@ -0,0 +1,151 @@
+package transip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+const (
+	apiURL = "https://api.transip.nl/v6"
+	// defaultExpire is the TTL in seconds of created DNS entries if no ttl is configured.
+	defaultExpire = 300
+)
+
+type dnsEntry struct {
+	Name    string `json:"name"`
+	Expire  uint32 `json:"expire"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// See https://api.transip.nl/rest/docs.html#domains-dns-get
+func (p *Provider) getEntry(ctx context.Context, client *http.Client,
+	token, recordType string) (entry dnsEntry, err error) {
+	var data struct {
+		DNSEntries []dnsEntry `json:"dnsEntries"`
+	}
+	err = p.doRequest(ctx, client, token, http.MethodGet, nil, http.StatusOK, &data)
+	if err != nil {
+		return dnsEntry{}, err
+	}
+
+	for _, entry := range data.DNSEntries {
+		if entry.Name == p.host && entry.Type == recordType {
+			return entry, nil
+		}
+	}
+	return dnsEntry{}, fmt.Errorf("%w: in %d dns entries",
+		errors.ErrRecordNotFound, len(data.DNSEntries))
+}
+
+// See https://api.transip.nl/rest/docs.html#domains-dns-post
+func (p *Provider) createEntry(ctx context.Context, client *http.Client,
+	token string, entry dnsEntry) (err error) {
+	requestData := struct {
+		DNSEntry dnsEntry `json:"dnsEntry"`
+	}{DNSEntry: entry}
+	return p.doRequest(ctx, client, token, http.MethodPost, requestData, http.StatusCreated, nil)
+}
+
+// updateEntry updates the content of the DNS entry matching the name,
+// expire and type of the entry given.
+// See https://api.transip.nl/rest/docs.html#domains-dns-patch
+func (p *Provider) updateEntry(ctx context.Context, client *http.Client,
+	token string, entry dnsEntry) (err error) {
+	requestData := struct {
+		DNSEntry dnsEntry `json:"dnsEntry"`
+	}{DNSEntry: entry}
+	return p.doRequest(ctx, client, token, http.MethodPatch, requestData, http.StatusNoContent, nil)
+}
+
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	token, method string, requestData any, expectedStatus int,
+	responseData any) (err error) {
+	url := apiURL + "/domains/" + p.domain + "/dns"
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	headers.SetAuthBearer(request, token)
+	if requestData != nil {
+		headers.SetContentType(request, "application/json")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != expectedStatus {
+		if response.StatusCode == http.StatusUnauthorized {
+			// Discard the cached token so a new one is requested
+			// on the next update.
+			p.token = ""
+		}
+		return makeStatusError(response)
+	}
+
+	if responseData == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
+
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Error string `json:"error"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && data.Error != "" {
+		message = data.Error
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, message)
+	case http.StatusBadRequest, http.StatusNotAcceptable:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", errors.ErrRateLimited, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
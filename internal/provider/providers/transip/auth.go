@@ -0,0 +1,158 @@
+package transip
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+)
+
+const (
+	// tokenLifetime is the lifetime requested for access tokens.
+	tokenLifetime = 30 * time.Minute
+	// tokenRenewMargin is the duration before the token expiry
+	// from which a new token is requested.
+	tokenRenewMargin = time.Minute
+)
+
+func parsePrivateKey(s string) (privateKey *rsa.PrivateKey, err error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w", errors.ErrKeyNotSet)
+	}
+
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", errors.ErrKeyNotValid)
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errors.ErrKeyNotValid, err)
+		}
+		return privateKey, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errors.ErrKeyNotValid, err)
+		}
+		privateKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: key is not an RSA key", errors.ErrKeyNotValid)
+		}
+		return privateKey, nil
+	default:
+		return nil, fmt.Errorf("%w: PEM block type %q is not supported",
+			errors.ErrKeyNotValid, block.Type)
+	}
+}
+
+// getToken returns the cached access token if it is not near its expiry,
+// and otherwise requests a new access token and caches it.
+func (p *Provider) getToken(ctx context.Context, client *http.Client) (
+	token string, err error) {
+	now := p.timeNow()
+	if p.token != "" && now.Before(p.tokenExpiry.Add(-tokenRenewMargin)) {
+		return p.token, nil
+	}
+
+	token, err = p.requestToken(ctx, client)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = token
+	p.tokenExpiry = now.Add(tokenLifetime)
+	return token, nil
+}
+
+// See https://api.transip.nl/rest/docs.html#header-authentication
+func (p *Provider) requestToken(ctx context.Context, client *http.Client) (
+	token string, err error) {
+	const nonceLength = 16
+	nonce := make([]byte, nonceLength)
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+
+	requestData := struct {
+		Login          string `json:"login"`
+		Nonce          string `json:"nonce"`
+		ReadOnly       bool   `json:"read_only"`
+		ExpirationTime string `json:"expiration_time"`
+		GlobalKey      bool   `json:"global_key"`
+	}{
+		Login:          p.login,
+		Nonce:          hex.EncodeToString(nonce),
+		ExpirationTime: fmt.Sprintf("%d minutes", int(tokenLifetime.Minutes())),
+		GlobalKey:      true,
+	}
+	body, err := json.Marshal(requestData)
+	if err != nil {
+		return "", fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	signature, err := signBody(body, p.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("signing request body: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		apiURL+"/auth", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetContentType(request, "application/json")
+	headers.SetAccept(request, "application/json")
+	request.Header.Set("Signature", signature)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return "", makeStatusError(response)
+	}
+
+	var data struct {
+		Token string `json:"token"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return "", fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	if data.Token == "" {
+		return "", fmt.Errorf("%w: no token", errors.ErrReceivedNoResult)
+	}
+	return data.Token, nil
+}
+
+// signBody returns the base64 encoded RSA SHA512 signature of the body.
+func signBody(body []byte, privateKey *rsa.PrivateKey) (signature string, err error) {
+	digest := sha512.Sum512(body)
+	signed, err := rsa.SignPKCS1v15(nil, privateKey, crypto.SHA512, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signed), nil
+}
@@ -0,0 +1,150 @@
+package transip
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Provider struct {
+	domain     string
+	host       string
+	ipVersion  ipversion.IPVersion
+	ipv6Suffix netip.Prefix
+	login      string
+	privateKey *rsa.PrivateKey
+	timeNow    func() time.Time
+	// token is the cached access token, valid until tokenExpiry.
+	token       string
+	tokenExpiry time.Time
+	ttl         uint32
+}
+
+func New(data json.RawMessage, domain, host string,
+	ipVersion ipversion.IPVersion, ipv6Suffix netip.Prefix) (
+	p *Provider, err error) {
+	extraSettings := struct {
+		Login      string `json:"login"`
+		PrivateKey string `json:"private_key"`
+		TTL        uint32 `json:"ttl"`
+	}{}
+	err = json.Unmarshal(data, &extraSettings)
+	if err != nil {
+		return nil, fmt.Errorf("decoding transip extra settings: %w", err)
+	}
+
+	if extraSettings.Login == "" {
+		return nil, fmt.Errorf("%w", errors.ErrUsernameNotSet)
+	}
+
+	privateKey, err := parsePrivateKey(extraSettings.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	p = &Provider{
+		domain:     domain,
+		host:       host,
+		ipVersion:  ipVersion,
+		ipv6Suffix: ipv6Suffix,
+		login:      extraSettings.Login,
+		privateKey: privateKey,
+		timeNow:    time.Now,
+		ttl:        extraSettings.TTL,
+	}
+	return p, nil
+}
+
+func (p *Provider) String() string {
+	return utils.ToString(p.domain, p.host, constants.TransIP, p.ipVersion)
+}
+
+func (p *Provider) Domain() string {
+	return p.domain
+}
+
+func (p *Provider) Host() string {
+	return p.host
+}
+
+func (p *Provider) IPVersion() ipversion.IPVersion {
+	return p.ipVersion
+}
+
+func (p *Provider) IPv6Suffix() netip.Prefix {
+	return p.ipv6Suffix
+}
+
+func (p *Provider) Proxied() bool {
+	return false
+}
+
+func (p *Provider) BuildDomainName() string {
+	return utils.BuildDomainName(p.host, p.domain)
+}
+
+func (p *Provider) HTML() models.HTMLRow {
+	return models.HTMLRow{
+		Domain:    fmt.Sprintf("<a href=\"http://%s\">%s</a>", p.BuildDomainName(), p.BuildDomainName()),
+		Host:      p.Host(),
+		Provider:  "<a href=\"https://www.transip.nl/\">TransIP</a>",
+		IPVersion: p.ipVersion.String(),
+	}
+}
+
+// See https://api.transip.nl/rest/docs.html
+func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	token, err := p.getToken(ctx, client)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting token: %w", err)
+	}
+
+	entry, err := p.getEntry(ctx, client, token, recordType)
+	if stderrors.Is(err, errors.ErrRecordNotFound) {
+		expire := uint32(defaultExpire)
+		if p.ttl != 0 {
+			expire = p.ttl
+		}
+		entry = dnsEntry{
+			Name:    p.host,
+			Expire:  expire,
+			Type:    recordType,
+			Content: ip.String(),
+		}
+		err = p.createEntry(ctx, client, token, entry)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("creating dns entry: %w", err)
+		}
+		return ip, nil
+	} else if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting dns entry: %w", err)
+	}
+
+	if entry.Content == ip.String() {
+		return ip, nil
+	}
+
+	entry.Content = ip.String()
+	err = p.updateEntry(ctx, client, token, entry)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating dns entry: %w", err)
+	}
+
+	return ip, nil
+}
@@ -0,0 +1,256 @@
+package variomedia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecordAttributes struct {
+	RecordType string `json:"record_type,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Domain     string `json:"domain,omitempty"`
+	Data       string `json:"data"`
+}
+
+type apiRecord struct {
+	Type       string              `json:"type"`
+	ID         string              `json:"id"`
+	Attributes apiRecordAttributes `json:"attributes"`
+}
+
+type apiJob struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		Status string `json:"status"`
+	} `json:"attributes"`
+}
+
+// See https://api.variomedia.de/docs/dns-records.html
+func (p *Provider) updateWithAPI(ctx context.Context, client *http.Client,
+	ip netip.Addr) (newIP netip.Addr, err error) {
+	recordType := constants.A
+	if ip.Is6() {
+		recordType = constants.AAAA
+	}
+
+	record, err := p.getAPIRecord(ctx, client, recordType)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("getting record: %w", err)
+	}
+
+	if record.Attributes.Data == ip.String() {
+		return ip, nil
+	}
+
+	job, err := p.patchAPIRecord(ctx, client, record.ID, ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("updating record: %w", err)
+	}
+
+	err = p.waitForJob(ctx, client, job)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("waiting for update job: %w", err)
+	}
+
+	return ip, nil
+}
+
+func (p *Provider) getAPIRecord(ctx context.Context, client *http.Client,
+	recordType string) (record apiRecord, err error) {
+	recordName := utils.BuildRecordName(p.host)
+	values := url.Values{}
+	values.Set("filter[domain]", p.domain)
+	values.Set("filter[name]", recordName)
+	var data struct {
+		Data []apiRecord `json:"data"`
+	}
+	err = p.doAPIRequest(ctx, client, http.MethodGet, "/dns-records", values, nil, &data)
+	if err != nil {
+		return record, err
+	}
+
+	for _, record := range data.Data {
+		if record.Attributes.Name == recordName &&
+			record.Attributes.RecordType == recordType {
+			return record, nil
+		}
+	}
+	return record, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(data.Data))
+}
+
+// patchAPIRecord updates the data of the record and returns the job
+// resource if the update is queued, or a done job otherwise.
+func (p *Provider) patchAPIRecord(ctx context.Context, client *http.Client,
+	recordID string, ip netip.Addr) (job apiJob, err error) {
+	requestData := struct {
+		Data apiRecord `json:"data"`
+	}{
+		Data: apiRecord{
+			Type:       "dns-record",
+			ID:         recordID,
+			Attributes: apiRecordAttributes{Data: ip.String()},
+		},
+	}
+	var responseData struct {
+		Data apiJob `json:"data"`
+	}
+	err = p.doAPIRequest(ctx, client, http.MethodPatch, "/dns-records/"+recordID,
+		nil, requestData, &responseData)
+	if err != nil {
+		return job, err
+	}
+
+	if responseData.Data.Type != "queue-job" {
+		responseData.Data.Attributes.Status = "done"
+	}
+	return responseData.Data, nil
+}
+
+// waitForJob polls the queue job until it is done, and returns an error
+// if it fails or if it is still not done after the maximum number of tries.
+func (p *Provider) waitForJob(ctx context.Context, client *http.Client,
+	job apiJob) (err error) {
+	const (
+		maxTries     = 10
+		pollInterval = 2 * time.Second
+	)
+
+	for try := 0; ; try++ {
+		switch job.Attributes.Status {
+		case "done":
+			return nil
+		case "failed":
+			return fmt.Errorf("%w: job %s failed", errors.ErrUnsuccessful, job.ID)
+		}
+
+		if try == maxTries {
+			return fmt.Errorf("%w: job %s still %s after %d tries",
+				errors.ErrUnsuccessful, job.ID, job.Attributes.Status, maxTries)
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		var data struct {
+			Data apiJob `json:"data"`
+		}
+		err = p.doAPIRequest(ctx, client, http.MethodGet, "/queue-jobs/"+job.ID,
+			nil, nil, &data)
+		if err != nil {
+			return fmt.Errorf("getting job status: %w", err)
+		}
+		job = data.Data
+	}
+}
+
+func (p *Provider) doAPIRequest(ctx context.Context, client *http.Client,
+	method, path string, values url.Values, requestData, responseData any) (err error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.variomedia.de",
+		Path:     path,
+		RawQuery: values.Encode(),
+	}
+
+	var body io.Reader
+	if requestData != nil {
+		buffer := bytes.NewBuffer(nil)
+		encoder := json.NewEncoder(buffer)
+		err = encoder.Encode(requestData)
+		if err != nil {
+			return fmt.Errorf("json encoding request data: %w", err)
+		}
+		body = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/vnd.variomedia.v1+json")
+	if requestData != nil {
+		headers.SetContentType(request, "application/vnd.api+json")
+	}
+	// The API uses the "token" authorization scheme instead of "Bearer".
+	request.Header.Set("Authorization", "token "+p.apiToken)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+	default:
+		return makeAPIStatusError(response)
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
+
+func makeAPIStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Errors []struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && len(data.Errors) > 0 {
+		messages := make([]string, len(data.Errors))
+		for i, apiError := range data.Errors {
+			messages[i] = apiError.Title
+			if apiError.Detail != "" {
+				messages[i] += ": " + apiError.Detail
+			}
+		}
+		message = strings.Join(messages, ", ")
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrRecordNotFound, message)
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", errors.ErrRateLimited, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
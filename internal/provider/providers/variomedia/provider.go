@@ -4,16 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/netip"
-	"net/url"
-	"strings"
 
 	"github.com/qdm12/ddns-updater/internal/models"
 	"github.com/qdm12/ddns-updater/internal/provider/constants"
 	"github.com/qdm12/ddns-updater/internal/provider/errors"
-	"github.com/qdm12/ddns-updater/internal/provider/headers"
 	"github.com/qdm12/ddns-updater/internal/provider/utils"
 	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
 )
@@ -26,6 +22,9 @@ type Provider struct {
 	email         string
 	password      string
 	useProviderIP bool
+	// apiToken is used to update the record through the API
+	// instead of the dyndns protocol if set.
+	apiToken string
 }
 
 func New(data json.RawMessage, domain, host string,
@@ -35,10 +34,11 @@ func New(data json.RawMessage, domain, host string,
 		Email         string `json:"email"`
 		Password      string `json:"password"`
 		UseProviderIP bool   `json:"provider_ip"`
+		APIToken      string `json:"api_token"`
 	}{}
 	err = json.Unmarshal(data, &extraSettings)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("decoding variomedia extra settings: %w", err)
 	}
 	p = &Provider{
 		domain:        domain,
@@ -48,6 +48,7 @@ func New(data json.RawMessage, domain, host string,
 		email:         extraSettings.Email,
 		password:      extraSettings.Password,
 		useProviderIP: extraSettings.UseProviderIP,
+		apiToken:      extraSettings.APIToken,
 	}
 	err = p.isValid()
 	if err != nil {
@@ -58,8 +59,10 @@ func New(data json.RawMessage, domain, host string,
 
 func (p *Provider) isValid() error {
 	switch {
+	case p.apiToken != "":
+		return nil
 	case p.email == "":
-		return fmt.Errorf("%w", errors.ErrEmailNotSet)
+		return fmt.Errorf("%w: and API token not set", errors.ErrEmailNotSet)
 	case p.password == "":
 		return fmt.Errorf("%w", errors.ErrPasswordNotSet)
 	case p.host == "*":
@@ -106,60 +109,8 @@ func (p *Provider) HTML() models.HTMLRow {
 }
 
 func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Addr) (newIP netip.Addr, err error) {
-	host := "dyndns.variomedia.de"
-	useProviderIP := p.useProviderIP && (ip.Is4() || !p.ipv6Suffix.IsValid())
-	if useProviderIP {
-		if ip.Is6() {
-			host = "dyndns6.variomedia.de"
-		} else {
-			host = "dyndns4.variomedia.de"
-		}
-	}
-
-	u := url.URL{
-		Scheme: "https",
-		User:   url.UserPassword(p.email, p.password),
-		Host:   host,
-		Path:   "/nic/update",
-	}
-	values := url.Values{}
-	values.Set("hostname", utils.BuildURLQueryHostname(p.host, p.domain))
-	if !p.useProviderIP {
-		values.Set("myip", ip.String())
-	}
-	u.RawQuery = values.Encode()
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("creating http request: %w", err)
-	}
-	headers.SetUserAgent(request)
-
-	response, err := client.Do(request)
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("doing http request: %w", err)
-	}
-	defer response.Body.Close()
-
-	b, err := io.ReadAll(response.Body)
-	if err != nil {
-		return netip.Addr{}, fmt.Errorf("reading response body: %w", err)
-	}
-	s := string(b)
-
-	if response.StatusCode != http.StatusOK {
-		return netip.Addr{}, fmt.Errorf("%w: %d: %s",
-			errors.ErrHTTPStatusNotValid, response.StatusCode, utils.ToSingleLine(s))
-	}
-
-	switch {
-	case strings.HasPrefix(s, constants.Notfqdn):
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrHostnameNotExists)
-	case strings.HasPrefix(s, "badrequest"):
-		return netip.Addr{}, fmt.Errorf("%w", errors.ErrBadRequest)
-	case strings.HasPrefix(s, "good"):
-		return ip, nil
-	default:
-		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, s)
+	if p.apiToken != "" {
+		return p.updateWithAPI(ctx, client, ip)
 	}
+	return p.updateWithDynDNS(ctx, client, ip)
 }
@@ -0,0 +1,219 @@
+package vultr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecord struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+func (p *Provider) setHeaders(request *http.Request) {
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	headers.SetAuthBearer(request, p.apiKey)
+}
+
+// getRecord pages through all the records of the domain and returns
+// the first record matching the host and the record type given.
+// See https://www.vultr.com/api/#tag/dns/operation/list-dns-domain-records
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	recordType string) (record apiRecord, err error) {
+	recordName := utils.BuildRecordName(p.host)
+	cursor := ""
+	for {
+		var records []apiRecord
+		records, cursor, err = p.listRecords(ctx, client, cursor)
+		if err != nil {
+			return apiRecord{}, err
+		}
+
+		for _, record := range records {
+			if record.Type == recordType && record.Name == recordName {
+				return record, nil
+			}
+		}
+
+		if cursor == "" {
+			return apiRecord{}, fmt.Errorf("%w", errors.ErrRecordNotFound)
+		}
+	}
+}
+
+func (p *Provider) listRecords(ctx context.Context, client *http.Client,
+	cursor string) (records []apiRecord, nextCursor string, err error) {
+	const perPage = "500" // maximum allowed by the API
+	values := url.Values{}
+	values.Set("per_page", perPage)
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "api.vultr.com",
+		Path:     "/v2/domains/" + p.domain + "/records",
+		RawQuery: values.Encode(),
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating http request: %w", err)
+	}
+	p.setHeaders(request)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", makeStatusError(response)
+	}
+
+	var data struct {
+		Records []apiRecord `json:"records"`
+		Meta    struct {
+			Links struct {
+				Next string `json:"next"`
+			} `json:"links"`
+		} `json:"meta"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&data)
+	if err != nil {
+		return nil, "", fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	return data.Records, data.Meta.Links.Next, nil
+}
+
+// See https://www.vultr.com/api/#tag/dns/operation/create-dns-domain-record
+func (p *Provider) createRecord(ctx context.Context, client *http.Client,
+	recordType string, ip netip.Addr) (err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.vultr.com",
+		Path:   "/v2/domains/" + p.domain + "/records",
+	}
+
+	requestData := struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+		Data string `json:"data"`
+	}{
+		Type: recordType,
+		Name: utils.BuildRecordName(p.host),
+		Data: ip.String(),
+	}
+	buffer := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buffer)
+	err = encoder.Encode(requestData)
+	if err != nil {
+		return fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), buffer)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	p.setHeaders(request)
+	headers.SetContentType(request, "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		return makeStatusError(response)
+	}
+
+	return nil
+}
+
+// See https://www.vultr.com/api/#tag/dns/operation/update-dns-domain-record
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	recordID string, ip netip.Addr) (err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "api.vultr.com",
+		Path:   "/v2/domains/" + p.domain + "/records/" + recordID,
+	}
+
+	requestData := struct {
+		Data string `json:"data"`
+	}{
+		Data: ip.String(),
+	}
+	buffer := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buffer)
+	err = encoder.Encode(requestData)
+	if err != nil {
+		return fmt.Errorf("json encoding request data: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPatch, u.String(), buffer)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	p.setHeaders(request)
+	headers.SetContentType(request, "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return makeStatusError(response)
+	}
+
+	return nil
+}
+
+func makeStatusError(response *http.Response) (err error) {
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %d: reading response body: %w",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, err)
+	}
+
+	var data struct {
+		Error string `json:"error"`
+	}
+	message := utils.ToSingleLine(string(b))
+	if json.Unmarshal(b, &data) == nil && data.Error != "" {
+		message = data.Error
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errors.ErrAuth, message)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, message)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", errors.ErrRateLimited, message)
+	default:
+		return fmt.Errorf("%w: %d: %s",
+			errors.ErrHTTPStatusNotValid, response.StatusCode, message)
+	}
+}
@@ -0,0 +1,103 @@
+package vultr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func Test_Provider_getRecord(t *testing.T) {
+	t.Parallel()
+
+	// pages maps the cursor query parameter to the response body.
+	pages := map[string]string{
+		"": `{"records":[
+			{"id":"1","type":"A","name":"other","data":"1.2.3.4"},
+			{"id":"2","type":"AAAA","name":"home","data":"::1"}
+		],"meta":{"links":{"next":"page2","prev":""}}}`,
+		"page2": `{"records":[
+			{"id":"3","type":"A","name":"home","data":"5.6.7.8"}
+		],"meta":{"links":{"next":"","prev":"page1"}}}`,
+	}
+
+	testCases := map[string]struct {
+		host       string
+		recordType string
+		record     apiRecord
+		cursors    []string
+		errWrapped error
+		errMessage string
+	}{
+		"record_on_first_page": {
+			host:       "home",
+			recordType: "AAAA",
+			record:     apiRecord{ID: "2", Type: "AAAA", Name: "home", Data: "::1"},
+			cursors:    []string{""},
+		},
+		"record_on_second_page": {
+			host:       "home",
+			recordType: "A",
+			record:     apiRecord{ID: "3", Type: "A", Name: "home", Data: "5.6.7.8"},
+			cursors:    []string{"", "page2"},
+		},
+		"record_not_found": {
+			host:       "missing",
+			recordType: "A",
+			cursors:    []string{"", "page2"},
+			errWrapped: errors.ErrRecordNotFound,
+			errMessage: "record not found",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var cursors []string
+			client := &http.Client{
+				Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, "api.vultr.com", r.URL.Host)
+					assert.Equal(t, "/v2/domains/example.com/records", r.URL.Path)
+					assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+					assert.Equal(t, "500", r.URL.Query().Get("per_page"))
+					cursor := r.URL.Query().Get("cursor")
+					cursors = append(cursors, cursor)
+					body, ok := pages[cursor]
+					require.True(t, ok, "unexpected cursor %q", cursor)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(body)),
+					}, nil
+				}),
+			}
+
+			provider := &Provider{
+				domain: "example.com",
+				host:   testCase.host,
+				apiKey: "key",
+			}
+
+			record, err := provider.getRecord(context.Background(), client, testCase.recordType)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.record, record)
+			assert.Equal(t, testCase.cursors, cursors)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package yandex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/provider/headers"
+	"github.com/qdm12/ddns-updater/internal/provider/utils"
+)
+
+type apiRecord struct {
+	ID        int64  `json:"record_id"`
+	Type      string `json:"type"`
+	Subdomain string `json:"subdomain"`
+	Content   string `json:"content"`
+}
+
+// See https://yandex.ru/dev/pdd/doc/reference/dns-list.html
+func (p *Provider) getRecord(ctx context.Context, client *http.Client,
+	recordType string) (record apiRecord, err error) {
+	values := url.Values{}
+	values.Set("domain", p.domain)
+	var data struct {
+		Records []apiRecord `json:"records"`
+	}
+	err = p.doRequest(ctx, client, http.MethodGet, "/list", values, &data)
+	if err != nil {
+		return apiRecord{}, err
+	}
+
+	subdomain := p.subdomain()
+	for _, record := range data.Records {
+		if record.Subdomain == subdomain && record.Type == recordType {
+			return record, nil
+		}
+	}
+	return apiRecord{}, fmt.Errorf("%w: in %d record(s)",
+		errors.ErrRecordNotFound, len(data.Records))
+}
+
+// See https://yandex.ru/dev/pdd/doc/reference/dns-edit.html
+func (p *Provider) updateRecord(ctx context.Context, client *http.Client,
+	recordID int64, ip netip.Addr) (err error) {
+	values := url.Values{}
+	values.Set("domain", p.domain)
+	values.Set("record_id", strconv.FormatInt(recordID, 10))
+	values.Set("subdomain", p.subdomain())
+	values.Set("content", ip.String())
+	var data struct {
+		Record apiRecord `json:"record"`
+	}
+	err = p.doRequest(ctx, client, http.MethodPost, "/edit", values, &data)
+	if err != nil {
+		return err
+	}
+
+	if data.Record.Content != ip.String() {
+		return fmt.Errorf("%w: sent ip %s to update but received %s",
+			errors.ErrIPReceivedMismatch, ip, data.Record.Content)
+	}
+	return nil
+}
+
+// doRequest sends the values to the API path, as query parameters for
+// GET requests and as a form otherwise, and decodes the response
+// into responseData. It returns an error if the response success
+// field is not "ok".
+func (p *Provider) doRequest(ctx context.Context, client *http.Client,
+	method, path string, values url.Values, responseData any) (err error) {
+	u := url.URL{
+		Scheme: "https",
+		Host:   "pddimp.yandex.ru",
+		Path:   "/api2/admin/dns" + path,
+	}
+
+	var body io.Reader
+	if method == http.MethodGet {
+		u.RawQuery = values.Encode()
+	} else {
+		body = strings.NewReader(values.Encode())
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	headers.SetUserAgent(request)
+	headers.SetAccept(request, "application/json")
+	if body != nil {
+		headers.SetContentType(request, "application/x-www-form-urlencoded")
+	}
+	request.Header.Set("PddToken", p.token)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %d: %s", errors.ErrHTTPStatusNotValid,
+			response.StatusCode, utils.ToSingleLine(string(b)))
+	}
+
+	var status struct {
+		Success string `json:"success"`
+		Error   string `json:"error"`
+	}
+	err = json.Unmarshal(b, &status)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+
+	if status.Success != "ok" {
+		return makeError(status.Error)
+	}
+
+	err = json.Unmarshal(b, responseData)
+	if err != nil {
+		return fmt.Errorf("json decoding response body: %w", err)
+	}
+	return nil
+}
+
+// See https://yandex.ru/dev/pdd/doc/concepts/api-errors.html
+func makeError(code string) (err error) {
+	switch code {
+	case "no_auth", "bad_oauth", "no_token", "bad_token", "token_expired":
+		return fmt.Errorf("%w: %s", errors.ErrAuth, code)
+	case "no_domain", "bad_domain", "prohibited", "not_allowed":
+		return fmt.Errorf("%w: %s", errors.ErrDomainNotFound, code)
+	case "no_record", "bad_record":
+		return fmt.Errorf("%w: %s", errors.ErrRecordNotFound, code)
+	case "occupied", "bad_subdomain", "bad_content":
+		return fmt.Errorf("%w: %s", errors.ErrBadRequest, code)
+	default:
+		return fmt.Errorf("%w: %s", errors.ErrUnsuccessful, code)
+	}
+}
@@ -166,13 +166,17 @@ func (p *Provider) Update(ctx context.Context, client *http.Client, ip netip.Add
 		return netip.Addr{}, fmt.Errorf("%w", errors.ErrReceivedNoResult)
 	case strings.Contains(s, `error code="702"`),
 		strings.Contains(s, "minimum 600 seconds between requests"):
-		return netip.Addr{}, fmt.Errorf("%w: zoneedit requires 10 minutes between each request", errors.ErrBannedAbuse)
+		// This is not a ban, the update can be retried later.
+		return netip.Addr{}, fmt.Errorf("%w: zoneedit requires 10 minutes between each request",
+			errors.ErrRateLimited)
 	case strings.Contains(s, `error code="709"`),
 		strings.Contains(s, "invalid hostname"):
 		return netip.Addr{}, fmt.Errorf("%w: invalid request sent", errors.ErrBannedAbuse)
 	case strings.Contains(s, `error code="708"`),
 		strings.Contains(s, "failed login"):
 		return netip.Addr{}, fmt.Errorf("%w: for user %s", errors.ErrAuth, p.username)
+	case strings.Contains(s, "<error"):
+		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnsuccessful, utils.ToSingleLine(s))
 	default:
 		return netip.Addr{}, fmt.Errorf("%w: %s", errors.ErrUnknownResponse, utils.ToSingleLine(s))
 	}
@@ -16,3 +16,12 @@ func BuildURLQueryHostname(host, domain string) string {
 	}
 	return host + "." + domain
 }
+
+// BuildRecordName returns the record name relative to the domain,
+// which is the empty string for the apex host "@".
+func BuildRecordName(host string) string {
+	if host == "@" {
+		return ""
+	}
+	return host
+}
@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+)
+
+// Default returns the default rate limit settings for the provider
+// given, matching the documented API rate limit of the provider for
+// an account. It returns settings without request limit for providers
+// without a known rate limit.
+func Default(provider models.Provider) (settings models.RateLimitSettings) {
+	switch provider {
+	case constants.Cloudflare:
+		return models.RateLimitSettings{Requests: 1200, Interval: 5 * time.Minute} //nolint:gomnd
+	case constants.DigitalOcean:
+		return models.RateLimitSettings{Requests: 250, Interval: time.Minute} //nolint:gomnd
+	case constants.GoDaddy:
+		return models.RateLimitSettings{Requests: 60, Interval: time.Minute} //nolint:gomnd
+	case constants.Route53:
+		return models.RateLimitSettings{Requests: 5, Interval: time.Second} //nolint:gomnd
+	default:
+		return models.RateLimitSettings{}
+	}
+}
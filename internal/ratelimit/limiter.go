@@ -0,0 +1,81 @@
+// Package ratelimit implements token bucket rate limiters shared by
+// the records of a same DNS provider account.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter, allowing bursts of up to
+// its number of requests, with tokens refilled continuously so that
+// this number of requests is allowed per interval. It is safe for
+// concurrent use.
+type Limiter struct {
+	capacity float64
+	// refillPeriod is the duration to refill one token.
+	refillPeriod time.Duration
+	timeNow      func() time.Time
+	mutex        sync.Mutex
+	tokens       float64
+	lastRefill   time.Time
+}
+
+// New returns a limiter allowing the number of requests given per
+// interval. The number of requests and the interval must be positive.
+func New(requests uint, interval time.Duration, timeNow func() time.Time) *Limiter {
+	return &Limiter{
+		capacity:     float64(requests),
+		refillPeriod: interval / time.Duration(requests),
+		timeNow:      timeNow,
+		tokens:       float64(requests),
+		lastRefill:   timeNow(),
+	}
+}
+
+// Wait blocks until a request is allowed and takes a token, or
+// returns the context error if the context is canceled before.
+func (l *Limiter) Wait(ctx context.Context) (err error) {
+	for {
+		wait := l.take()
+		if wait == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take takes a token if one is available and returns zero,
+// or returns the duration to wait for a token otherwise.
+func (l *Limiter) take() (wait time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.timeNow()
+	elapsed := now.Sub(l.lastRefill)
+	if elapsed > 0 {
+		l.tokens += float64(elapsed) / float64(l.refillPeriod)
+		if l.tokens > l.capacity {
+			l.tokens = l.capacity
+		}
+		l.lastRefill = now
+	}
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	wait = time.Duration((1 - l.tokens) * float64(l.refillPeriod))
+	if wait <= 0 {
+		wait = 1
+	}
+	return wait
+}
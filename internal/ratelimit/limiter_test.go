@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Limiter_take(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	timeNow := func() time.Time { return now }
+	limiter := New(2, time.Second, timeNow)
+
+	// burst of up to 2 requests
+	assert.Zero(t, limiter.take())
+	assert.Zero(t, limiter.take())
+	assert.Equal(t, 500*time.Millisecond, limiter.take())
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, 250*time.Millisecond, limiter.take())
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Zero(t, limiter.take())
+	assert.Equal(t, 500*time.Millisecond, limiter.take())
+
+	// tokens are capped to the number of requests
+	now = now.Add(time.Hour)
+	assert.Zero(t, limiter.take())
+	assert.Zero(t, limiter.take())
+	assert.Equal(t, 500*time.Millisecond, limiter.take())
+}
+
+func Test_Limiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(1, time.Hour, time.Now)
+	err := limiter.Wait(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = limiter.Wait(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_Registry_Wait(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry(time.Now)
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	settings := models.RateLimitSettings{Requests: 1, Interval: time.Hour}
+
+	err := registry.Wait(canceledCtx, "provider", models.RateLimitSettings{})
+	require.NoError(t, err, "no request limit")
+
+	err = registry.Wait(context.Background(), "provider", settings)
+	require.NoError(t, err)
+	err = registry.Wait(canceledCtx, "provider", settings)
+	assert.ErrorIs(t, err, context.Canceled, "bucket shared by the provider records")
+
+	settings.Bucket = "other account"
+	err = registry.Wait(context.Background(), "provider", settings)
+	assert.NoError(t, err, "separate bucket")
+}
+
+func Test_Registry_Wait_settingsChanged(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry(time.Now)
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	settings := models.RateLimitSettings{Requests: 1, Interval: time.Hour}
+
+	err := registry.Wait(context.Background(), "provider", settings)
+	require.NoError(t, err)
+	err = registry.Wait(canceledCtx, "provider", settings)
+	require.ErrorIs(t, err, context.Canceled)
+
+	settings.Requests = 2
+	err = registry.Wait(context.Background(), "provider", settings)
+	require.NoError(t, err, "limiter created again with the new requests")
+	err = registry.Wait(context.Background(), "provider", settings)
+	require.NoError(t, err)
+	err = registry.Wait(canceledCtx, "provider", settings)
+	require.ErrorIs(t, err, context.Canceled)
+
+	settings.Interval = time.Minute
+	err = registry.Wait(context.Background(), "provider", settings)
+	assert.NoError(t, err, "limiter created again with the new interval")
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+)
+
+// Registry holds the limiters of each provider and bucket,
+// created on first use. It is safe for concurrent use.
+type Registry struct {
+	timeNow  func() time.Time
+	mutex    sync.Mutex
+	limiters map[string]registryLimiter
+}
+
+// registryLimiter is a limiter with the number of requests
+// and interval it was created with.
+type registryLimiter struct {
+	limiter  *Limiter
+	requests uint
+	interval time.Duration
+}
+
+func NewRegistry(timeNow func() time.Time) *Registry {
+	return &Registry{
+		timeNow:  timeNow,
+		limiters: make(map[string]registryLimiter),
+	}
+}
+
+// Wait waits for a request to be allowed by the limiter shared by
+// the records of the provider and rate limit bucket given. It returns
+// immediately if the rate limit settings have no request limit. The
+// limiter of a bucket is created again if the number of requests or the
+// interval given differ from the ones it was created with, for example
+// after the records are reloaded, so records sharing a bucket should
+// have the same settings.
+func (r *Registry) Wait(ctx context.Context, provider models.Provider,
+	settings models.RateLimitSettings) (err error) {
+	if settings.Requests == 0 {
+		return nil
+	}
+
+	key := string(provider) + "/" + settings.Bucket
+	r.mutex.Lock()
+	entry, ok := r.limiters[key]
+	if !ok || entry.requests != settings.Requests || entry.interval != settings.Interval {
+		entry = registryLimiter{
+			limiter:  New(settings.Requests, settings.Interval, r.timeNow),
+			requests: settings.Requests,
+			interval: settings.Interval,
+		}
+		r.limiters[key] = entry
+	}
+	r.mutex.Unlock()
+
+	return entry.limiter.Wait(ctx)
+}
@@ -0,0 +1,27 @@
+package records
+
+import (
+	"github.com/qdm12/ddns-updater/internal/constants"
+	"github.com/qdm12/ddns-updater/internal/models"
+)
+
+// API returns the record fields for the JSON API.
+func (r *Record) API() models.APIRecord {
+	apiRecord := models.APIRecord{
+		Provider:  r.Settings.ProviderName,
+		Domain:    r.Provider.Domain(),
+		Host:      r.Provider.Host(),
+		IPVersion: r.Provider.IPVersion().String(),
+		Proxied:   r.Provider.Proxied(),
+		Status:    r.Status,
+		CurrentIP: r.History.GetCurrentIP(),
+	}
+	if !r.Time.IsZero() {
+		lastUpdate := r.Time
+		apiRecord.LastUpdate = &lastUpdate
+	}
+	if r.Status == constants.FAIL {
+		apiRecord.LastError = r.Message
+	}
+	return apiRecord
+}
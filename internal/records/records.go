@@ -11,18 +11,21 @@ import (
 
 // Record contains all the information to update and display a DNS record.
 type Record struct { // internal
-	Provider provider.Provider // fixed
-	History  models.History    // past information
+	Provider provider.Provider     // fixed
+	Settings models.RecordSettings // fixed
+	History  models.History        // past information
 	Status   models.Status
 	Message  string
 	Time     time.Time
 	LastBan  *time.Time // nil means no last ban
 }
 
-// New returns a new Record with provider and some history.
-func New(provider provider.Provider, events []models.HistoryEvent) Record {
+// New returns a new Record with provider, its settings and some history.
+func New(provider provider.Provider, settings models.RecordSettings,
+	events []models.HistoryEvent) Record {
 	return Record{
 		Provider: provider,
+		Settings: settings,
 		History:  events,
 		Status:   constants.UNSET,
 	}
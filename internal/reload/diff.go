@@ -0,0 +1,60 @@
+package reload
+
+import (
+	"bytes"
+
+	"github.com/qdm12/ddns-updater/internal/params"
+)
+
+type diff struct {
+	// previousIDs maps the IDs of the new records kept
+	// unchanged to their previous IDs.
+	previousIDs map[uint]uint
+	added       []string
+	changed     []string
+	removed     []string
+}
+
+func (d diff) isEmpty() bool {
+	return len(d.added) == 0 && len(d.changed) == 0 && len(d.removed) == 0
+}
+
+// diffProviders compares the previous and current providers settings,
+// identifying records by their provider, domain, host and IP version.
+// A record is changed if any of its raw JSON settings changed.
+func diffProviders(previous, current []params.ProviderSettings) (d diff) {
+	previousIDsByKey := make(map[string][]uint, len(previous))
+	for i, settings := range previous {
+		key := settings.Provider.String()
+		previousIDsByKey[key] = append(previousIDsByKey[key], uint(i))
+	}
+
+	d.previousIDs = make(map[uint]uint)
+	for i, settings := range current {
+		key := settings.Provider.String()
+		previousIDs := previousIDsByKey[key]
+		if len(previousIDs) == 0 {
+			d.added = append(d.added, key)
+			continue
+		}
+		previousID := previousIDs[0]
+		previousIDsByKey[key] = previousIDs[1:]
+
+		if bytes.Equal(previous[previousID].Raw, settings.Raw) {
+			d.previousIDs[uint(i)] = previousID
+		} else {
+			d.changed = append(d.changed, key)
+		}
+	}
+
+	for i, settings := range previous { // keep the previous order
+		key := settings.Provider.String()
+		for _, id := range previousIDsByKey[key] {
+			if id == uint(i) {
+				d.removed = append(d.removed, key)
+			}
+		}
+	}
+
+	return d
+}
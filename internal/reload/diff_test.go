@@ -0,0 +1,85 @@
+package reload
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/qdm12/ddns-updater/internal/params"
+	"github.com/qdm12/ddns-updater/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProvider struct {
+	provider.Provider
+	name string
+}
+
+func (p fakeProvider) String() string { return p.name }
+
+func makeSettings(name, raw string) params.ProviderSettings {
+	return params.ProviderSettings{
+		Provider: fakeProvider{name: name},
+		Raw:      json.RawMessage(raw),
+	}
+}
+
+func Test_diffProviders(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		previous []params.ProviderSettings
+		current  []params.ProviderSettings
+		diff     diff
+	}{
+		"empty": {
+			diff: diff{previousIDs: map[uint]uint{}},
+		},
+		"unchanged": {
+			previous: []params.ProviderSettings{makeSettings("a", "{}"), makeSettings("b", "{}")},
+			current:  []params.ProviderSettings{makeSettings("a", "{}"), makeSettings("b", "{}")},
+			diff:     diff{previousIDs: map[uint]uint{0: 0, 1: 1}},
+		},
+		"reordered": {
+			previous: []params.ProviderSettings{makeSettings("a", "{}"), makeSettings("b", "{}")},
+			current:  []params.ProviderSettings{makeSettings("b", "{}"), makeSettings("a", "{}")},
+			diff:     diff{previousIDs: map[uint]uint{0: 1, 1: 0}},
+		},
+		"added_changed_removed": {
+			previous: []params.ProviderSettings{
+				makeSettings("a", "{}"),
+				makeSettings("b", `{"key":"1"}`),
+				makeSettings("c", "{}"),
+			},
+			current: []params.ProviderSettings{
+				makeSettings("b", `{"key":"2"}`),
+				makeSettings("a", "{}"),
+				makeSettings("d", "{}"),
+			},
+			diff: diff{
+				previousIDs: map[uint]uint{1: 0},
+				added:       []string{"d"},
+				changed:     []string{"b"},
+				removed:     []string{"c"},
+			},
+		},
+		"duplicate_key_removed": {
+			previous: []params.ProviderSettings{makeSettings("a", "{}"), makeSettings("a", "{}")},
+			current:  []params.ProviderSettings{makeSettings("a", "{}")},
+			diff: diff{
+				previousIDs: map[uint]uint{0: 0},
+				removed:     []string{"a"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diff := diffProviders(testCase.previous, testCase.current)
+
+			assert.Equal(t, testCase.diff, diff)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package reload
+
+import (
+	"context"
+
+	"github.com/qdm12/ddns-updater/internal/params"
+	"github.com/qdm12/ddns-updater/internal/records"
+)
+
+type ProvidersReader interface {
+	JSONProvidersFromFile(filePath string) (
+		providers []params.ProviderSettings, warnings []string, err error)
+}
+
+type RecordsReloader interface {
+	ReloadRecords(ctx context.Context, records []records.Record,
+		previousIDs map[uint]uint) (errs []error)
+}
+
+type Logger interface {
+	Info(s string)
+	Warn(s string)
+	Error(s string)
+}
@@ -0,0 +1,146 @@
+// Package reload watches the JSON configuration file and reloads
+// the records when it changes.
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/params"
+	"github.com/qdm12/ddns-updater/internal/records"
+)
+
+// MakeRecordFunc creates a record from its settings, for example
+// by loading its history from the persistent database.
+type MakeRecordFunc func(settings params.ProviderSettings) (record records.Record, err error)
+
+type Reloader struct {
+	filePath   string
+	period     time.Duration
+	reader     ProvidersReader
+	makeRecord MakeRecordFunc
+	runner     RecordsReloader
+	logger     Logger
+	stat       func(name string) (fs.FileInfo, error)
+	// providers are the providers settings of the records
+	// currently in use, and are only accessed in Run.
+	providers []params.ProviderSettings
+	modTime   time.Time
+}
+
+// New creates a reloader checking the file at the path given for
+// changes every period, where providers are the providers settings
+// of the records at the time of the call.
+func New(filePath string, period time.Duration, providers []params.ProviderSettings,
+	reader ProvidersReader, makeRecord MakeRecordFunc, runner RecordsReloader,
+	logger Logger) *Reloader {
+	return &Reloader{
+		filePath:   filePath,
+		period:     period,
+		reader:     reader,
+		makeRecord: makeRecord,
+		runner:     runner,
+		logger:     logger,
+		stat:       os.Stat,
+		providers:  providers,
+	}
+}
+
+func (r *Reloader) Run(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+	if r.period == 0 {
+		r.logger.Info("disabled")
+		return
+	}
+
+	info, err := r.stat(r.filePath)
+	if err == nil {
+		r.modTime = info.ModTime()
+	}
+
+	ticker := time.NewTicker(r.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.checkFile(ctx)
+		}
+	}
+}
+
+func (r *Reloader) checkFile(ctx context.Context) {
+	info, err := r.stat(r.filePath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			r.logger.Error(err.Error())
+		}
+		return
+	}
+
+	if info.ModTime().Equal(r.modTime) {
+		return
+	}
+	r.modTime = info.ModTime()
+
+	err = r.reload(ctx)
+	if err != nil {
+		r.logger.Error("reloading " + r.filePath + ": " + err.Error() +
+			"; keeping the current records")
+	}
+}
+
+func (r *Reloader) reload(ctx context.Context) (err error) {
+	providers, warnings, err := r.reader.JSONProvidersFromFile(r.filePath)
+	for _, warning := range warnings {
+		r.logger.Warn(warning)
+	}
+	if err != nil {
+		return err
+	}
+
+	diff := diffProviders(r.providers, providers)
+	if diff.isEmpty() {
+		r.logger.Info("file " + r.filePath + " changed but records are unchanged")
+		return nil
+	}
+
+	newRecords := make([]records.Record, len(providers))
+	for i, settings := range providers {
+		_, kept := diff.previousIDs[uint(i)]
+		if kept {
+			continue
+		}
+		newRecords[i], err = r.makeRecord(settings)
+		if err != nil {
+			return fmt.Errorf("creating record %s: %w", settings.Provider, err)
+		}
+	}
+
+	logChanges(r.logger, "added", diff.added)
+	logChanges(r.logger, "changed", diff.changed)
+	logChanges(r.logger, "removed", diff.removed)
+
+	// note: update errors are logged by the runner,
+	// no need to log the resulting errors.
+	_ = r.runner.ReloadRecords(ctx, newRecords, diff.previousIDs)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.providers = providers
+	r.logger.Info(fmt.Sprintf("reloaded %d records", len(providers)))
+	return nil
+}
+
+func logChanges(logger Logger, change string, records []string) {
+	if len(records) == 0 {
+		return
+	}
+	logger.Info(change + " records: " + strings.Join(records, ", "))
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+)
+
+// records responds with the JSON list of records. It sets an ETag header
+// computed from the response body, as well as a Last-Modified header set
+// to the most recent record update time, so polling clients can send
+// conditional requests.
+func (h *handlers) records(w http.ResponseWriter, r *http.Request) {
+	records := h.db.SelectAll()
+	apiRecords := make([]models.APIRecord, len(records))
+	var lastModified time.Time
+	for i, record := range records {
+		apiRecords[i] = record.API()
+		if record.Time.After(lastModified) {
+			lastModified = record.Time
+		}
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buffer).Encode(apiRecords)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, "encoding records: "+err.Error())
+		return
+	}
+	body := buffer.Bytes()
+
+	digest := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(digest[:16]) + `"`
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	ifNoneMatch := strings.Join(r.Header.Values("If-None-Match"), ",")
+	if ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	_, _ = w.Write(body)
+}
+
+// etagMatches returns true if the If-None-Match header value given
+// matches the entity tag given, using the weak comparison defined in
+// https://www.rfc-editor.org/rfc/rfc9110#section-13.1.2. The header
+// value can be "*" or a comma separated list of entity tags.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	opaqueTag := strings.TrimPrefix(etag, "W/")
+	remaining := ifNoneMatch
+	for {
+		remaining = strings.TrimLeft(remaining, " \t,")
+		if remaining == "" {
+			return false
+		}
+		remaining = strings.TrimPrefix(remaining, "W/")
+		if !strings.HasPrefix(remaining, `"`) {
+			return false // malformed entity tag
+		}
+		closingIndex := strings.IndexByte(remaining[1:], '"')
+		if closingIndex == -1 {
+			return false // malformed entity tag
+		}
+		tagLength := closingIndex + 2 //nolint:gomnd
+		if remaining[:tagLength] == opaqueTag {
+			return true
+		}
+		remaining = remaining[tagLength:]
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/records"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_handlers_records(t *testing.T) {
+	t.Parallel()
+
+	record := makeTestRecord(t, "a")
+	record.Time = time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	db := &fakeDatabase{records: []records.Record{record}}
+	handlers := &handlers{db: db}
+
+	get := func(ifNoneMatch ...string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/api/records", nil)
+		for _, value := range ifNoneMatch {
+			request.Header.Add("If-None-Match", value)
+		}
+		recorder := httptest.NewRecorder()
+		handlers.records(recorder, request)
+		return recorder
+	}
+
+	response := get()
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.Equal(t, "Mon, 01 Jan 2024 10:00:00 GMT", response.Header().Get("Last-Modified"))
+	assert.Contains(t, response.Body.String(), `"host":"a"`)
+	etag := response.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	notModified := map[string][]string{
+		"same_etag":     {etag},
+		"weak_etag":     {"W/" + etag},
+		"etag_list":     {`"other", ` + etag},
+		"header_values": {`"other"`, etag},
+		"any":           {"*"},
+	}
+	for name, ifNoneMatch := range notModified {
+		response = get(ifNoneMatch...)
+		assert.Equal(t, http.StatusNotModified, response.Code, name)
+		assert.Equal(t, etag, response.Header().Get("ETag"), name)
+		assert.Empty(t, response.Body.String(), name)
+	}
+
+	response = get(`"other"`)
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func Test_etagMatches(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		ifNoneMatch string
+		etag        string
+		matches     bool
+	}{
+		"same": {
+			ifNoneMatch: `"abc"`,
+			etag:        `"abc"`,
+			matches:     true,
+		},
+		"different": {
+			ifNoneMatch: `"abd"`,
+			etag:        `"abc"`,
+		},
+		"weak_header": {
+			ifNoneMatch: `W/"abc"`,
+			etag:        `"abc"`,
+			matches:     true,
+		},
+		"weak_etag": {
+			ifNoneMatch: `"abc"`,
+			etag:        `W/"abc"`,
+			matches:     true,
+		},
+		"list": {
+			ifNoneMatch: `"x", W/"y" ,"abc"`,
+			etag:        `"abc"`,
+			matches:     true,
+		},
+		"comma_inside_tag": {
+			ifNoneMatch: `"x,abc", "abc"`,
+			etag:        `"abc"`,
+			matches:     true,
+		},
+		"any": {
+			ifNoneMatch: ` * `,
+			etag:        `"abc"`,
+			matches:     true,
+		},
+		"unquoted": {
+			ifNoneMatch: `abc`,
+			etag:        `"abc"`,
+		},
+		"unterminated": {
+			ifNoneMatch: `"abc`,
+			etag:        `"abc"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			matches := etagMatches(testCase.ifNoneMatch, testCase.etag)
+
+			assert.Equal(t, testCase.matches, matches)
+		})
+	}
+}
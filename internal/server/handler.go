@@ -5,6 +5,7 @@ import (
 	"embed"
 	"net/http"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -18,6 +19,11 @@ type handlers struct {
 	db            Database
 	runner        UpdateForcer
 	indexTemplate *template.Template
+	// pending contains the keys of records with a triggered update
+	// not yet finished, to avoid piling up updates. Records are keyed
+	// by their provider string, since their IDs change on reload.
+	pending      map[string]struct{}
+	pendingMutex sync.Mutex
 	// Mockable functions
 	timeNow func() time.Time
 }
@@ -26,13 +32,14 @@ type handlers struct {
 var uiFS embed.FS
 
 func newHandler(ctx context.Context, rootURL string,
-	db Database, runner UpdateForcer) http.Handler {
+	db Database, runner UpdateForcer, metricsHandler http.Handler) http.Handler {
 	indexTemplate := template.Must(template.ParseFS(uiFS, "ui/index.html"))
 
 	handlers := &handlers{
 		ctx:           ctx,
 		db:            db,
 		indexTemplate: indexTemplate,
+		pending:       make(map[string]struct{}),
 		// TODO build information
 		timeNow: time.Now,
 		runner:  runner,
@@ -46,6 +53,11 @@ func newHandler(ctx context.Context, rootURL string,
 	router.Get(rootURL+"/", handlers.index)
 
 	router.Get(rootURL+"/update", handlers.update)
+	router.Post(rootURL+"/update", handlers.triggerUpdate)
+
+	router.Get(rootURL+"/api/records", handlers.records)
+
+	router.Method(http.MethodGet, rootURL+"/metrics", metricsHandler)
 
 	return router
 }
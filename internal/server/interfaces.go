@@ -12,6 +12,7 @@ type Database interface {
 
 type UpdateForcer interface {
 	ForceUpdate(ctx context.Context) (errors []error)
+	ForceUpdateRecords(ctx context.Context, keys []string) (errors []error)
 }
 
 type Logger interface {
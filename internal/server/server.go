@@ -13,8 +13,8 @@ type Server struct {
 }
 
 func New(ctx context.Context, address, rootURL string, db Database,
-	logger Logger, runner UpdateForcer) *Server {
-	handler := newHandler(ctx, rootURL, db, runner)
+	logger Logger, runner UpdateForcer, metricsHandler http.Handler) *Server {
+	handler := newHandler(ctx, rootURL, db, runner, metricsHandler)
 	return &Server{
 		address: address,
 		logger:  logger,
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/json"
 	"net/http"
 )
 
@@ -16,3 +17,80 @@ func (h *handlers) update(w http.ResponseWriter, _ *http.Request) {
 	message := "All records updated successfully in " + duration.String()
 	_, _ = w.Write([]byte(message))
 }
+
+type triggeredRecord struct {
+	Domain    string `json:"domain"`
+	Host      string `json:"host"`
+	IPVersion string `json:"ip_version"`
+}
+
+type triggerUpdateResponse struct {
+	Status  string            `json:"status"`
+	Records []triggeredRecord `json:"records"`
+}
+
+// triggerUpdate triggers an update of all the records, or of the records
+// matching the domain and host query parameters if set, and responds
+// without waiting for the update to finish. Records with a triggered
+// update still pending are not triggered again.
+func (h *handlers) triggerUpdate(w http.ResponseWriter, r *http.Request) {
+	domain := r.URL.Query().Get("domain")
+	host := r.URL.Query().Get("host")
+
+	records := h.db.SelectAll()
+	var keys []string
+	response := triggerUpdateResponse{
+		Status:  "triggered",
+		Records: []triggeredRecord{},
+	}
+
+	h.pendingMutex.Lock()
+	matched := false
+	for _, record := range records {
+		if (domain != "" && record.Provider.Domain() != domain) ||
+			(host != "" && record.Provider.Host() != host) {
+			continue
+		}
+		matched = true
+		key := record.Provider.String()
+		if _, pending := h.pending[key]; pending {
+			continue
+		}
+		h.pending[key] = struct{}{}
+		keys = append(keys, key)
+		response.Records = append(response.Records, triggeredRecord{
+			Domain:    record.Provider.Domain(),
+			Host:      record.Provider.Host(),
+			IPVersion: record.Provider.IPVersion().String(),
+		})
+	}
+	h.pendingMutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case !matched:
+		httpError(w, http.StatusNotFound, "no record matches the domain and host given")
+		return
+	case len(keys) == 0:
+		response.Status = "already pending"
+	default:
+		go func() {
+			// Keys are given instead of record IDs since IDs change if records
+			// are reloaded before the runner handles the update. Errors are
+			// logged by the runner.
+			_ = h.runner.ForceUpdateRecords(h.ctx, keys)
+			h.pendingMutex.Lock()
+			for _, key := range keys {
+				delete(h.pending, key)
+			}
+			h.pendingMutex.Unlock()
+		}()
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/njalla"
+	"github.com/qdm12/ddns-updater/internal/records"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDatabase struct {
+	mutex   sync.Mutex
+	records []records.Record
+}
+
+func (d *fakeDatabase) SelectAll() []records.Record {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.records
+}
+
+func (d *fakeDatabase) replaceAll(records []records.Record) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.records = records
+}
+
+// blockingRunner blocks forced record updates until release is closed,
+// and sends the keys of the records to update on the calls channel.
+type blockingRunner struct {
+	calls   chan []string
+	release chan struct{}
+}
+
+func (r *blockingRunner) ForceUpdate(context.Context) []error { return nil }
+
+func (r *blockingRunner) ForceUpdateRecords(_ context.Context, keys []string) []error {
+	r.calls <- keys
+	<-r.release
+	return nil
+}
+
+func makeTestRecord(t *testing.T, host string) records.Record {
+	t.Helper()
+	provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", host,
+		ipversion.IP4, netip.Prefix{})
+	require.NoError(t, err)
+	return records.New(provider, models.RecordSettings{}, nil)
+}
+
+func Test_handlers_triggerUpdate(t *testing.T) {
+	t.Parallel()
+
+	recordA, recordB := makeTestRecord(t, "a"), makeTestRecord(t, "b")
+	db := &fakeDatabase{records: []records.Record{recordA, recordB}}
+	runner := &blockingRunner{
+		calls:   make(chan []string),
+		release: make(chan struct{}),
+	}
+	handlers := &handlers{
+		ctx:     context.Background(),
+		db:      db,
+		runner:  runner,
+		pending: make(map[string]struct{}),
+	}
+
+	trigger := func(query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/update?"+query, nil)
+		recorder := httptest.NewRecorder()
+		handlers.triggerUpdate(recorder, request)
+		return recorder
+	}
+
+	response := trigger("host=a")
+	assert.Equal(t, http.StatusAccepted, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"status":"triggered","records":[`+
+		`{"domain":"example.com","host":"a","ip_version":"ipv4"}]}`,
+		response.Body.String())
+	assert.Equal(t, []string{recordA.Provider.String()}, <-runner.calls)
+
+	response = trigger("host=a")
+	assert.Equal(t, http.StatusAccepted, response.Code)
+	assert.JSONEq(t, `{"status":"already pending","records":[]}`, response.Body.String())
+
+	response = trigger("host=c")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.JSONEq(t, `{"error":"no record matches the domain and host given"}`,
+		response.Body.String())
+
+	// A reload moves record b to the position of record a, which is
+	// still pending, and record b must still be triggered.
+	db.replaceAll([]records.Record{recordB, recordA})
+	response = trigger("host=b")
+	assert.Equal(t, http.StatusAccepted, response.Code)
+	assert.JSONEq(t, `{"status":"triggered","records":[`+
+		`{"domain":"example.com","host":"b","ip_version":"ipv4"}]}`,
+		response.Body.String())
+	assert.Equal(t, []string{recordB.Provider.String()}, <-runner.calls)
+
+	close(runner.release)
+}
@@ -20,9 +20,12 @@ func (s *Settings) setDefaults() {
 }
 
 func (s Settings) validate() (err error) {
-	_, err = shoutrrr.CreateSender(s.Addresses...)
-	if err != nil {
-		return fmt.Errorf("shoutrrr addresses: %w", err)
+	// Validate each address separately to report which one is malformed.
+	for i, address := range s.Addresses {
+		_, err = shoutrrr.CreateSender(address)
+		if err != nil {
+			return fmt.Errorf("shoutrrr address %d of %d: %w", i+1, len(s.Addresses), err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,51 @@
+package update
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+)
+
+// recordUpdateResult counts the consecutive failed updates of the
+// record, resetting the count on success, and logs when the record
+// enters or exits the backed-off state.
+// It must only be called from the Run goroutine.
+func (r *Runner) recordUpdateResult(record librecords.Record, id uint, err error) {
+	threshold := r.failureBackoff.Failures
+	if err == nil {
+		failures := r.failures[id]
+		delete(r.failures, id)
+		if threshold > 0 && failures >= threshold {
+			r.logger.Info("record " + record.Provider.String() +
+				" updated successfully, it is no longer backed off")
+		}
+		return
+	}
+
+	r.failures[id]++
+	if threshold > 0 && r.failures[id] == threshold {
+		r.logger.Warn("record " + record.Provider.String() + " failed " +
+			strconv.FormatUint(uint64(threshold), 10) + " consecutive times, " +
+			"increasing its update interval up to " + r.failureBackoff.MaxInterval.String())
+	}
+}
+
+// backoffInterval returns the interval between update checks of a
+// record following consecutive failed updates, given its normal cycle.
+// It returns zero if the record is not backed off.
+func (r *Runner) backoffInterval(id uint, cycle time.Duration) time.Duration {
+	threshold := r.failureBackoff.Failures
+	failures := r.failures[id]
+	if threshold == 0 || failures < threshold {
+		return 0
+	}
+	maxInterval := max(r.failureBackoff.MaxInterval, cycle)
+	exponent := float64(failures - threshold + 1)
+	interval := float64(cycle) * math.Pow(r.failureBackoff.Multiplier, exponent)
+	if interval >= float64(maxInterval) {
+		return maxInterval
+	}
+	return time.Duration(interval)
+}
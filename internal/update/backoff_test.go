@@ -0,0 +1,105 @@
+package update
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/njalla"
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Runner_failureBackoff(t *testing.T) {
+	t.Parallel()
+
+	provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", "@",
+		ipversion.IP4or6, netip.Prefix{})
+	require.NoError(t, err)
+	record := librecords.Record{Provider: provider}
+
+	runner := &Runner{
+		period:  time.Minute,
+		logger:  noopLogger{},
+		nextDue: make(map[uint]time.Time),
+		failureBackoff: models.FailureBackoffSettings{
+			Failures:    2,
+			Multiplier:  3,
+			MaxInterval: 20 * time.Minute,
+		},
+		failures: make(map[uint]uint),
+	}
+	now := time.Unix(0, 0)
+	errTest := errors.New("test error")
+
+	nextIntervals := []time.Duration{
+		time.Minute,      // 1 failure, not backed off yet
+		3 * time.Minute,  // 2 failures
+		9 * time.Minute,  // 3 failures
+		20 * time.Minute, // 4 failures, capped
+		20 * time.Minute, // 5 failures, capped
+	}
+	for i, expected := range nextIntervals {
+		runner.recordUpdateResult(record, 0, errTest)
+		next := runner.nextDueAfter(record, 0, now)
+		assert.Equal(t, expected, next.Sub(now), "after %d failures", i+1)
+	}
+
+	runner.recordUpdateResult(record, 0, nil)
+	assert.Empty(t, runner.failures)
+	next := runner.nextDueAfter(record, 0, now)
+	assert.Equal(t, time.Minute, next.Sub(now))
+}
+
+func Test_Runner_backoffInterval(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		settings models.FailureBackoffSettings
+		failures uint
+		cycle    time.Duration
+		interval time.Duration
+	}{
+		"disabled": {
+			failures: 10,
+			cycle:    time.Minute,
+		},
+		"below_threshold": {
+			settings: models.FailureBackoffSettings{Failures: 3, Multiplier: 2, MaxInterval: time.Hour},
+			failures: 2,
+			cycle:    time.Minute,
+		},
+		"at_threshold": {
+			settings: models.FailureBackoffSettings{Failures: 3, Multiplier: 2, MaxInterval: time.Hour},
+			failures: 3,
+			cycle:    time.Minute,
+			interval: 2 * time.Minute,
+		},
+		"cycle_above_max_interval": {
+			settings: models.FailureBackoffSettings{Failures: 1, Multiplier: 2, MaxInterval: time.Hour},
+			failures: 1,
+			cycle:    2 * time.Hour,
+			interval: 2 * time.Hour,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := &Runner{
+				failureBackoff: testCase.settings,
+				failures:       map[uint]uint{0: testCase.failures},
+			}
+
+			interval := runner.backoffInterval(0, testCase.cycle)
+
+			assert.Equal(t, testCase.interval, interval)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package update
+
+import (
+	"fmt"
+	"io"
+
+	settingserrors "github.com/qdm12/ddns-updater/internal/provider/errors"
+)
+
+// maxResponseBodySize is the maximum size of provider response bodies,
+// to protect against misbehaving endpoints sending enormous bodies.
+const maxResponseBodySize = 512 * 1024
+
+// limitedBody is a response body returning an error wrapping
+// ErrUnmarshalResponse if it is larger than its size limit.
+type limitedBody struct {
+	body      io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+func newLimitedBody(body io.ReadCloser, limit int64) *limitedBody {
+	return &limitedBody{
+		body:      body,
+		limit:     limit,
+		remaining: limit,
+	}
+}
+
+func (l *limitedBody) Read(p []byte) (n int, err error) {
+	if l.remaining <= 0 {
+		// check there is no more data past the limit
+		var probe [1]byte
+		n, err = l.body.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: %w: exceeding %d bytes",
+				settingserrors.ErrUnmarshalResponse, settingserrors.ErrResponseBodyTooLarge, l.limit)
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err = l.body.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	return l.body.Close()
+}
+
+type errorReader struct {
+	err error
+}
+
+func (e *errorReader) Read([]byte) (n int, err error) {
+	return 0, e.err
+}
@@ -0,0 +1,58 @@
+package update
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	settingserrors "github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_limitedBody(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		body       string
+		limit      int64
+		read       string
+		errWrapped error
+		errMessage string
+	}{
+		"below_limit": {
+			body:  "abc",
+			limit: 4,
+			read:  "abc",
+		},
+		"at_limit": {
+			body:  "abcd",
+			limit: 4,
+			read:  "abcd",
+		},
+		"above_limit": {
+			body:       "abcde",
+			limit:      4,
+			read:       "abcd",
+			errWrapped: settingserrors.ErrResponseBodyTooLarge,
+			errMessage: "cannot unmarshal response: response body is too large: exceeding 4 bytes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			body := newLimitedBody(io.NopCloser(strings.NewReader(testCase.body)), testCase.limit)
+
+			b, err := io.ReadAll(body)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, settingserrors.ErrUnmarshalResponse)
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.read, string(b))
+		})
+	}
+}
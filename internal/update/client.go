@@ -0,0 +1,59 @@
+package update
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/records"
+)
+
+// recordClient returns the HTTP client to use for the record provider
+// requests. It uses the custom endpoints client for the custom provider,
+// and the record proxy URL if it is set.
+func (u *Updater) recordClient(record records.Record) (client *http.Client) {
+	isCustom := record.Settings.ProviderName == constants.Custom
+	proxyURL := record.Settings.ProxyURL
+	switch {
+	case proxyURL == nil && isCustom:
+		return u.customClient
+	case proxyURL == nil:
+		return u.client
+	}
+
+	baseClient, key := u.healthcheckClient, *proxyURL
+	if isCustom {
+		baseClient, key = u.customBaseClient, "custom "+*proxyURL
+	}
+
+	u.proxyClientsMutex.Lock()
+	defer u.proxyClientsMutex.Unlock()
+	client, ok := u.proxyClients[key]
+	if !ok {
+		client = makeLogClient(makeProxyClient(baseClient, *proxyURL), u.logger)
+		u.proxyClients[key] = client
+	}
+	return client
+}
+
+// makeProxyClient returns a copy of the client given using the proxy URL
+// given, or no proxy at all if the proxy URL is empty. The proxy URL
+// must have been validated beforehand.
+func makeProxyClient(client *http.Client, proxyURL string) (newClient *http.Client) {
+	originalTransport := client.Transport
+	if originalTransport == nil {
+		originalTransport = http.DefaultTransport
+	}
+	transport := originalTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+
+	transport.Proxy = nil
+	if proxyURL != "" {
+		parsedURL, _ := url.Parse(proxyURL)
+		transport.Proxy = http.ProxyURL(parsedURL)
+	}
+
+	return &http.Client{
+		Timeout:   client.Timeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,59 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// recordHealthcheckTimeout is the timeout to ping the healthcheck URL of
+// a record, kept short since the ping is done in the update loop.
+const recordHealthcheckTimeout = 5 * time.Second
+
+// pingRecordHealthcheck sends a GET request to the healthcheck URL of a
+// record, with the /fail suffix if the update failed. Errors are logged
+// and never affect the update result.
+func (u *Updater) pingRecordHealthcheck(ctx context.Context, healthcheckURL string,
+	updateErr error) {
+	if healthcheckURL == "" {
+		return
+	}
+
+	if updateErr != nil {
+		healthcheckURL = strings.TrimSuffix(healthcheckURL, "/") + "/fail"
+	}
+
+	err := pingURL(ctx, u.healthcheckClient, healthcheckURL)
+	if err != nil {
+		u.logger.Warn("pinging record healthcheck URL: " + err.Error())
+	}
+}
+
+var ErrHealthcheckStatus = errors.New("bad healthcheck HTTP status")
+
+func pingURL(ctx context.Context, client *http.Client, url string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, recordHealthcheckTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("doing request: %w", err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %d %s", ErrHealthcheckStatus, response.StatusCode,
+			http.StatusText(response.StatusCode))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package update
 
 import (
+	"errors"
 	"fmt"
 	"net/netip"
 	"strings"
@@ -57,3 +58,18 @@ func (e *joinedErrors) Error() string {
 func (e *joinedErrors) Unwrap() []error {
 	return e.errs
 }
+
+var ErrIPVersionMismatch = errors.New("IP address does not match the record IP version")
+
+// checkIPVersion returns an error if any of the IP addresses given
+// does not match the IP version of the record.
+func checkIPVersion(record records.Record, ips []netip.Addr) (err error) {
+	ipVersion := record.Provider.IPVersion()
+	for _, ip := range ips {
+		if !ipVersion.Matches(ip) {
+			return fmt.Errorf("%w: %s is not %s for record %s, skipping update",
+				ErrIPVersionMismatch, ip, ipVersion, recordToLogString(record))
+		}
+	}
+	return nil
+}
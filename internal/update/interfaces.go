@@ -4,8 +4,12 @@ import (
 	"context"
 	"net"
 	"net/netip"
+	"time"
 
 	"github.com/qdm12/ddns-updater/internal/healthchecksio"
+	"github.com/qdm12/ddns-updater/internal/history"
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/notify"
 	"github.com/qdm12/ddns-updater/internal/records"
 )
 
@@ -15,14 +19,29 @@ type PublicIPFetcher interface {
 	IP6(ctx context.Context) (netip.Addr, error)
 }
 
+// ipCacheInvalidator is optionally implemented by a PublicIPFetcher
+// caching the IP addresses it fetches.
+type ipCacheInvalidator interface {
+	Invalidate()
+}
+
+// failuresRemapper is optionally implemented by an UpdaterInterface
+// counting the consecutive failed updates of each record ID.
+type failuresRemapper interface {
+	RemapFailures(previousIDs map[uint]uint)
+}
+
 type UpdaterInterface interface {
 	Update(ctx context.Context, recordID uint, ip netip.Addr) (err error)
+	UpdateBoth(ctx context.Context, recordID uint, ipv4, ipv6 netip.Addr) (err error)
+	UpdateValue(ctx context.Context, recordID uint) (err error)
 }
 
 type Database interface {
 	Select(recordID uint) (record records.Record, err error)
 	SelectAll() (records []records.Record)
 	Update(recordID uint, record records.Record) (err error)
+	ReplaceAll(records []records.Record)
 }
 
 type LookupIPer interface {
@@ -33,6 +52,18 @@ type ShoutrrrClient interface {
 	Notify(message string)
 }
 
+type EventDispatcher interface {
+	Dispatch(event notify.Event)
+}
+
+type MetricsRecorder interface {
+	UpdateAttempt(provider models.Provider, duration time.Duration, err error)
+}
+
+type HistoryStore interface {
+	Add(ctx context.Context, entry history.Entry) (err error)
+}
+
 type Logger interface {
 	DebugLogger
 	Info(s string)
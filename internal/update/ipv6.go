@@ -1,10 +1,12 @@
 package update
 
 import (
-	"fmt"
 	"net/netip"
 )
 
+// ipv6WithSuffix replaces the last ipv6Suffix.Bits() bits of the public
+// IPv6 address with the ones of the suffix address. This merges the
+// prefix delegated by the ISP with a stable interface identifier.
 func ipv6WithSuffix(publicIP netip.Addr, ipv6Suffix netip.Prefix) (
 	updateIP netip.Addr) {
 	if !publicIP.IsValid() || !publicIP.Is6() || !ipv6Suffix.IsValid() {
@@ -13,15 +15,15 @@ func ipv6WithSuffix(publicIP netip.Addr, ipv6Suffix netip.Prefix) (
 
 	const ipv6Bits = 128
 	const bitsInByte = 8
-	prefixLength := (ipv6Bits - ipv6Suffix.Bits()) / bitsInByte
-	ispPrefix := publicIP.AsSlice()[:prefixLength]
-	localSuffix := ipv6Suffix.Addr().AsSlice()[prefixLength:]
-	ipv6Bytes := ispPrefix // ispPrefix has already 16 bytes of capacity
-	ipv6Bytes = append(ipv6Bytes, localSuffix...)
-	updateIP, ok := netip.AddrFromSlice(ipv6Bytes)
-	if !ok {
-		panic(fmt.Sprintf("failed to create IPv6 address from merged bytes %v", ipv6Bytes))
+	prefixBits := ipv6Bits - ipv6Suffix.Bits()
+	ispPrefix := publicIP.As16()
+	localSuffix := ipv6Suffix.Addr().As16()
+	var ipv6Bytes [16]byte
+	for i := range ipv6Bytes {
+		prefixBitsInByte := min(max(prefixBits-i*bitsInByte, 0), bitsInByte)
+		prefixMask := byte(0xff << (bitsInByte - prefixBitsInByte))
+		ipv6Bytes[i] = ispPrefix[i]&prefixMask | localSuffix[i]&^prefixMask
 	}
 
-	return updateIP
+	return netip.AddrFrom16(ipv6Bytes)
 }
@@ -39,6 +39,11 @@ func Test_ipv6WithSuffix(t *testing.T) {
 			ipv6Suffix: netip.MustParsePrefix("bbff:8199:4e2f:b4ba:72ad:8fbb:a54e:bedd/56"),
 			updateIP:   netip.MustParseAddr("e4db:af36:82e:1221:1b" + "ad:8fbb:a54e:bedd"),
 		},
+		"suffix_60": {
+			publicIP:   netip.MustParseAddr("e4db:af36:82e:1221:1b7f:2f54:6e9e:5e5f"),
+			ipv6Suffix: netip.MustParsePrefix("0:0:0:0:fbcd::1/60"),
+			updateIP:   netip.MustParseAddr("e4db:af36:82e:1221:1bcd::1"),
+		},
 		"suffix_48": {
 			publicIP:   netip.MustParseAddr("e4db:af36:82e:1221:1b7f:2f54:6e9e:5e5f"),
 			ipv6Suffix: netip.MustParsePrefix("bbff:8199:4e2f:b4ba:72ad:8fbb:a54e:bedd/48"),
@@ -52,9 +52,15 @@ func (lrt *loggingRoundTripper) RoundTrip(request *http.Request) (
 
 	response, err = lrt.proxied.RoundTrip(request)
 	if err != nil {
+		// transport errors are classified as transient or not
+		// by the IsRetryable function of the provider errors package.
 		return response, err
 	}
 
+	if response.Body != nil {
+		response.Body = newLimitedBody(response.Body, maxResponseBodySize)
+	}
+
 	lrt.logger.Debug(responseToString(response))
 
 	return response, nil
@@ -106,6 +112,14 @@ func readAndResetBody(body io.ReadCloser) (
 	b, err := io.ReadAll(body)
 	if err != nil {
 		bodyString = "error reading body: " + err.Error()
+		// replay the data read and the error to the body reader
+		newBody = struct {
+			io.Reader
+			io.Closer
+		}{
+			Reader: io.MultiReader(bytes.NewReader(b), &errorReader{err: err}),
+			Closer: body,
+		}
 	} else {
 		bodyString = utils.ToSingleLine(string(b))
 		_ = body.Close()
@@ -0,0 +1,69 @@
+package update
+
+import (
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/logging"
+	"github.com/qdm12/ddns-updater/internal/records"
+)
+
+// Update results logged, using the dynamic DNS
+// protocol wording for unchanged records.
+const (
+	resultSuccess  = "success"
+	resultNoChange = "nochg"
+	resultFail     = "fail"
+)
+
+// logOutcome logs the outcome of a record update with the same fields
+// for successful, unchanged and failed updates, so they can be queried
+// consistently once ingested from JSON logs. The record is zero valued
+// if it could not be read from the database.
+func (u *Updater) logOutcome(record records.Record, ips, result string,
+	err error, duration time.Duration) {
+	if err != nil {
+		result = resultFail
+	}
+
+	message := "record updated"
+	switch result {
+	case resultNoChange:
+		message = "record unchanged"
+	case resultFail:
+		message = "record update failed"
+	}
+
+	var fields []logging.Field
+	if record.Provider != nil {
+		fields = append(fields,
+			logging.Field{Key: "provider", Value: string(record.Settings.ProviderName)},
+			logging.Field{Key: "domain", Value: record.Provider.Domain()},
+			logging.Field{Key: "host", Value: record.Provider.Host()},
+			logging.Field{Key: "type", Value: record.Settings.RecordType},
+			logging.Field{Key: "value", Value: record.Settings.Content})
+	}
+	fields = append(fields,
+		logging.Field{Key: "ip", Value: ips},
+		logging.Field{Key: "result", Value: result})
+	if err != nil {
+		fields = append(fields, logging.Field{Key: "error", Value: err.Error()})
+	}
+	fields = append(fields, logging.Field{Key: "duration", Value: duration.String()})
+
+	line := logging.Message(message, fields...)
+	if result == resultFail {
+		u.logger.Error(line)
+		return
+	}
+	u.logger.Info(line)
+}
+
+func ipsToString(ips []netip.Addr) string {
+	ipStrings := make([]string, len(ips))
+	for i, ip := range ips {
+		ipStrings[i] = ip.String()
+	}
+	return strings.Join(ipStrings, ",")
+}
@@ -0,0 +1,99 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	settingserrors "github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/records"
+)
+
+// updateWithRetries updates the record provider with the IP addresses given,
+// retrying with an exponential backoff if the update fails with a transient
+// error, as configured by the record retry settings.
+func (u *Updater) updateWithRetries(ctx context.Context, record records.Record,
+	ips []netip.Addr) (newIPs []netip.Addr, err error) {
+	err = u.withRetries(ctx, record, func(ctx context.Context, client *http.Client) (err error) {
+		newIPs, err = updateProvider(ctx, record.Provider, client, ips)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newIPs, nil
+}
+
+// withRetries runs the provider call given with the record client, waiting
+// for the provider rate limit before each attempt and limiting each attempt
+// to the record HTTP timeout if it is set, and retrying with an exponential
+// backoff if it fails with a transient error, as configured by the record
+// retry settings.
+func (u *Updater) withRetries(ctx context.Context, record records.Record,
+	call func(ctx context.Context, client *http.Client) (err error)) (err error) {
+	settings := record.Settings.Retry
+	client := u.recordClient(record)
+	for attempt := uint(1); ; attempt++ {
+		err = u.rateLimiters.Wait(ctx, record.Settings.ProviderName, record.Settings.RateLimit)
+		if err != nil {
+			return fmt.Errorf("waiting for provider rate limit: %w", err)
+		}
+
+		start := u.timeNow()
+		err = callWithTimeout(ctx, record.Settings.HTTPTimeout, client, call)
+		u.metrics.UpdateAttempt(record.Settings.ProviderName, u.timeNow().Sub(start), err)
+		if err == nil || attempt >= settings.MaxAttempts ||
+			!settingserrors.IsRetryable(err) {
+			return err
+		}
+
+		delay := backoffDelay(settings, attempt, rand.Int64N)
+		u.logger.Debug(fmt.Sprintf("update attempt %d of %d for %s failed: %s; retrying in %s",
+			attempt, settings.MaxAttempts, record.Provider, err, delay))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (retry aborted: %w)", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// callWithTimeout runs the provider call given, limiting it
+// to the timeout given if it is positive.
+func callWithTimeout(ctx context.Context, timeout time.Duration, client *http.Client,
+	call func(ctx context.Context, client *http.Client) (err error)) (err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return call(ctx, client)
+}
+
+// backoffDelay returns the delay to wait before the next update attempt,
+// given the number of attempts already made. The delay grows exponentially
+// from the base delay and is capped by the maximum delay, and is then
+// randomized between half of it and all of it to spread out retries.
+func backoffDelay(settings models.RetrySettings, attempt uint,
+	randInt64N func(n int64) int64) (delay time.Duration) {
+	exponent := float64(attempt - 1)
+	backoff := float64(settings.BaseDelay) * math.Pow(settings.Multiplier, exponent)
+	if backoff > float64(settings.MaxDelay) {
+		backoff = float64(settings.MaxDelay)
+	}
+	delay = time.Duration(backoff)
+
+	half := delay / 2 //nolint:gomnd
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(randInt64N(int64(delay-half)+1))
+}
@@ -0,0 +1,72 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_backoffDelay(t *testing.T) {
+	t.Parallel()
+
+	settings := models.RetrySettings{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+		MaxDelay:    5 * time.Second,
+		Multiplier:  2,
+	}
+	noJitter := func(n int64) int64 { return n - 1 }
+	noDelayJitter := func(int64) int64 { return 0 }
+
+	testCases := map[string]struct {
+		settings   models.RetrySettings
+		attempt    uint
+		randInt64N func(n int64) int64
+		delay      time.Duration
+	}{
+		"first_attempt": {
+			settings:   settings,
+			attempt:    1,
+			randInt64N: noJitter,
+			delay:      time.Second,
+		},
+		"second_attempt": {
+			settings:   settings,
+			attempt:    2,
+			randInt64N: noJitter,
+			delay:      2 * time.Second,
+		},
+		"capped_by_max_delay": {
+			settings:   settings,
+			attempt:    4,
+			randInt64N: noJitter,
+			delay:      5 * time.Second,
+		},
+		"minimum_jitter": {
+			settings:   settings,
+			attempt:    2,
+			randInt64N: noDelayJitter,
+			delay:      time.Second,
+		},
+		"zero_base_delay": {
+			settings: models.RetrySettings{
+				Multiplier: 2,
+			},
+			attempt:    3,
+			randInt64N: noJitter,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			delay := backoffDelay(testCase.settings, testCase.attempt, testCase.randInt64N)
+
+			assert.Equal(t, testCase.delay, delay)
+		})
+	}
+}
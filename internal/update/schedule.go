@@ -0,0 +1,101 @@
+package update
+
+import (
+	"time"
+
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+)
+
+// recordInterval returns the interval between update checks of the
+// record, defaulting to the runner period if the record has none.
+func (r *Runner) recordInterval(record librecords.Record) time.Duration {
+	if record.Settings.Interval > 0 {
+		return record.Settings.Interval
+	}
+	return r.period
+}
+
+// nextDueAfter returns the next time the record is due for an update
+// check after the time given, using its cron schedule if set and its
+// interval otherwise, with a random jitter added. A record backed off
+// after consecutive failures is not due before its backoff interval.
+func (r *Runner) nextDueAfter(record librecords.Record, id uint, now time.Time) time.Time {
+	cycle := r.recordInterval(record)
+	next := now.Add(cycle)
+	if record.Settings.Cron != nil {
+		if cronNext := record.Settings.Cron.Next(now); !cronNext.IsZero() {
+			next = cronNext
+			if afterNext := record.Settings.Cron.Next(cronNext); !afterNext.IsZero() {
+				cycle = afterNext.Sub(cronNext)
+			}
+		}
+	}
+	if backoff := r.backoffInterval(id, cycle); backoff > 0 {
+		next = maxTime(next, now.Add(backoff))
+	}
+	return next.Add(r.randomJitter(cycle))
+}
+
+// randomJitter returns a random jitter between 0 and the runner jitter,
+// capped to half of the cycle given so a record never skips a cycle.
+// It is re-randomized on each call.
+func (r *Runner) randomJitter(cycle time.Duration) time.Duration {
+	maxJitter := min(r.jitter, cycle/2) //nolint:gomnd
+	if maxJitter <= 0 {
+		return 0
+	}
+	return time.Duration(r.randInt64N(int64(maxJitter) + 1))
+}
+
+// untilNextDue returns the duration until the next record is due for
+// an update check, scheduling records not scheduled yet at their next
+// due time. It must only be called from the Run goroutine.
+func (r *Runner) untilNextDue(records []librecords.Record, now time.Time) (duration time.Duration) {
+	duration = r.period
+	for i, record := range records {
+		id := uint(i)
+		nextDue, ok := r.nextDue[id]
+		if !ok {
+			nextDue = r.nextDueAfter(record, id, now)
+			r.nextDue[id] = nextDue
+		}
+		duration = min(duration, nextDue.Sub(now))
+	}
+	return max(duration, 0)
+}
+
+// dueRecordIDs returns the IDs of the records due for an update check.
+// It must only be called from the Run goroutine.
+func (r *Runner) dueRecordIDs(records []librecords.Record, now time.Time) (ids map[uint]struct{}) {
+	ids = make(map[uint]struct{})
+	for i := range records {
+		id := uint(i)
+		nextDue, ok := r.nextDue[id]
+		if ok && !nextDue.After(now) {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids
+}
+
+// reschedule sets the next due time of the records with the IDs given,
+// or of all records if ids is nil, to their next due time.
+// It must only be called from the Run goroutine.
+func (r *Runner) reschedule(records []librecords.Record, ids map[uint]struct{}, now time.Time) {
+	for i, record := range records {
+		id := uint(i)
+		if ids != nil {
+			if _, ok := ids[id]; !ok {
+				continue
+			}
+		}
+		r.nextDue[id] = r.nextDueAfter(record, id, now)
+	}
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,104 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/cron"
+	"github.com/qdm12/ddns-updater/internal/models"
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Runner_schedule(t *testing.T) {
+	t.Parallel()
+
+	runner := &Runner{
+		period:  time.Hour,
+		nextDue: make(map[uint]time.Time),
+	}
+	records := []librecords.Record{
+		{Settings: models.RecordSettings{Interval: time.Minute}},
+		{}, // uses the runner period
+	}
+	start := time.Unix(0, 0)
+
+	duration := runner.untilNextDue(records, start)
+	assert.Equal(t, time.Minute, duration)
+	assert.Empty(t, runner.dueRecordIDs(records, start))
+
+	now := start.Add(time.Minute)
+	dueIDs := runner.dueRecordIDs(records, now)
+	assert.Equal(t, map[uint]struct{}{0: {}}, dueIDs)
+
+	runner.reschedule(records, dueIDs, now)
+	duration = runner.untilNextDue(records, now)
+	assert.Equal(t, time.Minute, duration)
+
+	now = start.Add(time.Hour)
+	dueIDs = runner.dueRecordIDs(records, now)
+	assert.Equal(t, map[uint]struct{}{0: {}, 1: {}}, dueIDs)
+
+	runner.reschedule(records, nil, now)
+	assert.Equal(t, map[uint]time.Time{
+		0: now.Add(time.Minute),
+		1: now.Add(time.Hour),
+	}, runner.nextDue)
+}
+
+func Test_Runner_nextDueAfter(t *testing.T) {
+	t.Parallel()
+
+	runner := &Runner{period: time.Hour}
+	schedule, err := cron.Parse("0 * * * *")
+	require.NoError(t, err)
+	record := librecords.Record{
+		Settings: models.RecordSettings{Interval: time.Minute, Cron: schedule},
+	}
+	now := time.Date(2024, time.January, 1, 10, 20, 0, 0, time.UTC)
+
+	next := runner.nextDueAfter(record, 0, now)
+
+	assert.Equal(t, time.Date(2024, time.January, 1, 11, 0, 0, 0, time.UTC), next)
+}
+
+func Test_Runner_randomJitter(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		jitter       time.Duration
+		cycle        time.Duration
+		jitterResult time.Duration
+	}{
+		"disabled": {
+			cycle: time.Minute,
+		},
+		"below_half_cycle": {
+			jitter:       10 * time.Second,
+			cycle:        time.Minute,
+			jitterResult: 10 * time.Second,
+		},
+		"capped_to_half_cycle": {
+			jitter:       time.Hour,
+			cycle:        time.Minute,
+			jitterResult: 30 * time.Second,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := &Runner{
+				jitter:     testCase.jitter,
+				randInt64N: func(n int64) int64 { return n - 1 }, // maximum value
+			}
+
+			jitter := runner.randomJitter(testCase.cycle)
+
+			assert.Equal(t, testCase.jitterResult, jitter)
+		})
+	}
+}
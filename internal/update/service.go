@@ -3,7 +3,9 @@ package update
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/qdm12/ddns-updater/internal/constants"
@@ -14,34 +16,57 @@ import (
 )
 
 type Runner struct {
-	period      time.Duration
-	db          Database
-	updater     UpdaterInterface
-	force       chan struct{}
-	forceResult chan []error
-	cooldown    time.Duration
-	resolver    LookupIPer
-	ipGetter    PublicIPFetcher
-	logger      Logger
-	timeNow     func() time.Time
-	hioClient   HealthchecksIOClient
+	period  time.Duration
+	db      Database
+	updater UpdaterInterface
+	// force receives requests to force an update of records.
+	force chan forceRequest
+	// reload receives new records to replace the current records with.
+	reload    chan recordsReload
+	cooldown  time.Duration
+	resolver  LookupIPer
+	ipGetter  PublicIPFetcher
+	logger    Logger
+	timeNow   func() time.Time
+	hioClient HealthchecksIOClient
+	// nextDue maps record IDs to the next time they are due for
+	// an update check, and is only accessed in the Run goroutine.
+	nextDue map[uint]time.Time
+	// jitter is the maximum random offset added to each record
+	// next due time, and is zero to disable it.
+	jitter     time.Duration
+	randInt64N func(n int64) int64
+	// shutdownGracePeriod is the duration given to in-flight updates
+	// to complete once Run is asked to stop, before canceling them.
+	shutdownGracePeriod time.Duration
+	failureBackoff      models.FailureBackoffSettings
+	// failures maps record IDs to their number of consecutive failed
+	// updates, and is only accessed in the Run goroutine.
+	failures map[uint]uint
 }
 
 func NewRunner(db Database, updater UpdaterInterface, ipGetter PublicIPFetcher,
-	period time.Duration, cooldown time.Duration, logger Logger, resolver LookupIPer,
-	timeNow func() time.Time, hioClient HealthchecksIOClient) *Runner {
+	period, cooldown, jitter, shutdownGracePeriod time.Duration,
+	failureBackoff models.FailureBackoffSettings, logger Logger,
+	resolver LookupIPer, timeNow func() time.Time, hioClient HealthchecksIOClient) *Runner {
 	return &Runner{
-		period:      period,
-		db:          db,
-		updater:     updater,
-		force:       make(chan struct{}),
-		forceResult: make(chan []error),
-		cooldown:    cooldown,
-		resolver:    resolver,
-		ipGetter:    ipGetter,
-		logger:      logger,
-		timeNow:     timeNow,
-		hioClient:   hioClient,
+		period:              period,
+		db:                  db,
+		updater:             updater,
+		force:               make(chan forceRequest),
+		reload:              make(chan recordsReload),
+		cooldown:            cooldown,
+		resolver:            resolver,
+		ipGetter:            ipGetter,
+		logger:              logger,
+		timeNow:             timeNow,
+		hioClient:           hioClient,
+		nextDue:             make(map[uint]time.Time),
+		jitter:              jitter,
+		randInt64N:          rand.Int64N,
+		shutdownGracePeriod: shutdownGracePeriod,
+		failureBackoff:      failureBackoff,
+		failures:            make(map[uint]uint),
 	}
 }
 
@@ -85,6 +110,9 @@ func (r *Runner) lookupIPs(ctx context.Context, hostname string) (
 
 func doIPVersion(records []librecords.Record) (doIP, doIPv4, doIPv6 bool) {
 	for _, record := range records {
+		if isValueRecord(record) {
+			continue
+		}
 		switch record.Provider.IPVersion() {
 		case ipversion.IP4or6:
 			doIP = true
@@ -92,6 +120,8 @@ func doIPVersion(records []librecords.Record) (doIP, doIPv4, doIPv6 bool) {
 			doIPv4 = true
 		case ipversion.IP6:
 			doIPv6 = true
+		case ipversion.IP4and6:
+			doIPv4, doIPv6 = true, true
 		}
 		if doIP && doIPv4 && doIPv6 {
 			return true, true, true
@@ -124,10 +154,23 @@ func (r *Runner) getNewIPs(ctx context.Context, doIP, doIPv4, doIPv6 bool) (
 	return ip, ipv4, ipv6, errors
 }
 
+// getRecordIDsToUpdate returns the IDs of the records to update with the
+// public IP addresses given. If onlyIDs is not nil, only records with an ID
+// present in onlyIDs are checked. If refresh is true, the skip unchanged
+// setting of the records is ignored.
 func (r *Runner) getRecordIDsToUpdate(ctx context.Context, records []librecords.Record,
-	ip, ipv4, ipv6 netip.Addr) (recordIDs map[uint]struct{}) {
+	onlyIDs map[uint]struct{}, ip, ipv4, ipv6 netip.Addr, refresh bool) (
+	recordIDs map[uint]struct{}) {
 	recordIDs = make(map[uint]struct{})
 	for i, record := range records {
+		if isValueRecord(record) {
+			continue
+		} else if _, ok := onlyIDs[uint(i)]; onlyIDs != nil && !ok {
+			continue
+		}
+		if refresh {
+			record.Settings.SkipUnchanged = false // record is a copy
+		}
 		shouldUpdate := r.shouldUpdateRecord(ctx, record, ip, ipv4, ipv6)
 		if shouldUpdate {
 			id := uint(i)
@@ -137,6 +180,38 @@ func (r *Runner) getRecordIDsToUpdate(ctx context.Context, records []librecords.
 	return recordIDs
 }
 
+// getValueRecordIDsToUpdate returns the IDs of the records set to a fixed
+// value, such as TXT records, which were not set successfully yet. If
+// onlyIDs is not nil, only records with an ID present in onlyIDs are returned.
+func (r *Runner) getValueRecordIDsToUpdate(records []librecords.Record,
+	onlyIDs map[uint]struct{}) (recordIDs []uint) {
+	now := r.timeNow()
+	for i, record := range records {
+		id := uint(i)
+		if !isValueRecord(record) {
+			continue
+		} else if _, ok := onlyIDs[id]; onlyIDs != nil && !ok {
+			continue
+		}
+
+		switch record.Status {
+		case constants.SUCCESS, constants.UPTODATE:
+			continue
+		}
+
+		const banPeriod = time.Hour
+		isWithinBanPeriod := record.LastBan != nil && now.Sub(*record.LastBan) < banPeriod
+		if isWithinBanPeriod {
+			r.logger.Info(fmt.Sprintf(
+				"record %s is within ban period of %s started at %s, skipping update",
+				record.Provider, banPeriod, *record.LastBan))
+			continue
+		}
+		recordIDs = append(recordIDs, id)
+	}
+	return recordIDs
+}
+
 func (r *Runner) shouldUpdateRecord(ctx context.Context, record librecords.Record,
 	ip, ipv4, ipv6 netip.Addr) (update bool) {
 	now := r.timeNow()
@@ -158,8 +233,12 @@ func (r *Runner) shouldUpdateRecord(ctx context.Context, record librecords.Recor
 		return false
 	}
 
-	hostname := record.Provider.BuildDomainName()
 	ipVersion := record.Provider.IPVersion()
+	if ipVersion == ipversion.IP4and6 {
+		return r.shouldUpdateDualStackRecord(ctx, record, ipv4, ipv6)
+	}
+
+	hostname := record.Provider.BuildDomainName()
 	publicIP := getIPMatchingVersion(ip, ipv4, ipv6, ipVersion)
 
 	if !publicIP.IsValid() {
@@ -170,6 +249,10 @@ func (r *Runner) shouldUpdateRecord(ctx context.Context, record librecords.Recor
 		publicIP = ipv6WithSuffix(publicIP, record.Provider.IPv6Suffix())
 	}
 
+	if r.isUnchanged(record, now, publicIP) {
+		return false
+	}
+
 	if record.Provider.Proxied() {
 		lastIP := record.History.GetCurrentIP() // can be nil
 		return r.shouldUpdateRecordNoLookup(hostname, ipVersion, lastIP, publicIP)
@@ -177,6 +260,36 @@ func (r *Runner) shouldUpdateRecord(ctx context.Context, record librecords.Recor
 	return r.shouldUpdateRecordWithLookup(ctx, hostname, ipVersion, publicIP)
 }
 
+// shouldUpdateDualStackRecord returns true if either of the IPv4 or IPv6
+// addresses of the record differs from the public IP address of the same
+// family, for records configured to update both addresses.
+func (r *Runner) shouldUpdateDualStackRecord(ctx context.Context, record librecords.Record,
+	ipv4, ipv6 netip.Addr) (update bool) {
+	hostname := record.Provider.BuildDomainName()
+	if !ipv4.IsValid() && !ipv6.IsValid() {
+		r.logger.Warn(fmt.Sprintf("Skipping update for %s because neither ipv4 nor ipv6 address was found",
+			hostname))
+		return false
+	} else if ipv6.IsValid() {
+		ipv6 = ipv6WithSuffix(ipv6, record.Provider.IPv6Suffix())
+	}
+
+	if r.isUnchanged(record, r.timeNow(), ipv4, ipv6) {
+		return false
+	}
+
+	var updateIPv4, updateIPv6 bool
+	if record.Provider.Proxied() {
+		lastIPv4, lastIPv6 := record.History.GetCurrentIPs() // can be invalid
+		updateIPv4 = ipv4.IsValid() && r.shouldUpdateRecordNoLookup(hostname, ipversion.IP4, lastIPv4, ipv4)
+		updateIPv6 = ipv6.IsValid() && r.shouldUpdateRecordNoLookup(hostname, ipversion.IP6, lastIPv6, ipv6)
+	} else {
+		updateIPv4 = ipv4.IsValid() && r.shouldUpdateRecordWithLookup(ctx, hostname, ipversion.IP4, ipv4)
+		updateIPv6 = ipv6.IsValid() && r.shouldUpdateRecordWithLookup(ctx, hostname, ipversion.IP6, ipv6)
+	}
+	return updateIPv4 || updateIPv6
+}
+
 func (r *Runner) shouldUpdateRecordNoLookup(hostname string, ipVersion ipversion.IPVersion,
 	lastIP, publicIP netip.Addr) (update bool) {
 	ipKind := ipVersionToIPKind(ipVersion)
@@ -230,7 +343,32 @@ func getIPMatchingVersion(ip, ipv4, ipv6 netip.Addr, ipVersion ipversion.IPVersi
 	return netip.Addr{}
 }
 
-func setInitialUpToDateStatus(db Database, id uint, updateIP netip.Addr, now time.Time) error {
+// getUpdateIPs returns the valid public IP addresses to update the record
+// with, with IPv4-mapped addresses unmapped and the IPv6 suffix applied to
+// IPv6 addresses. It returns both the IPv4 and IPv6 addresses for records
+// configured to update both.
+func getUpdateIPs(record librecords.Record, ip, ipv4, ipv6 netip.Addr) (updateIPs []netip.Addr) {
+	ipVersion := record.Provider.IPVersion()
+	candidates := []netip.Addr{getIPMatchingVersion(ip, ipv4, ipv6, ipVersion)}
+	if ipVersion == ipversion.IP4and6 {
+		candidates = []netip.Addr{ipv4, ipv6}
+	}
+
+	for _, candidate := range candidates {
+		// IPv4-mapped IPv6 addresses match the IPv4 version, so unmap
+		// them since providers tell IPv4 and IPv6 apart with Is6.
+		candidate = candidate.Unmap()
+		if !candidate.IsValid() {
+			continue
+		} else if candidate.Is6() {
+			candidate = ipv6WithSuffix(candidate, record.Provider.IPv6Suffix())
+		}
+		updateIPs = append(updateIPs, candidate)
+	}
+	return updateIPs
+}
+
+func setInitialUpToDateStatus(db Database, id uint, updateIPs []netip.Addr, now time.Time) error {
 	record, err := db.Select(id)
 	if err != nil {
 		return err
@@ -238,10 +376,12 @@ func setInitialUpToDateStatus(db Database, id uint, updateIP netip.Addr, now tim
 	record.Status = constants.UPTODATE
 	record.Time = now
 	if !record.History.GetCurrentIP().IsValid() {
-		record.History = append(record.History, models.HistoryEvent{
-			IP:   updateIP,
-			Time: now,
-		})
+		for _, updateIP := range updateIPs {
+			record.History = append(record.History, models.HistoryEvent{
+				IP:   updateIP,
+				Time: now,
+			})
+		}
 	}
 	return db.Update(id, record)
 }
@@ -257,9 +397,22 @@ func setInitialPublicIPFailStatus(db Database, id uint, now time.Time) error {
 	return db.Update(id, record)
 }
 
-func (r *Runner) updateNecessary(ctx context.Context) (errors []error) {
+// updateNecessary updates the records requiring an update. If onlyIDs is not
+// nil, only the records with an ID present in onlyIDs are updated. If refresh
+// is true, records are updated even if configured to skip unchanged updates.
+func (r *Runner) updateNecessary(ctx context.Context, onlyIDs map[uint]struct{},
+	refresh bool) (errors []error) {
 	records := r.db.SelectAll()
-	doIP, doIPv4, doIPv6 := doIPVersion(records)
+	recordsToCheck := records
+	if onlyIDs != nil {
+		recordsToCheck = make([]librecords.Record, 0, len(onlyIDs))
+		for id := range onlyIDs {
+			if id < uint(len(records)) {
+				recordsToCheck = append(recordsToCheck, records[id])
+			}
+		}
+	}
+	doIP, doIPv4, doIPv6 := doIPVersion(recordsToCheck)
 	r.logger.Debug(fmt.Sprintf("configured to fetch IP: v4 or v6: %t, v4: %t, v6: %t", doIP, doIPv4, doIPv6))
 	ip, ipv4, ipv6, errors := r.getNewIPs(ctx, doIP, doIPv4, doIPv6)
 	r.logger.Debug(fmt.Sprintf("your public IP address are: v4 or v6: %s, v4: %s, v6: %s", ip, ipv4, ipv6))
@@ -267,7 +420,7 @@ func (r *Runner) updateNecessary(ctx context.Context) (errors []error) {
 		r.logger.Error(err.Error())
 	}
 
-	recordIDs := r.getRecordIDsToUpdate(ctx, records, ip, ipv4, ipv6)
+	recordIDs := r.getRecordIDsToUpdate(ctx, records, onlyIDs, ip, ipv4, ipv6, refresh)
 
 	// Current time is used to set initial states for records already
 	// up to date or in the fail state due to the public IP not found.
@@ -278,13 +431,14 @@ func (r *Runner) updateNecessary(ctx context.Context) (errors []error) {
 	for i, record := range records {
 		id := uint(i)
 		_, requireUpdate := recordIDs[id]
-		if requireUpdate || record.Status != constants.UNSET {
+		if requireUpdate || record.Status != constants.UNSET || isValueRecord(record) {
+			continue
+		} else if _, ok := onlyIDs[id]; onlyIDs != nil && !ok {
 			continue
 		}
 
-		ipVersion := record.Provider.IPVersion()
-		updateIP := getIPMatchingVersion(ip, ipv4, ipv6, ipVersion)
-		if !updateIP.IsValid() {
+		updateIPs := getUpdateIPs(record, ip, ipv4, ipv6)
+		if len(updateIPs) == 0 {
 			// warning was already logged in getRecordIDsToUpdate
 			err := setInitialPublicIPFailStatus(r.db, id, now)
 			if err != nil {
@@ -293,11 +447,9 @@ func (r *Runner) updateNecessary(ctx context.Context) (errors []error) {
 				r.logger.Error(err.Error())
 			}
 			continue
-		} else if updateIP.Is6() {
-			updateIP = ipv6WithSuffix(updateIP, record.Provider.IPv6Suffix())
 		}
 
-		err := setInitialUpToDateStatus(r.db, id, updateIP, now)
+		err := setInitialUpToDateStatus(r.db, id, updateIPs, now)
 		if err != nil {
 			err = fmt.Errorf("setting initial up to date status: %w", err)
 			errors = append(errors, err)
@@ -306,16 +458,46 @@ func (r *Runner) updateNecessary(ctx context.Context) (errors []error) {
 	}
 	for id := range recordIDs {
 		record := records[id]
-		updateIP := getIPMatchingVersion(ip, ipv4, ipv6, record.Provider.IPVersion())
-		// Note: each record id has a matching valid public IP address.
-		if updateIP.Is6() {
-			updateIP = ipv6WithSuffix(updateIP, record.Provider.IPv6Suffix())
-		}
-		r.logger.Info("Updating record " + record.Provider.String() + " to use " + updateIP.String())
-		err := r.updater.Update(ctx, id, updateIP)
+		// Note: each record id has at least one matching valid public IP address.
+		updateIPs := getUpdateIPs(record, ip, ipv4, ipv6)
+		err := checkIPVersion(record, updateIPs)
 		if err != nil {
 			errors = append(errors, err)
 			r.logger.Error(err.Error())
+			continue
+		}
+		if record.Settings.DryRun {
+			r.logDryRun(record, updateIPs)
+			continue
+		}
+		if len(updateIPs) == 2 { //nolint:gomnd
+			r.logger.Info("Updating record " + record.Provider.String() + " to use " +
+				updateIPs[0].String() + " and " + updateIPs[1].String())
+			err = r.updater.UpdateBoth(ctx, id, updateIPs[0], updateIPs[1])
+		} else {
+			r.logger.Info("Updating record " + record.Provider.String() + " to use " + updateIPs[0].String())
+			err = r.updater.Update(ctx, id, updateIPs[0])
+		}
+		r.recordUpdateResult(record, id, err)
+		if err != nil {
+			// the error is already logged by the updater
+			errors = append(errors, err)
+		}
+	}
+	for _, id := range r.getValueRecordIDsToUpdate(records, onlyIDs) {
+		record := records[id]
+		if record.Settings.DryRun {
+			r.logger.Info("Dry run: would set " + record.Settings.RecordType +
+				" record " + record.Provider.String() + " to " + record.Settings.Content)
+			continue
+		}
+		r.logger.Info("Setting " + record.Settings.RecordType + " record " +
+			record.Provider.String() + " to " + record.Settings.Content)
+		err := r.updater.UpdateValue(ctx, id)
+		r.recordUpdateResult(record, id, err)
+		if err != nil {
+			// the error is already logged by the updater
+			errors = append(errors, err)
 		}
 	}
 
@@ -332,29 +514,258 @@ func (r *Runner) updateNecessary(ctx context.Context) (errors []error) {
 	return errors
 }
 
+// logDryRun logs the update that would be done for the record
+// if it was not in dry run mode.
+func (r *Runner) logDryRun(record librecords.Record, updateIPs []netip.Addr) {
+	currentIPv4, currentIPv6 := record.History.GetCurrentIPs()
+	changes := make([]string, len(updateIPs))
+	for i, ip := range updateIPs {
+		currentIP := currentIPv4
+		if ip.Is6() {
+			currentIP = currentIPv6
+		}
+		if currentIP.IsValid() {
+			changes[i] = currentIP.String() + " to " + ip.String()
+		} else {
+			changes[i] = "no known IP address to " + ip.String()
+		}
+	}
+	r.logger.Info("Dry run: would update record " + record.Provider.String() +
+		" from " + strings.Join(changes, " and from "))
+}
+
+// Run checks each record for an update when it is due, according to its
+// own interval, and runs forced updates, until the context is canceled.
+// Once the context is canceled, no new update is started, and an update
+// in progress is given the shutdown grace period to complete before its
+// context is canceled.
 func (r *Runner) Run(ctx context.Context, done chan<- struct{}) {
 	defer close(done)
-	ticker := time.NewTicker(r.period)
+
+	updateCtx, cancelUpdates := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelUpdates()
+	stopGracePeriod := context.AfterFunc(ctx, func() {
+		time.AfterFunc(r.shutdownGracePeriod, cancelUpdates)
+	})
+	defer stopGracePeriod()
+
+	timer := time.NewTimer(r.untilNextDue(r.db.SelectAll(), r.timeNow()))
 	for {
 		select {
-		case <-ticker.C:
-			r.updateNecessary(ctx)
-		case <-r.force:
-			r.forceResult <- r.updateNecessary(ctx)
+		case <-timer.C:
+			if ctx.Err() != nil {
+				return
+			}
+			dueIDs := r.dueRecordIDs(r.db.SelectAll(), r.timeNow())
+			if len(dueIDs) > 0 {
+				r.updateNecessary(updateCtx, dueIDs, false)
+				r.reschedule(r.db.SelectAll(), dueIDs, r.timeNow())
+			}
+		case request := <-r.force:
+			if ctx.Err() != nil {
+				timer.Stop()
+				request.result <- []error{ctx.Err()}
+				return
+			}
+			// Records are selected here since their IDs change when
+			// records are reloaded, which happens in this goroutine.
+			onlyIDs := selectRecordIDs(r.db.SelectAll(), request.selectRecord)
+			if onlyIDs != nil && len(onlyIDs) == 0 {
+				request.result <- nil // selected records were removed
+			} else {
+				r.invalidateIPCache()
+				request.result <- r.updateNecessary(updateCtx, onlyIDs, request.refresh)
+				r.reschedule(r.db.SelectAll(), onlyIDs, r.timeNow())
+			}
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case reload := <-r.reload:
+			if ctx.Err() != nil {
+				timer.Stop()
+				reload.result <- []error{ctx.Err()}
+				return
+			}
+			reload.result <- r.reloadRecords(updateCtx, reload)
+			if !timer.Stop() {
+				<-timer.C
+			}
 		case <-ctx.Done():
-			ticker.Stop()
+			timer.Stop()
 			return
 		}
+		timer.Reset(r.untilNextDue(r.db.SelectAll(), r.timeNow()))
 	}
 }
 
+// invalidateIPCache clears the public IP fetcher cache, if any,
+// so forced updates always use freshly fetched IP addresses.
+func (r *Runner) invalidateIPCache() {
+	cache, ok := r.ipGetter.(ipCacheInvalidator)
+	if ok {
+		cache.Invalidate()
+	}
+}
+
+// ForceUpdate updates all the records requiring an update now, and returns
+// once done. Since updates run in the Run goroutine, a forced update never runs
+// concurrently with a periodic update.
 func (r *Runner) ForceUpdate(ctx context.Context) (errs []error) {
-	r.force <- struct{}{}
+	return r.forceUpdate(ctx, nil, false)
+}
+
+// UpdateOnStart updates the records configured to be updated at program
+// start, even if they are configured to skip unchanged updates, and returns
+// once done. It is meant to be called once, at program start.
+func (r *Runner) UpdateOnStart(ctx context.Context) (errs []error) {
+	selectRecord := func(record librecords.Record) bool {
+		return record.Settings.UpdateOnStart
+	}
+	if len(selectRecordIDs(r.db.SelectAll(), selectRecord)) == 0 {
+		return nil
+	}
+	return r.forceUpdate(ctx, selectRecord, true)
+}
+
+// ForceUpdateRecords is like ForceUpdate but only for the records with
+// the keys given, where the key of a record is its provider String value.
+// Keys are used instead of record IDs since IDs change when records are
+// reloaded, and records no longer present are ignored.
+func (r *Runner) ForceUpdateRecords(ctx context.Context, keys []string) (errs []error) {
+	keysSet := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		keysSet[key] = struct{}{}
+	}
+	return r.forceUpdate(ctx, func(record librecords.Record) bool {
+		_, ok := keysSet[record.Provider.String()]
+		return ok
+	}, false)
+}
+
+type forceRequest struct {
+	// selectRecord returns true for the records to force an update for,
+	// and is nil to force an update for all records.
+	selectRecord func(record librecords.Record) bool
+	// refresh is true to update records even if they are
+	// configured to skip unchanged updates.
+	refresh bool
+	// result is buffered so the Run goroutine never blocks
+	// sending the result to a caller which stopped waiting.
+	result chan []error
+}
+
+// selectRecordIDs returns the set of IDs of the records for which
+// selectRecord returns true, or nil if selectRecord is nil.
+func selectRecordIDs(records []librecords.Record,
+	selectRecord func(record librecords.Record) bool) (ids map[uint]struct{}) {
+	if selectRecord == nil {
+		return nil
+	}
+	ids = make(map[uint]struct{})
+	for i, record := range records {
+		if selectRecord(record) {
+			ids[uint(i)] = struct{}{}
+		}
+	}
+	return ids
+}
+
+func (r *Runner) forceUpdate(ctx context.Context,
+	selectRecord func(record librecords.Record) bool, refresh bool) (errs []error) {
+	request := forceRequest{
+		selectRecord: selectRecord,
+		refresh:      refresh,
+		result:       make(chan []error, 1),
+	}
+	select {
+	case r.force <- request:
+	case <-ctx.Done():
+		return []error{ctx.Err()}
+	}
+
+	select {
+	case errs = <-request.result:
+	case <-ctx.Done():
+		errs = []error{ctx.Err()}
+	}
+	return errs
+}
+
+type recordsReload struct {
+	records []librecords.Record
+	// previousIDs maps the IDs of the new records kept from the
+	// current records to their current IDs.
+	previousIDs map[uint]uint
+	// result is buffered so the Run goroutine never blocks
+	// sending the result to a caller which stopped waiting.
+	result chan []error
+}
+
+// ReloadRecords replaces all the records with the records given, where
+// previousIDs maps the IDs of records kept unchanged to their current IDs.
+// Kept records are taken from the current records with their state and
+// schedule, so their entries in the records given are ignored. Other
+// records are updated if necessary right away. Since the replacement runs in the Run goroutine,
+// it never happens during an update.
+func (r *Runner) ReloadRecords(ctx context.Context, records []librecords.Record,
+	previousIDs map[uint]uint) (errs []error) {
+	reload := recordsReload{
+		records:     records,
+		previousIDs: previousIDs,
+		result:      make(chan []error, 1),
+	}
+	select {
+	case r.reload <- reload:
+	case <-ctx.Done():
+		return []error{ctx.Err()}
+	}
 
 	select {
-	case errs = <-r.forceResult:
+	case errs = <-reload.result:
 	case <-ctx.Done():
 		errs = []error{ctx.Err()}
 	}
 	return errs
 }
+
+// reloadRecords must only be called from the Run goroutine.
+func (r *Runner) reloadRecords(ctx context.Context, reload recordsReload) (errs []error) {
+	currentRecords := r.db.SelectAll()
+	for newID, previousID := range reload.previousIDs {
+		if previousID < uint(len(currentRecords)) {
+			reload.records[newID] = currentRecords[previousID]
+		}
+	}
+	r.db.ReplaceAll(reload.records)
+
+	nextDue := make(map[uint]time.Time, len(reload.previousIDs))
+	failures := make(map[uint]uint)
+	newIDs := make(map[uint]struct{})
+	for i := range reload.records {
+		id := uint(i)
+		previousID, ok := reload.previousIDs[id]
+		if !ok {
+			newIDs[id] = struct{}{}
+			continue
+		}
+		due, ok := r.nextDue[previousID]
+		if ok {
+			nextDue[id] = due
+		}
+		if count, ok := r.failures[previousID]; ok {
+			failures[id] = count
+		}
+	}
+	r.nextDue = nextDue
+	r.failures = failures
+	if remapper, ok := r.updater.(failuresRemapper); ok {
+		remapper.RemapFailures(reload.previousIDs)
+	}
+
+	if len(newIDs) == 0 {
+		return nil
+	}
+	errs = r.updateNecessary(ctx, newIDs, false)
+	r.reschedule(reload.records, newIDs, r.timeNow())
+	return errs
+}
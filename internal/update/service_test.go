@@ -0,0 +1,301 @@
+package update
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/constants"
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/njalla"
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedIPGetter struct {
+	ip netip.Addr
+}
+
+func (g fixedIPGetter) IP(context.Context) (netip.Addr, error)  { return g.ip, nil }
+func (g fixedIPGetter) IP4(context.Context) (netip.Addr, error) { return g.ip, nil }
+func (g fixedIPGetter) IP6(context.Context) (netip.Addr, error) { return netip.Addr{}, nil }
+
+type fixedResolver struct {
+	ip net.IP
+}
+
+func (r fixedResolver) LookupIP(context.Context, string, string) ([]net.IP, error) {
+	return []net.IP{r.ip}, nil
+}
+
+// recordingUpdater records the IDs of the records updated.
+type recordingUpdater struct {
+	updatedIDs []uint
+}
+
+func (u *recordingUpdater) Update(_ context.Context, id uint, _ netip.Addr) error {
+	u.updatedIDs = append(u.updatedIDs, id)
+	return nil
+}
+
+func (u *recordingUpdater) UpdateBoth(_ context.Context, id uint, _, _ netip.Addr) error {
+	u.updatedIDs = append(u.updatedIDs, id)
+	return nil
+}
+
+func (u *recordingUpdater) UpdateValue(_ context.Context, id uint) error {
+	u.updatedIDs = append(u.updatedIDs, id)
+	return nil
+}
+
+func Test_Runner_UpdateOnStart(t *testing.T) {
+	t.Parallel()
+
+	provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", "@",
+		ipversion.IP4, netip.Prefix{})
+	require.NoError(t, err)
+
+	now := time.Unix(100000, 0)
+	publicIP := netip.MustParseAddr("1.2.3.4")
+	history := []models.HistoryEvent{{IP: publicIP, Time: now.Add(-time.Hour)}}
+
+	testCases := map[string]struct {
+		updateOnStart bool
+		forceUpdate   bool
+		updatedIDs    []uint
+	}{
+		"update_on_start_ignores_skip_unchanged": {
+			updateOnStart: true,
+			updatedIDs:    []uint{0},
+		},
+		"update_on_start_disabled": {},
+		"forced_update_skips_unchanged": {
+			updateOnStart: true,
+			forceUpdate:   true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := models.RecordSettings{
+				SkipUnchanged: true,
+				UpdateOnStart: testCase.updateOnStart,
+			}
+			db := &fakeDatabase{records: []librecords.Record{
+				librecords.New(provider, settings, history),
+			}}
+			updater := &recordingUpdater{}
+			// The DNS record no longer points to the public IP address,
+			// for example after it was changed on the provider side.
+			resolver := fixedResolver{ip: net.IPv4(5, 6, 7, 8)}
+			timeNow := func() time.Time { return now }
+			runner := NewRunner(db, updater, fixedIPGetter{ip: publicIP}, time.Hour,
+				0, 0, 0, models.FailureBackoffSettings{}, noopLogger{},
+				resolver, timeNow, noopHealthchecksIO{})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go runner.Run(ctx, done)
+
+			var errs []error
+			if testCase.forceUpdate {
+				errs = runner.ForceUpdate(ctx)
+			} else {
+				errs = runner.UpdateOnStart(ctx)
+			}
+			cancel()
+			<-done
+
+			assert.Empty(t, errs)
+			assert.Equal(t, testCase.updatedIDs, updater.updatedIDs)
+		})
+	}
+}
+
+func Test_Runner_UpdateOnStart_otherRecordUntouched(t *testing.T) {
+	t.Parallel()
+
+	makeRecord := func(host string, updateOnStart bool) librecords.Record {
+		provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", host,
+			ipversion.IP4, netip.Prefix{})
+		require.NoError(t, err)
+		settings := models.RecordSettings{UpdateOnStart: updateOnStart}
+		return librecords.New(provider, settings, nil)
+	}
+	db := &fakeDatabase{records: []librecords.Record{
+		makeRecord("a", true),
+		makeRecord("b", false),
+	}}
+	updater := &recordingUpdater{}
+	// Both DNS records no longer point to the public IP address.
+	resolver := fixedResolver{ip: net.IPv4(5, 6, 7, 8)}
+	timeNow := func() time.Time { return time.Unix(100000, 0) }
+	runner := NewRunner(db, updater, fixedIPGetter{ip: netip.MustParseAddr("1.2.3.4")},
+		time.Hour, 0, 0, 0, models.FailureBackoffSettings{}, noopLogger{},
+		resolver, timeNow, noopHealthchecksIO{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go runner.Run(ctx, done)
+
+	errs := runner.UpdateOnStart(ctx)
+	cancel()
+	<-done
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []uint{0}, updater.updatedIDs)
+	record := db.records[1]
+	assert.Equal(t, constants.UNSET, record.Status)
+	assert.Empty(t, record.History)
+}
+
+func Test_getUpdateIPs(t *testing.T) {
+	t.Parallel()
+
+	ipv4 := netip.MustParseAddr("1.2.3.4")
+	ipv4Mapped := netip.MustParseAddr("::ffff:1.2.3.4")
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+
+	testCases := map[string]struct {
+		ipVersion ipversion.IPVersion
+		ip        netip.Addr
+		ipv4      netip.Addr
+		ipv6      netip.Addr
+		updateIPs []netip.Addr
+	}{
+		"ipv4": {
+			ipVersion: ipversion.IP4,
+			ipv4:      ipv4,
+			updateIPs: []netip.Addr{ipv4},
+		},
+		"ipv4_mapped": {
+			ipVersion: ipversion.IP4,
+			ipv4:      ipv4Mapped,
+			updateIPs: []netip.Addr{ipv4},
+		},
+		"ipv4_or_ipv6_mapped": {
+			ipVersion: ipversion.IP4or6,
+			ip:        ipv4Mapped,
+			updateIPs: []netip.Addr{ipv4},
+		},
+		"ipv4_and_ipv6_mapped": {
+			ipVersion: ipversion.IP4and6,
+			ipv4:      ipv4Mapped,
+			ipv6:      ipv6,
+			updateIPs: []netip.Addr{ipv4, ipv6},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", "@",
+				testCase.ipVersion, netip.Prefix{})
+			require.NoError(t, err)
+			record := librecords.New(provider, models.RecordSettings{}, nil)
+
+			updateIPs := getUpdateIPs(record, testCase.ip, testCase.ipv4, testCase.ipv6)
+
+			assert.Equal(t, testCase.updateIPs, updateIPs)
+			assert.NoError(t, checkIPVersion(record, updateIPs))
+		})
+	}
+}
+
+func Test_Runner_ForceUpdateRecords(t *testing.T) {
+	t.Parallel()
+
+	makeRecord := func(host string) librecords.Record {
+		provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", host,
+			ipversion.IP4, netip.Prefix{})
+		require.NoError(t, err)
+		return librecords.New(provider, models.RecordSettings{}, nil)
+	}
+	recordA, recordB := makeRecord("a"), makeRecord("b")
+
+	db := &fakeDatabase{records: []librecords.Record{recordA, recordB}}
+	updater := &recordingUpdater{}
+	resolver := fixedResolver{ip: net.IPv4(5, 6, 7, 8)}
+	timeNow := func() time.Time { return time.Unix(100000, 0) }
+	runner := NewRunner(db, updater, fixedIPGetter{ip: netip.MustParseAddr("1.2.3.4")},
+		time.Hour, 0, 0, 0, models.FailureBackoffSettings{}, noopLogger{},
+		resolver, timeNow, noopHealthchecksIO{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go runner.Run(ctx, done)
+
+	// The reload swaps the records, so record b now has the ID 0.
+	errs := runner.ReloadRecords(ctx, []librecords.Record{recordB, recordA},
+		map[uint]uint{0: 1, 1: 0})
+	require.Empty(t, errs)
+
+	errs = runner.ForceUpdateRecords(ctx, []string{recordB.Provider.String()})
+	assert.Empty(t, errs)
+
+	errs = runner.ForceUpdateRecords(ctx, []string{"removed record"})
+	assert.Empty(t, errs)
+
+	cancel()
+	<-done
+
+	assert.Equal(t, []uint{0}, updater.updatedIDs)
+}
+
+// warningsLogger records the warnings logged.
+type warningsLogger struct {
+	noopLogger
+	warnings []string
+}
+
+func (l *warningsLogger) Warn(s string) { l.warnings = append(l.warnings, s) }
+
+// countingResolver records the hostnames looked up.
+type countingResolver struct {
+	fixedResolver
+	hostnames []string
+}
+
+func (r *countingResolver) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	r.hostnames = append(r.hostnames, host)
+	return r.fixedResolver.LookupIP(ctx, network, host)
+}
+
+func Test_Runner_updateNecessary_onlyIDs(t *testing.T) {
+	t.Parallel()
+
+	makeRecord := func(host string, ipVersion ipversion.IPVersion) librecords.Record {
+		provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", host,
+			ipVersion, netip.Prefix{})
+		require.NoError(t, err)
+		return librecords.New(provider, models.RecordSettings{}, nil)
+	}
+	db := &fakeDatabase{records: []librecords.Record{
+		makeRecord("a", ipversion.IP4),
+		// not selected, and its IPv6 address is not fetched
+		makeRecord("b", ipversion.IP6),
+	}}
+	updater := &recordingUpdater{}
+	logger := &warningsLogger{}
+	resolver := &countingResolver{fixedResolver: fixedResolver{ip: net.IPv4(5, 6, 7, 8)}}
+	timeNow := func() time.Time { return time.Unix(100000, 0) }
+	runner := NewRunner(db, updater, fixedIPGetter{ip: netip.MustParseAddr("1.2.3.4")},
+		time.Hour, 0, 0, 0, models.FailureBackoffSettings{}, logger,
+		resolver, timeNow, noopHealthchecksIO{})
+
+	errs := runner.updateNecessary(context.Background(), map[uint]struct{}{0: {}}, false)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []uint{0}, updater.updatedIDs)
+	assert.Empty(t, logger.warnings)
+	assert.Equal(t, []string{"a.example.com"}, resolver.hostnames)
+}
@@ -0,0 +1,117 @@
+package update
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/healthchecksio"
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/njalla"
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDatabase struct {
+	records []librecords.Record
+}
+
+func (d *fakeDatabase) Select(id uint) (librecords.Record, error) { return d.records[id], nil }
+func (d *fakeDatabase) SelectAll() []librecords.Record            { return d.records }
+func (d *fakeDatabase) ReplaceAll(records []librecords.Record)    { d.records = records }
+func (d *fakeDatabase) Update(id uint, record librecords.Record) error {
+	d.records[id] = record
+	return nil
+}
+
+type noopHealthchecksIO struct{}
+
+func (noopHealthchecksIO) Ping(context.Context, healthchecksio.State) error { return nil }
+
+// blockingUpdater blocks value updates until release is closed
+// or the update context is canceled.
+type blockingUpdater struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (u *blockingUpdater) Update(context.Context, uint, netip.Addr) error { return nil }
+func (u *blockingUpdater) UpdateBoth(context.Context, uint, netip.Addr, netip.Addr) error {
+	return nil
+}
+
+func (u *blockingUpdater) UpdateValue(ctx context.Context, _ uint) error {
+	close(u.started)
+	select {
+	case <-u.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func Test_Runner_Run_shutdown(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		gracePeriod time.Duration
+		release     bool
+		errs        []error
+	}{
+		"in_flight_update_completes": {
+			gracePeriod: time.Hour,
+			release:     true,
+		},
+		"in_flight_update_canceled": {
+			gracePeriod: time.Millisecond,
+			errs:        []error{context.Canceled},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", "@",
+				ipversion.IP4or6, netip.Prefix{})
+			require.NoError(t, err)
+			settings := models.RecordSettings{RecordType: "TXT", Content: "value"}
+			db := &fakeDatabase{records: []librecords.Record{
+				librecords.New(provider, settings, nil),
+			}}
+			updater := &blockingUpdater{
+				started: make(chan struct{}),
+				release: make(chan struct{}),
+			}
+			runner := NewRunner(db, updater, nil, time.Hour, 0, 0, testCase.gracePeriod,
+				models.FailureBackoffSettings{},
+				noopLogger{}, nil, time.Now, noopHealthchecksIO{})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go runner.Run(ctx, done)
+
+			errsCh := make(chan []error)
+			go func() {
+				errsCh <- runner.ForceUpdate(context.Background())
+			}()
+
+			<-updater.started
+			cancel()
+			if testCase.release {
+				close(updater.release)
+			}
+
+			errs := <-errsCh
+			require.Len(t, errs, len(testCase.errs))
+			for i := range errs {
+				assert.ErrorIs(t, errs[i], testCase.errs[i])
+			}
+			<-done
+		})
+	}
+}
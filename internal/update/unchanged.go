@@ -0,0 +1,51 @@
+package update
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/constants"
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+)
+
+// isUnchanged returns true if the record is configured to skip unchanged
+// updates, its last update did not fail, and each valid public IP address
+// given is the last IP address of its family successfully submitted for
+// the record, as persisted in its history. It returns false once the
+// record force interval elapsed since its last successful update.
+func (r *Runner) isUnchanged(record librecords.Record, now time.Time,
+	publicIPs ...netip.Addr) (unchanged bool) {
+	if !record.Settings.SkipUnchanged || record.Status == constants.FAIL {
+		return false
+	}
+
+	forceInterval := record.Settings.ForceInterval
+	if forceInterval > 0 && now.Sub(record.History.GetSuccessTime()) >= forceInterval {
+		return false
+	}
+
+	lastIPv4, lastIPv6 := record.History.GetCurrentIPs()
+	ipStrings := make([]string, 0, len(publicIPs))
+	for _, publicIP := range publicIPs {
+		if !publicIP.IsValid() {
+			continue
+		}
+		lastIP := lastIPv4
+		if publicIP.Is6() {
+			lastIP = lastIPv6
+		}
+		if publicIP.Compare(lastIP) != 0 {
+			return false
+		}
+		ipStrings = append(ipStrings, publicIP.String())
+	}
+	if len(ipStrings) == 0 {
+		return false
+	}
+
+	r.logger.Debug(fmt.Sprintf("Last update of %s succeeded with %s, skipping unchanged update",
+		recordToLogString(record), strings.Join(ipStrings, " and ")))
+	return true
+}
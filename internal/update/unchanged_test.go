@@ -0,0 +1,107 @@
+package update
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/constants"
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/njalla"
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string) {}
+func (noopLogger) Info(string)  {}
+func (noopLogger) Warn(string)  {}
+func (noopLogger) Error(string) {}
+
+func Test_Runner_isUnchanged(t *testing.T) {
+	t.Parallel()
+
+	provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", "@",
+		ipversion.IP4or6, netip.Prefix{})
+	require.NoError(t, err)
+
+	now := time.Unix(100000, 0)
+	lastIP := netip.MustParseAddr("1.2.3.4")
+	history := models.History{{IP: lastIP, Time: now.Add(-time.Hour)}}
+	settings := models.RecordSettings{
+		SkipUnchanged: true,
+		ForceInterval: 24 * time.Hour,
+	}
+
+	testCases := map[string]struct {
+		settings  models.RecordSettings
+		status    models.Status
+		publicIPs []netip.Addr
+		unchanged bool
+	}{
+		"same_ip": {
+			settings:  settings,
+			status:    constants.SUCCESS,
+			publicIPs: []netip.Addr{lastIP},
+			unchanged: true,
+		},
+		"different_ip": {
+			settings:  settings,
+			status:    constants.SUCCESS,
+			publicIPs: []netip.Addr{netip.MustParseAddr("5.6.7.8")},
+		},
+		"disabled": {
+			settings:  models.RecordSettings{ForceInterval: 24 * time.Hour},
+			status:    constants.SUCCESS,
+			publicIPs: []netip.Addr{lastIP},
+		},
+		"last_update_failed": {
+			settings:  settings,
+			status:    constants.FAIL,
+			publicIPs: []netip.Addr{lastIP},
+		},
+		"force_interval_elapsed": {
+			settings: models.RecordSettings{
+				SkipUnchanged: true,
+				ForceInterval: time.Hour,
+			},
+			status:    constants.SUCCESS,
+			publicIPs: []netip.Addr{lastIP},
+		},
+		"never_forced": {
+			settings:  models.RecordSettings{SkipUnchanged: true},
+			status:    constants.UNSET,
+			publicIPs: []netip.Addr{lastIP},
+			unchanged: true,
+		},
+		"missing_ipv6_ignored": {
+			settings:  settings,
+			status:    constants.SUCCESS,
+			publicIPs: []netip.Addr{lastIP, {}},
+			unchanged: true,
+		},
+		"ipv6_not_submitted": {
+			settings:  settings,
+			status:    constants.SUCCESS,
+			publicIPs: []netip.Addr{lastIP, netip.MustParseAddr("::1")},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := &Runner{logger: noopLogger{}}
+			record := librecords.New(provider, testCase.settings, history)
+			record.Status = testCase.status
+
+			unchanged := runner.isUnchanged(record, now, testCase.publicIPs...)
+
+			assert.Equal(t, testCase.unchanged, unchanged)
+		})
+	}
+}
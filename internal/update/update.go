@@ -6,35 +6,93 @@ import (
 	"fmt"
 	"net/http"
 	"net/netip"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/qdm12/ddns-updater/internal/constants"
+	"github.com/qdm12/ddns-updater/internal/history"
 	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/notify"
+	"github.com/qdm12/ddns-updater/internal/provider"
 	settingserrors "github.com/qdm12/ddns-updater/internal/provider/errors"
+	"github.com/qdm12/ddns-updater/internal/ratelimit"
+	"github.com/qdm12/ddns-updater/internal/records"
 )
 
 type Updater struct {
-	db             Database
-	client         *http.Client
-	shoutrrrClient ShoutrrrClient
-	logger         DebugLogger
-	timeNow        func() time.Time
+	db     Database
+	client *http.Client
+	// healthcheckClient is the client without debug logging,
+	// used to ping record healthcheck URLs.
+	healthcheckClient *http.Client
+	// customClient is the client with debug logging for custom
+	// endpoints, and customBaseClient is the same without debug logging.
+	customClient     *http.Client
+	customBaseClient *http.Client
+	shoutrrrClient   ShoutrrrClient
+	dispatcher       EventDispatcher
+	historyStore     HistoryStore
+	metrics          MetricsRecorder
+	logger           Logger
+	timeNow          func() time.Time
+	// failures maps record IDs to their number of
+	// consecutive failed updates.
+	failures      map[uint]uint
+	failuresMutex sync.Mutex
+	// proxyClients maps record proxy URLs to their HTTP
+	// client with debug logging, created on first use.
+	proxyClients      map[string]*http.Client
+	proxyClientsMutex sync.Mutex
+	// rateLimiters limits the rate of update attempts of the
+	// records of the same provider account.
+	rateLimiters *ratelimit.Registry
 }
 
-func NewUpdater(db Database, client *http.Client, shoutrrrClient ShoutrrrClient,
-	logger DebugLogger, timeNow func() time.Time) *Updater {
-	client = makeLogClient(client, logger)
+// failuresToNotify is the number of consecutive failed updates
+// of a record after which a failure event is dispatched.
+const failuresToNotify = 3
+
+func NewUpdater(db Database, client, customClient *http.Client, shoutrrrClient ShoutrrrClient,
+	dispatcher EventDispatcher, historyStore HistoryStore, metrics MetricsRecorder,
+	logger Logger, timeNow func() time.Time) *Updater {
 	return &Updater{
-		db:             db,
-		client:         client,
-		shoutrrrClient: shoutrrrClient,
-		logger:         logger,
-		timeNow:        timeNow,
+		db:                db,
+		client:            makeLogClient(client, logger),
+		healthcheckClient: client,
+		customClient:      makeLogClient(customClient, logger),
+		customBaseClient:  customClient,
+		shoutrrrClient:    shoutrrrClient,
+		dispatcher:        dispatcher,
+		historyStore:      historyStore,
+		metrics:           metrics,
+		logger:            logger,
+		timeNow:           timeNow,
+		failures:          make(map[uint]uint),
+		proxyClients:      make(map[string]*http.Client),
+		rateLimiters:      ratelimit.NewRegistry(timeNow),
 	}
 }
 
 func (u *Updater) Update(ctx context.Context, id uint, ip netip.Addr) (err error) {
-	record, err := u.db.Select(id)
+	return u.update(ctx, id, []netip.Addr{ip})
+}
+
+// UpdateBoth updates the record with the IPv4 and IPv6 addresses given,
+// in a single request if the provider supports it.
+func (u *Updater) UpdateBoth(ctx context.Context, id uint, ipv4, ipv6 netip.Addr) (err error) {
+	return u.update(ctx, id, []netip.Addr{ipv4, ipv6})
+}
+
+func (u *Updater) update(ctx context.Context, id uint, ips []netip.Addr) (err error) {
+	start := u.timeNow()
+	result := resultFail
+	var record records.Record
+	defer func() {
+		u.logOutcome(record, ipsToString(ips), result, err, u.timeNow().Sub(start))
+	}()
+
+	record, err = u.db.Select(id)
 	if err != nil {
 		return err
 	}
@@ -44,32 +102,189 @@ func (u *Updater) Update(ctx context.Context, id uint, ip netip.Addr) (err error
 	if err != nil {
 		return err
 	}
-	record.Status = constants.FAIL
-	newIP, err := record.Provider.Update(ctx, u.client, ip)
+	newIPs, err := u.updateWithRetries(ctx, record, ips)
 	if err != nil {
-		record.Message = err.Error()
-		if errors.Is(err, settingserrors.ErrBannedAbuse) {
-			lastBan := time.Unix(u.timeNow().Unix(), 0)
-			record.LastBan = &lastBan
-			domainName := record.Provider.BuildDomainName()
-			message := domainName + ": " + record.Message +
-				", no more updates will be attempted for an hour"
-			u.shoutrrrClient.Notify(message)
-			err = fmt.Errorf("%w: for domain %s, no more update will be attempted for 1h", err, domainName)
-		} else {
-			record.LastBan = nil // clear a previous ban
+		return u.handleFailure(ctx, id, record, ips, err)
+	}
+	u.resetFailures(id)
+	record.Status = constants.SUCCESS
+	u.addHistoryEntries(ctx, record, newIPs, nil)
+	u.pingRecordHealthcheck(ctx, record.Settings.HealthcheckURL, nil)
+	ipStrings := make([]string, len(ips))
+	for i, ip := range ips {
+		ipStrings[i] = ip.String()
+	}
+	record.Message = "changed to " + strings.Join(ipStrings, " and ")
+	now := u.timeNow()
+	oldIPv4, oldIPv6 := record.History.GetCurrentIPs()
+	result = resultNoChange
+	for _, newIP := range newIPs {
+		oldIP := oldIPv4
+		if newIP.Is6() {
+			oldIP = oldIPv6
 		}
-		if updateErr := u.db.Update(id, record); updateErr != nil {
-			return fmt.Errorf("%w (with database update error: %w)", err, updateErr)
+		record.History = append(record.History, models.HistoryEvent{
+			IP:   newIP,
+			Time: now,
+		})
+		if newIP == oldIP {
+			continue
 		}
-		return err
+		result = resultSuccess
+		u.dispatcher.Dispatch(notify.Event{
+			Domain:   record.Provider.Domain(),
+			Host:     record.Provider.Host(),
+			Provider: record.Settings.ProviderName,
+			OldIP:    oldIP,
+			NewIP:    newIP,
+			Time:     now,
+		})
 	}
-	record.Status = constants.SUCCESS
-	record.Message = fmt.Sprintf("changed to %s", ip.String())
-	record.History = append(record.History, models.HistoryEvent{
-		IP:   newIP,
-		Time: u.timeNow(),
-	})
-	u.shoutrrrClient.Notify(record.Provider.BuildDomainName() + " " + record.Message)
 	return u.db.Update(id, record) // persists some data if needed (i.e new IP)
 }
+
+// handleFailure marks the record as failed with the update error given,
+// bans it for an hour if the provider reported an abuse, dispatches a failure
+// event if it failed repeatedly, adds history entries and pings its healthcheck.
+// The IP addresses are the ones the record failed to update to, and are
+// empty for a record set to a value. It returns the update error, wrapped
+// with the ban or database update error if any.
+func (u *Updater) handleFailure(ctx context.Context, id uint, record records.Record,
+	ips []netip.Addr, err error) error {
+	record.Status = constants.FAIL
+	record.Message = err.Error()
+	if errors.Is(err, settingserrors.ErrBannedAbuse) {
+		lastBan := time.Unix(u.timeNow().Unix(), 0)
+		record.LastBan = &lastBan
+		domainName := record.Provider.BuildDomainName()
+		message := domainName + ": " + record.Message +
+			", no more updates will be attempted for an hour"
+		u.shoutrrrClient.Notify(message)
+		err = fmt.Errorf("%w: for domain %s, no more update will be attempted for 1h", err, domainName)
+	} else {
+		record.LastBan = nil // clear a previous ban
+	}
+	u.dispatchFailure(id, record, ips, err)
+	u.addHistoryEntries(ctx, record, ips, err)
+	u.pingRecordHealthcheck(ctx, record.Settings.HealthcheckURL, err)
+	if updateErr := u.db.Update(id, record); updateErr != nil {
+		return fmt.Errorf("%w (with database update error: %w)", err, updateErr)
+	}
+	return err
+}
+
+// dispatchFailure increments the number of consecutive failed updates
+// of the record, and dispatches a failure event once it reaches
+// failuresToNotify, until the record is updated successfully again.
+func (u *Updater) dispatchFailure(id uint, record records.Record,
+	ips []netip.Addr, err error) {
+	u.failuresMutex.Lock()
+	u.failures[id]++
+	failures := u.failures[id]
+	u.failuresMutex.Unlock()
+	if failures != failuresToNotify {
+		return
+	}
+
+	// The event has no IP addresses for a record set to a value.
+	var oldIP, newIP netip.Addr
+	if len(ips) > 0 {
+		newIP = ips[len(ips)-1]
+		oldIPv4, oldIPv6 := record.History.GetCurrentIPs()
+		oldIP = oldIPv4
+		if newIP.Is6() {
+			oldIP = oldIPv6
+		}
+	}
+	u.dispatcher.Dispatch(notify.Event{
+		Domain:   record.Provider.Domain(),
+		Host:     record.Provider.Host(),
+		Provider: record.Settings.ProviderName,
+		OldIP:    oldIP,
+		NewIP:    newIP,
+		Time:     u.timeNow(),
+		Err:      err,
+	})
+}
+
+func (u *Updater) resetFailures(id uint) {
+	u.failuresMutex.Lock()
+	delete(u.failures, id)
+	u.failuresMutex.Unlock()
+}
+
+// RemapFailures moves the consecutive failed updates count of each record
+// to its new record ID, given previousIDs mapping new record IDs to their
+// previous record ID. Counts of records no longer present are dropped.
+func (u *Updater) RemapFailures(previousIDs map[uint]uint) {
+	u.failuresMutex.Lock()
+	defer u.failuresMutex.Unlock()
+	failures := make(map[uint]uint)
+	for newID, previousID := range previousIDs {
+		if count, ok := u.failures[previousID]; ok {
+			failures[newID] = count
+		}
+	}
+	u.failures = failures
+}
+
+// addHistoryEntries adds an entry to the history store for each IP address
+// given, with the status of the record and the update error if any.
+// A single entry without IP address is added if no IP address is given,
+// for a record set to a value. Store errors are logged and do not fail the update.
+func (u *Updater) addHistoryEntries(ctx context.Context, record records.Record,
+	ips []netip.Addr, updateErr error) {
+	var errMessage string
+	if updateErr != nil {
+		errMessage = updateErr.Error()
+	}
+	// The entries are added even if the update context is canceled,
+	// for example on shutdown, to record the outcome of the update.
+	ctx = context.WithoutCancel(ctx)
+	if len(ips) == 0 {
+		ips = []netip.Addr{{}}
+	}
+	for _, ip := range ips {
+		entry := history.Entry{
+			Domain:   record.Provider.Domain(),
+			Host:     record.Provider.Host(),
+			Provider: record.Settings.ProviderName,
+			IP:       ip,
+			Time:     u.timeNow(),
+			Status:   record.Status,
+			Error:    errMessage,
+		}
+		err := u.historyStore.Add(ctx, entry)
+		if err != nil {
+			u.logger.Error("adding history entry: " + err.Error())
+		}
+	}
+}
+
+// updateProvider updates the provider record with the IP addresses given.
+// If two IP addresses are given and the provider implements the
+// DualStackUpdater interface, both addresses are updated in a single
+// request, otherwise the provider Update method is called for each address.
+func updateProvider(ctx context.Context, p provider.Provider, client *http.Client,
+	ips []netip.Addr) (newIPs []netip.Addr, err error) {
+	dualStackUpdater, ok := p.(provider.DualStackUpdater)
+	if ok && len(ips) == 2 { //nolint:gomnd
+		newIPv4, newIPv6, err := dualStackUpdater.UpdateBoth(ctx, client, ips[0], ips[1])
+		if err != nil {
+			return nil, err
+		}
+		return []netip.Addr{newIPv4, newIPv6}, nil
+	}
+
+	newIPs = make([]netip.Addr, len(ips))
+	for i, ip := range ips {
+		newIPs[i], err = p.Update(ctx, client, ip)
+		if err != nil {
+			if len(ips) > 1 {
+				err = fmt.Errorf("updating %s: %w", ip, err)
+			}
+			return nil, err
+		}
+	}
+	return newIPs, nil
+}
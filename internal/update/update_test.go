@@ -0,0 +1,147 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/qdm12/ddns-updater/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProvider records the IP addresses it is updated with, and
+// fails updating the IP address errIP with err.
+type fakeProvider struct {
+	provider.Provider
+	updatedIPs []netip.Addr
+	errIP      netip.Addr
+	err        error
+}
+
+func (p *fakeProvider) Update(_ context.Context, _ *http.Client, ip netip.Addr) (netip.Addr, error) {
+	p.updatedIPs = append(p.updatedIPs, ip)
+	if ip == p.errIP {
+		return netip.Addr{}, p.err
+	}
+	return ip, nil
+}
+
+// fakeDualStackProvider also implements the provider.DualStackUpdater interface.
+type fakeDualStackProvider struct {
+	fakeProvider
+	bothIPs []netip.Addr
+}
+
+func (p *fakeDualStackProvider) UpdateBoth(_ context.Context, _ *http.Client,
+	ipv4, ipv6 netip.Addr) (newIPv4, newIPv6 netip.Addr, err error) {
+	p.bothIPs = append(p.bothIPs, ipv4, ipv6)
+	if p.err != nil {
+		return netip.Addr{}, netip.Addr{}, p.err
+	}
+	return ipv4, ipv6, nil
+}
+
+func Test_updateProvider(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	ipv4 := netip.MustParseAddr("1.2.3.4")
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+
+	testCases := map[string]struct {
+		provider   *fakeDualStackProvider
+		dualStack  bool
+		ips        []netip.Addr
+		newIPs     []netip.Addr
+		updatedIPs []netip.Addr
+		bothIPs    []netip.Addr
+		errWrapped error
+		errMessage string
+	}{
+		"single_ip": {
+			provider:   &fakeDualStackProvider{},
+			ips:        []netip.Addr{ipv4},
+			newIPs:     []netip.Addr{ipv4},
+			updatedIPs: []netip.Addr{ipv4},
+		},
+		"single_ip_error_not_wrapped": {
+			provider:   &fakeDualStackProvider{fakeProvider: fakeProvider{errIP: ipv4, err: errTest}},
+			ips:        []netip.Addr{ipv4},
+			updatedIPs: []netip.Addr{ipv4},
+			errWrapped: errTest,
+			errMessage: "test error",
+		},
+		"single_ip_dual_stack_provider": {
+			provider:   &fakeDualStackProvider{},
+			dualStack:  true,
+			ips:        []netip.Addr{ipv4},
+			newIPs:     []netip.Addr{ipv4},
+			updatedIPs: []netip.Addr{ipv4},
+		},
+		"two_ips_fallback": {
+			provider:   &fakeDualStackProvider{},
+			ips:        []netip.Addr{ipv4, ipv6},
+			newIPs:     []netip.Addr{ipv4, ipv6},
+			updatedIPs: []netip.Addr{ipv4, ipv6},
+		},
+		"two_ips_fallback_error": {
+			provider:   &fakeDualStackProvider{fakeProvider: fakeProvider{errIP: ipv6, err: errTest}},
+			ips:        []netip.Addr{ipv4, ipv6},
+			updatedIPs: []netip.Addr{ipv4, ipv6},
+			errWrapped: errTest,
+			errMessage: "updating 2001:db8::1: test error",
+		},
+		"two_ips_dual_stack": {
+			provider:  &fakeDualStackProvider{},
+			dualStack: true,
+			ips:       []netip.Addr{ipv4, ipv6},
+			newIPs:    []netip.Addr{ipv4, ipv6},
+			bothIPs:   []netip.Addr{ipv4, ipv6},
+		},
+		"two_ips_dual_stack_error": {
+			provider:   &fakeDualStackProvider{fakeProvider: fakeProvider{err: errTest}},
+			dualStack:  true,
+			ips:        []netip.Addr{ipv4, ipv6},
+			bothIPs:    []netip.Addr{ipv4, ipv6},
+			errWrapped: errTest,
+			errMessage: "test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var p provider.Provider = &testCase.provider.fakeProvider
+			if testCase.dualStack {
+				p = testCase.provider
+			}
+
+			newIPs, err := updateProvider(context.Background(), p, &http.Client{}, testCase.ips)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.newIPs, newIPs)
+			assert.Equal(t, testCase.updatedIPs, testCase.provider.updatedIPs)
+			assert.Equal(t, testCase.bothIPs, testCase.provider.bothIPs)
+		})
+	}
+}
+
+func Test_Updater_RemapFailures(t *testing.T) {
+	t.Parallel()
+
+	updater := &Updater{failures: map[uint]uint{0: 2, 1: 1, 2: 3}}
+
+	// Record 0 is removed, record 1 moves to ID 0 and
+	// record 2 stays at ID 1, with a new record at ID 2.
+	updater.RemapFailures(map[uint]uint{0: 1, 1: 2})
+
+	expected := map[uint]uint{0: 1, 1: 3}
+	assert.Equal(t, expected, updater.failures)
+}
@@ -0,0 +1,70 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/qdm12/ddns-updater/internal/constants"
+	"github.com/qdm12/ddns-updater/internal/provider"
+	"github.com/qdm12/ddns-updater/internal/records"
+)
+
+var ErrValueUpdaterNotImplemented = errors.New("provider does not support setting record values")
+
+// UpdateValue sets the record of the type configured for the record, such as
+// a TXT record, to the content configured, instead of to an IP address.
+// History entries are recorded without IP address for such records, and only
+// failure events are dispatched since there is no IP address change to notify.
+func (u *Updater) UpdateValue(ctx context.Context, id uint) (err error) {
+	start := u.timeNow()
+	result := resultFail
+	var record records.Record
+	defer func() {
+		u.logOutcome(record, "", result, err, u.timeNow().Sub(start))
+	}()
+
+	record, err = u.db.Select(id)
+	if err != nil {
+		return err
+	}
+	record.Time = u.timeNow()
+	record.Status = constants.UPDATING
+	err = u.db.Update(id, record)
+	if err != nil {
+		return err
+	}
+
+	recordType, content := record.Settings.RecordType, record.Settings.Content
+	err = u.withRetries(ctx, record, func(ctx context.Context, client *http.Client) (err error) {
+		return updateProviderValue(ctx, record.Provider, client, recordType, content)
+	})
+	if err != nil {
+		return u.handleFailure(ctx, id, record, nil, err)
+	}
+
+	u.resetFailures(id)
+	record.Status = constants.SUCCESS
+	result = resultSuccess
+	record.Message = "set " + recordType + " record to " + content
+	record.LastBan = nil
+	u.addHistoryEntries(ctx, record, nil, nil)
+	u.pingRecordHealthcheck(ctx, record.Settings.HealthcheckURL, nil)
+	return u.db.Update(id, record)
+}
+
+func updateProviderValue(ctx context.Context, p provider.Provider, client *http.Client,
+	recordType, value string) (err error) {
+	valueUpdater, ok := p.(provider.ValueUpdater)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrValueUpdaterNotImplemented, p)
+	}
+	return valueUpdater.UpdateValue(ctx, client, recordType, value)
+}
+
+// isValueRecord returns true if the record is set to a fixed value
+// of another record type, instead of to the public IP address.
+func isValueRecord(record records.Record) bool {
+	return record.Settings.RecordType != ""
+}
@@ -0,0 +1,90 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qdm12/ddns-updater/internal/constants"
+	"github.com/qdm12/ddns-updater/internal/history"
+	"github.com/qdm12/ddns-updater/internal/models"
+	"github.com/qdm12/ddns-updater/internal/notify"
+	providerconstants "github.com/qdm12/ddns-updater/internal/provider/constants"
+	"github.com/qdm12/ddns-updater/internal/provider/providers/njalla"
+	librecords "github.com/qdm12/ddns-updater/internal/records"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopShoutrrr struct{}
+
+func (noopShoutrrr) Notify(string) {}
+
+type noopMetrics struct{}
+
+func (noopMetrics) UpdateAttempt(models.Provider, time.Duration, error) {}
+
+type recordingDispatcher struct {
+	events []notify.Event
+}
+
+func (d *recordingDispatcher) Dispatch(event notify.Event) {
+	d.events = append(d.events, event)
+}
+
+type recordingHistoryStore struct {
+	mutex   sync.Mutex
+	entries []history.Entry
+}
+
+func (s *recordingHistoryStore) Add(_ context.Context, entry history.Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func Test_Updater_UpdateValue_failure(t *testing.T) {
+	t.Parallel()
+
+	provider, err := njalla.New([]byte(`{"key":"key"}`), "example.com", "@",
+		ipversion.IP4, netip.Prefix{})
+	require.NoError(t, err)
+	settings := models.RecordSettings{
+		ProviderName: providerconstants.Njalla,
+		RecordType:   "TXT",
+		Content:      "value",
+	}
+	db := &fakeDatabase{records: []librecords.Record{
+		librecords.New(provider, settings, nil),
+	}}
+	dispatcher := &recordingDispatcher{}
+	historyStore := &recordingHistoryStore{}
+	now := time.Unix(100000, 0)
+	timeNow := func() time.Time { return now }
+	updater := NewUpdater(db, &http.Client{}, &http.Client{}, noopShoutrrr{},
+		dispatcher, historyStore, noopMetrics{}, noopLogger{}, timeNow)
+
+	for i := 0; i < failuresToNotify; i++ {
+		err = updater.UpdateValue(context.Background(), 0)
+		require.ErrorIs(t, err, ErrValueUpdaterNotImplemented)
+	}
+
+	assert.Equal(t, constants.FAIL, db.records[0].Status)
+	require.Len(t, dispatcher.events, 1)
+	event := dispatcher.events[0]
+	assert.False(t, event.NewIP.IsValid())
+	assert.ErrorIs(t, event.Err, ErrValueUpdaterNotImplemented)
+	assert.Equal(t, "example.com (njalla) failed updating: "+err.Error(), event.Message())
+
+	require.Len(t, historyStore.entries, failuresToNotify)
+	for _, entry := range historyStore.entries {
+		assert.Equal(t, constants.FAIL, entry.Status)
+		assert.False(t, entry.IP.IsValid())
+		assert.Equal(t, err.Error(), entry.Error)
+	}
+}
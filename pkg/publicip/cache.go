@@ -0,0 +1,84 @@
+package publicip
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+// Cache wraps a public IP fetcher to remember the last IP address
+// fetched for each IP version, for a given time to live.
+type Cache struct {
+	fetcher ipFetcher
+	ttl     time.Duration
+	timeNow func() time.Time
+	mutex   sync.Mutex
+	entries map[ipversion.IPVersion]cacheEntry
+}
+
+type cacheEntry struct {
+	ip      netip.Addr
+	expires time.Time
+}
+
+// NewCache returns a cache wrapping the fetcher given. A zero ttl
+// disables caching.
+func NewCache(fetcher ipFetcher, ttl time.Duration,
+	timeNow func() time.Time) *Cache {
+	return &Cache{
+		fetcher: fetcher,
+		ttl:     ttl,
+		timeNow: timeNow,
+		entries: make(map[ipversion.IPVersion]cacheEntry),
+	}
+}
+
+func (c *Cache) IP(ctx context.Context) (ip netip.Addr, err error) {
+	return c.get(ctx, ipversion.IP4or6, c.fetcher.IP)
+}
+
+func (c *Cache) IP4(ctx context.Context) (ipv4 netip.Addr, err error) {
+	return c.get(ctx, ipversion.IP4, c.fetcher.IP4)
+}
+
+func (c *Cache) IP6(ctx context.Context) (ipv6 netip.Addr, err error) {
+	return c.get(ctx, ipversion.IP6, c.fetcher.IP6)
+}
+
+// Invalidate clears the cache so the next calls fetch
+// the IP addresses again.
+func (c *Cache) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	clear(c.entries)
+}
+
+func (c *Cache) get(ctx context.Context, version ipversion.IPVersion,
+	fetch func(ctx context.Context) (netip.Addr, error)) (ip netip.Addr, err error) {
+	if c.ttl == 0 {
+		return fetch(ctx)
+	}
+
+	c.mutex.Lock()
+	entry, ok := c.entries[version]
+	c.mutex.Unlock()
+	if ok && c.timeNow().Before(entry.expires) {
+		return entry.ip, nil
+	}
+
+	ip, err = fetch(ctx)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	c.mutex.Lock()
+	c.entries[version] = cacheEntry{
+		ip:      ip,
+		expires: c.timeNow().Add(c.ttl),
+	}
+	c.mutex.Unlock()
+	return ip, nil
+}
@@ -0,0 +1,53 @@
+package publicip
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingFetcher struct {
+	fakeFetcher
+	calls int
+}
+
+func (f *countingFetcher) IP4(ctx context.Context) (netip.Addr, error) {
+	f.calls++
+	return f.fakeFetcher.IP4(ctx)
+}
+
+func Test_Cache(t *testing.T) {
+	t.Parallel()
+
+	fetcher := &countingFetcher{
+		fakeFetcher: fakeFetcher{ip: netip.AddrFrom4([4]byte{1, 1, 1, 1})},
+	}
+	now := time.Unix(0, 0)
+	timeNow := func() time.Time { return now }
+	cache := NewCache(fetcher, time.Minute, timeNow)
+	ctx := context.Background()
+
+	ip, err := cache.IP4(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, netip.AddrFrom4([4]byte{1, 1, 1, 1}), ip)
+	assert.Equal(t, 1, fetcher.calls)
+
+	now = now.Add(30 * time.Second)
+	_, err = cache.IP4(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetcher.calls, "cached value should be used")
+
+	now = now.Add(time.Minute)
+	_, err = cache.IP4(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetcher.calls, "expired value should be refetched")
+
+	cache.Invalidate()
+	_, err = cache.IP4(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fetcher.calls, "invalidated value should be refetched")
+}
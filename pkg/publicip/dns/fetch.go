@@ -32,7 +32,13 @@ func fetch(ctx context.Context, client Client, network string,
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrNetworkNotSupported, network)
 	}
-	serverAddress := net.JoinHostPort(serverHost, "853")
+	port := "853"
+	if providerData.TLSName == "" {
+		port = "53"
+	}
+	serverAddress := net.JoinHostPort(serverHost, port)
+
+	qType := questionType(network, providerData.qType)
 
 	message := &dns.Msg{
 		MsgHdr: dns.MsgHdr{
@@ -41,7 +47,7 @@ func fetch(ctx context.Context, client Client, network string,
 		Question: []dns.Question{
 			{
 				Name:   providerData.fqdn,
-				Qtype:  uint16(providerData.qType),
+				Qtype:  uint16(qType),
 				Qclass: uint16(providerData.class),
 			},
 		},
@@ -59,19 +65,19 @@ func fetch(ctx context.Context, client Client, network string,
 	publicIPs = make([]netip.Addr, 0, len(r.Answer))
 	for _, answer := range r.Answer {
 		var publicIP netip.Addr
-		switch uint16(providerData.qType) {
+		switch uint16(qType) {
 		case dns.TypeTXT:
 			publicIP, err = handleAnswerTXT(answer)
-		case dns.TypeANY:
+		case dns.TypeANY, dns.TypeA, dns.TypeAAAA:
 			publicIP, err = handleAnswerANY(answer)
 		default:
 			return nil, fmt.Errorf("%w: %s",
-				ErrAnswerTypeNotSupported, dns.TypeToString[uint16(providerData.qType)])
+				ErrAnswerTypeNotSupported, dns.TypeToString[uint16(qType)])
 		}
 
 		if err != nil {
 			return nil, fmt.Errorf("handling %s answer: %w",
-				providerData.qType.String(), err)
+				qType.String(), err)
 		}
 
 		publicIPs = append(publicIPs, publicIP)
@@ -80,6 +86,26 @@ func fetch(ctx context.Context, client Client, network string,
 	return publicIPs, nil
 }
 
+// questionType returns the question type to use for the network given.
+// For providers answering ANY questions, such as OpenDNS, an A question is
+// used for IPv4 and an AAAA question is used for IPv6, since the server
+// only answers with a record matching the IP family of the query transport,
+// and ANY questions may be refused by recursive resolvers as per RFC 8482.
+func questionType(network string, providerQType dns.Type) (qType dns.Type) {
+	if uint16(providerQType) != dns.TypeANY {
+		return providerQType
+	}
+
+	switch network {
+	case "tcp4":
+		return dns.Type(dns.TypeA)
+	case "tcp6":
+		return dns.Type(dns.TypeAAAA)
+	default:
+		return providerQType
+	}
+}
+
 var (
 	ErrTooManyTXTRecords = errors.New("too many TXT records")
 )
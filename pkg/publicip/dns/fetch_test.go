@@ -128,3 +128,45 @@ func Test_fetch(t *testing.T) {
 		})
 	}
 }
+
+func Test_questionType(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		network       string
+		providerQType dns.Type
+		qType         dns.Type
+	}{
+		"txt_unchanged": {
+			network:       "tcp4",
+			providerQType: dns.Type(dns.TypeTXT),
+			qType:         dns.Type(dns.TypeTXT),
+		},
+		"any_for_any_ip_version": {
+			network:       "tcp",
+			providerQType: dns.Type(dns.TypeANY),
+			qType:         dns.Type(dns.TypeANY),
+		},
+		"any_for_ipv4": {
+			network:       "tcp4",
+			providerQType: dns.Type(dns.TypeANY),
+			qType:         dns.Type(dns.TypeA),
+		},
+		"any_for_ipv6": {
+			network:       "tcp6",
+			providerQType: dns.Type(dns.TypeANY),
+			qType:         dns.Type(dns.TypeAAAA),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			qType := questionType(testCase.network, testCase.providerQType)
+
+			assert.Equal(t, testCase.qType, qType)
+		})
+	}
+}
@@ -57,13 +57,16 @@ func (f *Fetcher) ip(ctx context.Context, network string) (
 	providerData := f.ring.providers[index].data()
 
 	client := &dns.Client{
-		Net:         network + "-tls",
+		Net:         network,
 		Timeout:     f.timeout,
 		DialTimeout: f.timeout,
-		TLSConfig: &tls.Config{
+	}
+	if providerData.TLSName != "" {
+		client.Net = network + "-tls"
+		client.TLSConfig = &tls.Config{
 			MinVersion: tls.VersionTLS12,
 			ServerName: providerData.TLSName,
-		},
+		}
 	}
 
 	return fetch(ctx, client, network, providerData)
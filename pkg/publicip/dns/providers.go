@@ -12,12 +12,14 @@ type Provider string
 
 const (
 	Cloudflare Provider = "cloudflare"
+	Google     Provider = "google"
 	OpenDNS    Provider = "opendns"
 )
 
 func ListProviders() []Provider {
 	return []Provider{
 		Cloudflare,
+		Google,
 		OpenDNS,
 	}
 }
@@ -38,6 +40,9 @@ type providerData struct {
 	Address string
 	IPv4    netip.Addr
 	IPv6    netip.Addr
+	// TLSName is the TLS server name of the nameserver,
+	// and is empty if the nameserver does not support
+	// DNS over TLS, in which case plaintext DNS is used.
 	TLSName string
 	fqdn    string
 	class   dns.Class
@@ -46,13 +51,6 @@ type providerData struct {
 
 func (p Provider) data() providerData {
 	switch p {
-	// Note on deprecating Google:
-	// Only their nameserver ns1.google.com returns your public IP address.
-	// All their other nameservers return the closest Google datacenter IP.
-	// Unfortunately, ns1.google.com is not compatible with DNS over TLS,
-	// and dns.google.com is but does not echo your IP address.
-	// dig TXT @ns1.google.com o-o.myaddr.l.google.com +tls
-	// dig TXT @dns.google.com o-o.myaddr.l.google.com +tls
 	case Cloudflare:
 		return providerData{
 			Address: "1dot1dot1dot1.cloudflare-dns.com",
@@ -63,6 +61,21 @@ func (p Provider) data() providerData {
 			class:   dns.ClassCHAOS,
 			qType:   dns.Type(dns.TypeTXT),
 		}
+	case Google:
+		// Only their nameserver ns1.google.com returns your public IP address.
+		// All their other nameservers return the closest Google datacenter IP.
+		// Unfortunately, ns1.google.com is not compatible with DNS over TLS,
+		// and dns.google.com is but does not echo your IP address, so
+		// plaintext DNS over TCP is used.
+		// dig TXT @ns1.google.com o-o.myaddr.l.google.com +tcp
+		return providerData{
+			Address: "ns1.google.com",
+			IPv4:    netip.AddrFrom4([4]byte{216, 239, 32, 10}),
+			IPv6:    netip.AddrFrom16([16]byte{0x20, 0x1, 0x48, 0x60, 0x48, 0x2, 0x0, 0x32, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xa}), //nolint:lll
+			fqdn:    "o-o.myaddr.l.google.com.",
+			class:   dns.ClassINET,
+			qType:   dns.Type(dns.TypeTXT),
+		}
 	case OpenDNS:
 		return providerData{
 			Address: "dns.opendns.com",
@@ -62,6 +62,17 @@ func Test_data(t *testing.T) {
 				qType:   dns.Type(dns.TypeTXT),
 			},
 		},
+		"google": {
+			provider: Google,
+			data: providerData{
+				Address: "ns1.google.com",
+				IPv4:    netip.AddrFrom4([4]byte{216, 239, 32, 10}),
+				IPv6:    netip.AddrFrom16([16]byte{0x20, 0x1, 0x48, 0x60, 0x48, 0x2, 0x0, 0x32, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xa}), //nolint:lll
+				fqdn:    "o-o.myaddr.l.google.com.",
+				class:   dns.ClassINET,
+				qType:   dns.Type(dns.TypeTXT),
+			},
+		},
 		"opendns": {
 			provider: OpenDNS,
 			data: providerData{
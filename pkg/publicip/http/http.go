@@ -11,17 +11,24 @@ import (
 )
 
 type Fetcher struct {
-	client  *http.Client
-	timeout time.Duration
-	ip4or6  *urlsRing // URLs to get ipv4 or ipv6
-	ip4     *urlsRing // URLs to get ipv4 only
-	ip6     *urlsRing // URLs to get ipv6 only
+	client *http.Client
+	// customClient is the client used for custom URLs, and
+	// client is used instead if it is nil.
+	customClient *http.Client
+	timeout      time.Duration
+	order        Order
+	ip4or6       *urlsRing // URLs to get ipv4 or ipv6
+	ip4          *urlsRing // URLs to get ipv4 only
+	ip6          *urlsRing // URLs to get ipv6 only
 }
 
 type urlsRing struct {
 	index  int
 	urls   []string
 	banned map[int]string // urls indices <-> ban error string
+	// custom is the set of indices of custom URLs, and is nil
+	// if there is no custom URL.
+	custom map[int]struct{}
 	mutex  sync.Mutex
 }
 
@@ -35,11 +42,13 @@ func New(client *http.Client, options ...Option) (f *Fetcher, err error) {
 	}
 
 	return &Fetcher{
-		client:  client,
-		timeout: settings.timeout,
-		ip4or6:  newRing(settings.providersIP, ipversion.IP4or6),
-		ip4:     newRing(settings.providersIP4, ipversion.IP4),
-		ip6:     newRing(settings.providersIP6, ipversion.IP6),
+		client:       client,
+		customClient: settings.customURLsClient,
+		timeout:      settings.timeout,
+		order:        settings.order,
+		ip4or6:       newRing(settings.providersIP, ipversion.IP4or6),
+		ip4:          newRing(settings.providersIP4, ipversion.IP4),
+		ip6:          newRing(settings.providersIP6, ipversion.IP6),
 	}, nil
 }
 
@@ -49,6 +58,12 @@ func newRing(providers []Provider, ipVersion ipversion.IPVersion) (ring *urlsRin
 	ring.urls = make([]string, len(providers))
 	for i, provider := range providers {
 		ring.urls[i], _ = provider.url(ipVersion)
+		if provider.IsCustom() {
+			if ring.custom == nil {
+				ring.custom = make(map[int]struct{})
+			}
+			ring.custom[i] = struct{}{}
+		}
 	}
 	return ring
 }
@@ -23,6 +23,7 @@ func Test_New(t *testing.T) {
 			fetcher: &Fetcher{
 				client:  client,
 				timeout: 5 * time.Second,
+				order:   OrderCycle,
 				ip4or6: &urlsRing{
 					banned: map[int]string{},
 					urls:   []string{"https://domains.google.com/checkip"},
@@ -43,10 +44,12 @@ func Test_New(t *testing.T) {
 				SetProvidersIP4(Ipify),
 				SetProvidersIP6(Ipify),
 				SetTimeout(time.Second),
+				SetOrder(OrderRandom),
 			},
 			fetcher: &Fetcher{
 				client:  client,
 				timeout: time.Second,
+				order:   OrderRandom,
 				ip4or6: &urlsRing{
 					banned: map[int]string{},
 					urls:   []string{"https://domains.google.com/checkip"},
@@ -22,42 +22,78 @@ func (f *Fetcher) IP6(ctx context.Context) (publicIP netip.Addr, err error) {
 	return f.ip(ctx, f.ip6, ipversion.IP6)
 }
 
+// ip tries each non banned URL of the ring in the fetcher order,
+// and only fails if all of them fail.
 func (f *Fetcher) ip(ctx context.Context, ring *urlsRing, version ipversion.IPVersion) (
 	publicIP netip.Addr, err error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
 	ring.mutex.Lock()
+	indices := ring.indices(f.order)
+	ring.mutex.Unlock()
 
-	var index int
-	banned := 0
-	for {
-		ring.index = (ring.index + 1) % len(ring.urls)
-		index = ring.index
+	var errs []error
+	tried := 0
+	for _, index := range indices {
+		ring.mutex.Lock()
 		_, indexIsBanned := ring.banned[index]
-		if !indexIsBanned {
-			break
-		}
-		banned++
-		if banned == len(ring.urls) {
-			banString := ring.banString()
+		if indexIsBanned {
 			ring.mutex.Unlock()
-			return netip.Addr{}, fmt.Errorf("%w: %s", ErrBanned, banString)
+			continue
 		}
-	}
-
-	ring.mutex.Unlock()
-
-	url := ring.urls[index]
+		if f.order == OrderCycle || f.order == "" {
+			ring.index = index
+		}
+		ring.mutex.Unlock()
+		tried++
 
-	ctx, cancel := context.WithTimeout(ctx, f.timeout)
-	defer cancel()
+		url := ring.urls[index]
+		client := f.client
+		if _, isCustom := ring.custom[index]; isCustom && f.customClient != nil {
+			client = f.customClient
+		}
+		publicIP, err = fetch(ctx, client, url, version)
+		if err == nil {
+			return publicIP, nil
+		}
 
-	publicIP, err = fetch(ctx, f.client, url, version)
-	if err != nil {
 		if errors.Is(err, ErrBanned) {
 			ring.mutex.Lock()
 			ring.banned[index] = strings.ReplaceAll(err.Error(), ErrBanned.Error()+": ", "")
 			ring.mutex.Unlock()
 		}
-		return netip.Addr{}, err
+
+		if ctx.Err() != nil {
+			return netip.Addr{}, err
+		}
+		errs = append(errs, err)
+	}
+
+	switch {
+	case tried == 0:
+		ring.mutex.Lock()
+		banString := ring.banString()
+		ring.mutex.Unlock()
+		return netip.Addr{}, fmt.Errorf("%w: %s", ErrBanned, banString)
+	case len(errs) == 1:
+		return netip.Addr{}, errs[0]
+	default:
+		return netip.Addr{}, fmt.Errorf("all %d URLs failed: %w", len(errs), joinedErrors(errs))
 	}
-	return publicIP, nil
+}
+
+// joinedErrors is like errors.Join but formats all the errors on a single line.
+type joinedErrors []error
+
+func (e joinedErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e joinedErrors) Unwrap() []error {
+	return e
 }
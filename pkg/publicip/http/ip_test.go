@@ -175,6 +175,7 @@ func Test_fetcher_ip(t *testing.T) {
 	testCases := map[string]struct {
 		initialFetcher *Fetcher
 		ctx            context.Context
+		version        ipversion.IPVersion
 		publicIP       netip.Addr
 		err            error
 		errMessage     string
@@ -304,7 +305,7 @@ func Test_fetcher_ip(t *testing.T) {
 				ip4or6: &urlsRing{
 					index:  1,
 					urls:   []string{"a", "b"},
-					banned: map[int]string{},
+					banned: map[int]string{1: "banned"},
 				},
 			},
 			finalFetcher: &Fetcher{
@@ -312,12 +313,73 @@ func Test_fetcher_ip(t *testing.T) {
 				ip4or6: &urlsRing{
 					index:  0,
 					urls:   []string{"a", "b"},
-					banned: map[int]string{0: "429 (get out)"},
+					banned: map[int]string{0: "429 (get out)", 1: "banned"},
 				},
 			},
 			err:        ErrBanned,
 			errMessage: "we got banned: 429 (get out)",
 		},
+		"fallback to next URL": {
+			ctx: context.Background(),
+			initialFetcher: &Fetcher{
+				timeout: time.Hour,
+				client: &http.Client{
+					Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+						body := []byte(`55.55.55.55`)
+						if r.URL.String() == "a" {
+							body = []byte(`not an ip`)
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(bytes.NewReader(body)),
+						}, nil
+					}),
+				},
+				ip4or6: &urlsRing{
+					index: 1,
+					urls:  []string{"a", "b"},
+				},
+			},
+			publicIP: netip.AddrFrom4([4]byte{55, 55, 55, 55}),
+			finalFetcher: &Fetcher{
+				timeout: time.Hour,
+				ip4or6: &urlsRing{
+					index: 1,
+					urls:  []string{"a", "b"},
+				},
+			},
+		},
+		"all URLs failed": {
+			ctx: context.Background(),
+			initialFetcher: &Fetcher{
+				timeout: time.Hour,
+				order:   OrderSequential,
+				client: &http.Client{
+					Transport: roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(bytes.NewReader([]byte(`::1`))),
+						}, nil
+					}),
+				},
+				ip4or6: &urlsRing{
+					index: 1,
+					urls:  []string{"a", "b"},
+				},
+			},
+			finalFetcher: &Fetcher{
+				timeout: time.Hour,
+				order:   OrderSequential,
+				ip4or6: &urlsRing{
+					index: 1,
+					urls:  []string{"a", "b"},
+				},
+			},
+			version: ipversion.IP4,
+			err:     ErrNoIPFound,
+			errMessage: `all 2 URLs failed: no IP address found: from "a" for version ipv4; ` +
+				`no IP address found: from "b" for version ipv4`,
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -327,7 +389,7 @@ func Test_fetcher_ip(t *testing.T) {
 
 			urlRing := testCase.initialFetcher.ip4or6
 
-			publicIP, err := testCase.initialFetcher.ip(testCase.ctx, urlRing, ipversion.IP4or6)
+			publicIP, err := testCase.initialFetcher.ip(testCase.ctx, urlRing, testCase.version)
 
 			assert.ErrorIs(t, err, testCase.err)
 			if testCase.err != nil {
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
@@ -11,6 +12,10 @@ type settings struct {
 	providersIP4 []Provider
 	providersIP6 []Provider
 	timeout      time.Duration
+	order        Order
+	// customURLsClient is the HTTP client to use for custom URLs only,
+	// for example with a custom TLS configuration.
+	customURLsClient *http.Client
 }
 
 func newDefaultSettings() settings {
@@ -20,13 +25,14 @@ func newDefaultSettings() settings {
 		providersIP4: []Provider{Ipify},
 		providersIP6: []Provider{Ipify},
 		timeout:      defaultTimeout,
+		order:        OrderCycle,
 	}
 }
 
 type Option func(s *settings) error
 
 func SetProvidersIP(first Provider, providers ...Provider) Option {
-	providers = append(providers, first)
+	providers = append([]Provider{first}, providers...)
 	return func(s *settings) (err error) {
 		for _, provider := range providers {
 			err = ValidateProvider(provider, ipversion.IP4or6)
@@ -40,7 +46,7 @@ func SetProvidersIP(first Provider, providers ...Provider) Option {
 }
 
 func SetProvidersIP4(first Provider, providers ...Provider) Option {
-	providers = append(providers, first)
+	providers = append([]Provider{first}, providers...)
 	return func(s *settings) (err error) {
 		for _, provider := range providers {
 			err = ValidateProvider(provider, ipversion.IP4)
@@ -54,7 +60,7 @@ func SetProvidersIP4(first Provider, providers ...Provider) Option {
 }
 
 func SetProvidersIP6(first Provider, providers ...Provider) Option {
-	providers = append(providers, first)
+	providers = append([]Provider{first}, providers...)
 	return func(s *settings) (err error) {
 		for _, provider := range providers {
 			err = ValidateProvider(provider, ipversion.IP6)
@@ -73,3 +79,25 @@ func SetTimeout(timeout time.Duration) Option {
 		return nil
 	}
 }
+
+// SetOrder sets the order in which the URLs are tried, until
+// one of them succeeds.
+func SetOrder(order Order) Option {
+	return func(s *settings) (err error) {
+		err = ValidateOrder(order)
+		if err != nil {
+			return err
+		}
+		s.order = order
+		return nil
+	}
+}
+
+// SetCustomURLsClient sets the HTTP client to use for custom URLs
+// only, for example to trust a custom certificate authority.
+func SetCustomURLsClient(client *http.Client) Option {
+	return func(s *settings) (err error) {
+		s.customURLsClient = client
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// Order is the order in which the URLs of a ring are tried.
+type Order string
+
+const (
+	// OrderCycle starts from the URL after the one used last,
+	// spreading the requests over all the URLs.
+	OrderCycle Order = "cycle"
+	// OrderSequential always starts from the first URL.
+	OrderSequential Order = "sequential"
+	// OrderRandom tries the URLs in a random order.
+	OrderRandom Order = "random"
+)
+
+func ListOrders() []Order {
+	return []Order{OrderCycle, OrderSequential, OrderRandom}
+}
+
+var ErrOrderNotValid = errors.New("order is not valid")
+
+func ValidateOrder(order Order) error {
+	for _, possible := range ListOrders() {
+		if order == possible {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrOrderNotValid, order)
+}
+
+// indices returns the ring URL indices in the order they should
+// be tried. It must be called with the ring mutex locked.
+func (u *urlsRing) indices(order Order) (indices []int) {
+	switch order {
+	case OrderSequential:
+		indices = make([]int, len(u.urls))
+		for i := range indices {
+			indices[i] = i
+		}
+	case OrderRandom:
+		indices = rand.Perm(len(u.urls)) //nolint:gosec
+	default: // OrderCycle
+		indices = make([]int, len(u.urls))
+		for i := range indices {
+			indices[i] = (u.index + 1 + i) % len(u.urls)
+		}
+	}
+	return indices
+}
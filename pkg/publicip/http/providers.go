@@ -151,6 +151,11 @@ func (provider Provider) url(version ipversion.IPVersion) (url string, ok bool)
 	return url, true
 }
 
+// IsCustom returns true if the provider is a custom URL.
+func (provider Provider) IsCustom() bool {
+	return strings.HasPrefix(string(provider), "url:")
+}
+
 func (provider Provider) SupportsVersion(version ipversion.IPVersion) bool {
 	_, ok := provider.url(version)
 	return ok
@@ -0,0 +1,50 @@
+// Package iface obtains the public IP address directly from the
+// addresses of a local network interface, for hosts without NAT
+// or with a global IPv6 address on their interface.
+package iface
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type Fetcher struct {
+	name      string
+	ipVersion ipversion.IPVersion
+	// interfaceAddrs is net.InterfaceByName followed by Addrs,
+	// and can be swapped in tests.
+	interfaceAddrs func(name string) (addrs []net.Addr, err error)
+}
+
+var ErrInterfaceNameNotSet = errors.New("interface name is not set")
+
+func New(options ...Option) (f *Fetcher, err error) {
+	settings := newDefaultSettings()
+	for _, option := range options {
+		err = option(&settings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.name == "" {
+		return nil, fmt.Errorf("%w", ErrInterfaceNameNotSet)
+	}
+
+	return &Fetcher{
+		name:           settings.name,
+		ipVersion:      settings.ipVersion,
+		interfaceAddrs: interfaceAddrs,
+	}, nil
+}
+
+func interfaceAddrs(name string) (addrs []net.Addr, err error) {
+	netInterface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return netInterface.Addrs()
+}
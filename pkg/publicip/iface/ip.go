@@ -0,0 +1,89 @@
+package iface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+var (
+	ErrIPVersionNotEnabled = errors.New("IP version is not enabled for the interface")
+	ErrNoPublicIPFound     = errors.New("no public IP address found on interface")
+)
+
+func (f *Fetcher) IP(_ context.Context) (publicIP netip.Addr, err error) {
+	return f.ip(f.ipVersion)
+}
+
+func (f *Fetcher) IP4(_ context.Context) (publicIP netip.Addr, err error) {
+	if f.ipVersion == ipversion.IP6 {
+		return netip.Addr{}, fmt.Errorf("%w: %s", ErrIPVersionNotEnabled, ipversion.IP4)
+	}
+	return f.ip(ipversion.IP4)
+}
+
+func (f *Fetcher) IP6(_ context.Context) (publicIP netip.Addr, err error) {
+	if f.ipVersion == ipversion.IP4 {
+		return netip.Addr{}, fmt.Errorf("%w: %s", ErrIPVersionNotEnabled, ipversion.IP6)
+	}
+	return f.ip(ipversion.IP6)
+}
+
+func (f *Fetcher) ip(version ipversion.IPVersion) (publicIP netip.Addr, err error) {
+	addrs, err := f.interfaceAddrs(f.name)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("listing addresses of interface %s: %w", f.name, err)
+	}
+
+	var ipv4, ipv6 netip.Addr
+	for _, addr := range addrs {
+		ip, ok := addrToIP(addr)
+		if !ok || !isPublic(ip) {
+			continue
+		}
+
+		switch {
+		case ip.Is4() && !ipv4.IsValid():
+			ipv4 = ip
+		case ip.Is6() && !ipv6.IsValid():
+			ipv6 = ip
+		}
+	}
+
+	switch {
+	case version != ipversion.IP6 && ipv4.IsValid():
+		return ipv4, nil
+	case version != ipversion.IP4 && ipv6.IsValid():
+		return ipv6, nil
+	default:
+		return netip.Addr{}, fmt.Errorf("%w: %s for version %s",
+			ErrNoPublicIPFound, f.name, version)
+	}
+}
+
+func addrToIP(addr net.Addr) (ip netip.Addr, ok bool) {
+	var netIP net.IP
+	switch typedAddr := addr.(type) {
+	case *net.IPNet:
+		netIP = typedAddr.IP
+	case *net.IPAddr:
+		netIP = typedAddr.IP
+	default:
+		return netip.Addr{}, false
+	}
+	ip, ok = netip.AddrFromSlice(netIP)
+	return ip.Unmap(), ok
+}
+
+// sharedAddressSpace is the carrier-grade NAT range from RFC 6598.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10") //nolint:gochecknoglobals
+
+func isPublic(ip netip.Addr) bool {
+	return ip.IsGlobalUnicast() &&
+		!ip.IsPrivate() &&
+		!sharedAddressSpace.Contains(ip)
+}
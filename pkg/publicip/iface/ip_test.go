@@ -0,0 +1,115 @@
+package iface
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Fetcher_ip(t *testing.T) {
+	t.Parallel()
+
+	ipNet := func(s string) net.Addr {
+		ip, network, err := net.ParseCIDR(s)
+		if err != nil {
+			panic(err)
+		}
+		network.IP = ip
+		return network
+	}
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		addrs      []net.Addr
+		addrsErr   error
+		version    ipversion.IPVersion
+		ip         netip.Addr
+		err        error
+		errMessage string
+	}{
+		"addresses error": {
+			addrsErr:   errTest,
+			err:        errTest,
+			errMessage: "listing addresses of interface eth0: test error",
+		},
+		"skip non public addresses": {
+			addrs: []net.Addr{
+				ipNet("127.0.0.1/8"),
+				ipNet("192.168.1.2/24"),
+				ipNet("100.64.1.2/10"),
+				ipNet("169.254.1.2/16"),
+				ipNet("fe80::1/64"),
+				ipNet("fd00::1/64"),
+			},
+			version:    ipversion.IP4or6,
+			err:        ErrNoPublicIPFound,
+			errMessage: "no public IP address found on interface: eth0 for version ipv4 or ipv6",
+		},
+		"ipv4 preferred": {
+			addrs: []net.Addr{
+				ipNet("2001:db8::1/64"),
+				ipNet("192.168.1.2/24"),
+				ipNet("1.2.3.4/24"),
+			},
+			version: ipversion.IP4or6,
+			ip:      netip.MustParseAddr("1.2.3.4"),
+		},
+		"ipv6 global": {
+			addrs: []net.Addr{
+				ipNet("fe80::1/64"),
+				ipNet("1.2.3.4/24"),
+				ipNet("2001:db8::1/64"),
+			},
+			version: ipversion.IP6,
+			ip:      netip.MustParseAddr("2001:db8::1"),
+		},
+		"no ipv4": {
+			addrs: []net.Addr{
+				ipNet("2001:db8::1/64"),
+			},
+			version:    ipversion.IP4,
+			err:        ErrNoPublicIPFound,
+			errMessage: "no public IP address found on interface: eth0 for version ipv4",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fetcher := &Fetcher{
+				name:      "eth0",
+				ipVersion: ipversion.IP4or6,
+				interfaceAddrs: func(name string) ([]net.Addr, error) {
+					assert.Equal(t, "eth0", name)
+					return testCase.addrs, testCase.addrsErr
+				},
+			}
+
+			ip, err := fetcher.ip(testCase.version)
+
+			assert.ErrorIs(t, err, testCase.err)
+			if testCase.err != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.ip, ip)
+		})
+	}
+}
+
+func Test_Fetcher_IP4_versionNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	fetcher := &Fetcher{name: "eth0", ipVersion: ipversion.IP6}
+
+	_, err := fetcher.IP4(context.Background())
+
+	assert.ErrorIs(t, err, ErrIPVersionNotEnabled)
+}
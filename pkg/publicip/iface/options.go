@@ -0,0 +1,37 @@
+package iface
+
+import (
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+type settings struct {
+	name      string
+	ipVersion ipversion.IPVersion
+}
+
+func newDefaultSettings() settings {
+	return settings{
+		ipVersion: ipversion.IP4or6,
+	}
+}
+
+type Option func(s *settings) error
+
+// SetName sets the name of the network interface to read
+// the public IP address from, for example eth0.
+func SetName(name string) Option {
+	return func(s *settings) (err error) {
+		s.name = name
+		return nil
+	}
+}
+
+// SetIPVersion restricts the IP version of the addresses picked
+// from the network interface. With ipversion.IP4or6, a global IPv4
+// address is preferred over a global IPv6 address.
+func SetIPVersion(version ipversion.IPVersion) Option {
+	return func(s *settings) (err error) {
+		s.ipVersion = version
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package ipversion
 import (
 	"errors"
 	"fmt"
+	"net/netip"
 	"strings"
 )
 
@@ -12,6 +13,8 @@ const (
 	IP4or6 IPVersion = iota
 	IP4
 	IP6
+	// IP4and6 is used to update both the IPv4 and IPv6 addresses.
+	IP4and6
 )
 
 func (v IPVersion) String() string {
@@ -22,6 +25,8 @@ func (v IPVersion) String() string {
 		return "ipv4"
 	case IP6:
 		return "ipv6"
+	case IP4and6:
+		return "ipv4 and ipv6"
 	default:
 		return "ip?"
 	}
@@ -37,7 +42,25 @@ func Parse(s string) (version IPVersion, err error) {
 		return IP4, nil
 	case "ipv6":
 		return IP6, nil
+	case "ipv4 and ipv6":
+		return IP4and6, nil
 	default:
 		return IP4or6, fmt.Errorf("%w: %q", ErrInvalidIPVersion, s)
 	}
 }
+
+// Matches returns true if the IP address family matches the IP version.
+// IPv4-mapped IPv6 addresses are considered IPv4 addresses, and both
+// families match the IP versions ipv4 or ipv6 and ipv4 and ipv6.
+func (v IPVersion) Matches(ip netip.Addr) bool {
+	switch v {
+	case IP4:
+		return ip.Is4() || ip.Is4In6()
+	case IP6:
+		return ip.Is6() && !ip.Is4In6()
+	case IP4or6, IP4and6:
+		return ip.IsValid()
+	default:
+		return false
+	}
+}
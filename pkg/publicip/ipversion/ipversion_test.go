@@ -0,0 +1,44 @@
+package ipversion
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IPVersion_Matches(t *testing.T) {
+	t.Parallel()
+
+	ipv4 := netip.MustParseAddr("1.2.3.4")
+	ipv4In6 := netip.MustParseAddr("::ffff:1.2.3.4")
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+
+	testCases := map[string]struct {
+		version IPVersion
+		ip      netip.Addr
+		matches bool
+	}{
+		"ipv4_ipv4":          {version: IP4, ip: ipv4, matches: true},
+		"ipv4_ipv4_in_ipv6":  {version: IP4, ip: ipv4In6, matches: true},
+		"ipv4_ipv6":          {version: IP4, ip: ipv6},
+		"ipv6_ipv6":          {version: IP6, ip: ipv6, matches: true},
+		"ipv6_ipv4":          {version: IP6, ip: ipv4},
+		"ipv6_ipv4_in_ipv6":  {version: IP6, ip: ipv4In6},
+		"ipv4_or_ipv6_ipv4":  {version: IP4or6, ip: ipv4, matches: true},
+		"ipv4_or_ipv6_ipv6":  {version: IP4or6, ip: ipv6, matches: true},
+		"ipv4_and_ipv6_ipv6": {version: IP4and6, ip: ipv6, matches: true},
+		"invalid_ip":         {version: IP4or6},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			matches := testCase.version.Matches(testCase.ip)
+
+			assert.Equal(t, testCase.matches, matches)
+		})
+	}
+}
@@ -3,10 +3,15 @@ package publicip
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/netip"
+	"slices"
 
 	"github.com/qdm12/ddns-updater/pkg/publicip/dns"
 	"github.com/qdm12/ddns-updater/pkg/publicip/http"
+	"github.com/qdm12/ddns-updater/pkg/publicip/iface"
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+	"github.com/qdm12/ddns-updater/pkg/publicip/stun"
 )
 
 type ipFetcher interface {
@@ -16,18 +21,22 @@ type ipFetcher interface {
 }
 
 type Fetcher struct {
-	settings settings
+	settings Settings
 	fetchers []ipFetcher
-	// Cycling effect if both are enabled
+	// ipv6Prefix is used instead of fetchers for IPv6 if it is not nil.
+	ipv6Prefix ipFetcher
+	// Cycling effect for the cycle strategy
 	counter *uint32 // 32 bit for 32 bit systems
 }
 
 var ErrNoFetchTypeSpecified = errors.New("at least one fetcher type must be specified")
 
-func NewFetcher(dnsSettings DNSSettings, httpSettings HTTPSettings) (f *Fetcher, err error) {
-	settings := settings{
-		dns:  dnsSettings,
-		http: httpSettings,
+// NewFetcher creates a public IP fetcher using the sub fetchers enabled
+// in the settings, in the order interface, STUN, DNS and HTTP.
+func NewFetcher(settings Settings) (f *Fetcher, err error) {
+	err = ValidateStrategy(settings.Strategy)
+	if err != nil {
+		return nil, err
 	}
 
 	fetcher := &Fetcher{
@@ -35,16 +44,36 @@ func NewFetcher(dnsSettings DNSSettings, httpSettings HTTPSettings) (f *Fetcher,
 		counter:  new(uint32),
 	}
 
-	if settings.dns.Enabled {
-		subFetcher, err := dns.New(settings.dns.Options...)
+	if settings.Interface.Enabled {
+		subFetcher, err := iface.New(settings.Interface.Options...)
 		if err != nil {
 			return nil, err
 		}
 		fetcher.fetchers = append(fetcher.fetchers, subFetcher)
 	}
 
-	if settings.http.Enabled {
-		subFetcher, err := http.New(settings.http.Client, settings.http.Options...)
+	if settings.STUN.Enabled {
+		subFetcher, err := stun.New(settings.STUN.Options...)
+		if err != nil {
+			return nil, err
+		}
+		fetcher.fetchers = append(fetcher.fetchers, subFetcher)
+	}
+
+	if settings.DNS.Enabled {
+		subFetcher, err := dns.New(settings.DNS.Options...)
+		if err != nil {
+			return nil, err
+		}
+		fetcher.fetchers = append(fetcher.fetchers, subFetcher)
+	}
+
+	if settings.HTTP.Enabled {
+		options := slices.Clone(settings.HTTP.Options)
+		if settings.HTTP.CustomURLsClient != nil {
+			options = append(options, http.SetCustomURLsClient(settings.HTTP.CustomURLsClient))
+		}
+		subFetcher, err := http.New(settings.HTTP.Client, options...)
 		if err != nil {
 			return nil, err
 		}
@@ -55,17 +84,35 @@ func NewFetcher(dnsSettings DNSSettings, httpSettings HTTPSettings) (f *Fetcher,
 		return nil, ErrNoFetchTypeSpecified
 	}
 
+	if settings.IPv6Prefix.Enabled {
+		options := append(slices.Clone(settings.IPv6Prefix.Options), iface.SetIPVersion(ipversion.IP6))
+		ipv6Prefix, err := iface.New(options...)
+		if err != nil {
+			return nil, fmt.Errorf("IPv6 prefix interface: %w", err)
+		}
+		fetcher.ipv6Prefix = ipv6Prefix
+	}
+
 	return fetcher, nil
 }
 
 func (f *Fetcher) IP(ctx context.Context) (ip netip.Addr, err error) {
-	return f.getSubFetcher().IP(ctx)
+	return f.fetch(ctx, func(ctx context.Context, fetcher ipFetcher) (netip.Addr, error) {
+		return fetcher.IP(ctx)
+	})
 }
 
 func (f *Fetcher) IP4(ctx context.Context) (ipv4 netip.Addr, err error) {
-	return f.getSubFetcher().IP4(ctx)
+	return f.fetch(ctx, func(ctx context.Context, fetcher ipFetcher) (netip.Addr, error) {
+		return fetcher.IP4(ctx)
+	})
 }
 
 func (f *Fetcher) IP6(ctx context.Context) (ipv6 netip.Addr, err error) {
-	return f.getSubFetcher().IP6(ctx)
+	if f.ipv6Prefix != nil {
+		return f.ipv6Prefix.IP6(ctx)
+	}
+	return f.fetch(ctx, func(ctx context.Context, fetcher ipFetcher) (netip.Addr, error) {
+		return fetcher.IP6(ctx)
+	})
 }
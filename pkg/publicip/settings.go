@@ -5,12 +5,30 @@ import (
 
 	"github.com/qdm12/ddns-updater/pkg/publicip/dns"
 	iphttp "github.com/qdm12/ddns-updater/pkg/publicip/http"
+	"github.com/qdm12/ddns-updater/pkg/publicip/iface"
+	"github.com/qdm12/ddns-updater/pkg/publicip/stun"
 )
 
-type settings struct {
-	// If both dns and http are enabled it will cycle between both of them.
-	dns  DNSSettings
-	http HTTPSettings
+type Settings struct {
+	Interface InterfaceSettings
+	STUN      STUNSettings
+	DNS       DNSSettings
+	HTTP      HTTPSettings
+	Strategy  Strategy
+	// IPv6Prefix, if enabled, is the network interface the IPv6 address
+	// is read from instead of the sub fetchers above, typically to get
+	// the prefix delegated by the ISP to merge with a record IPv6 suffix.
+	IPv6Prefix InterfaceSettings
+}
+
+type InterfaceSettings struct {
+	Enabled bool
+	Options []iface.Option
+}
+
+type STUNSettings struct {
+	Enabled bool
+	Options []stun.Option
 }
 
 type DNSSettings struct {
@@ -21,5 +39,8 @@ type DNSSettings struct {
 type HTTPSettings struct {
 	Enabled bool
 	Client  *http.Client
-	Options []iphttp.Option
+	// CustomURLsClient is the client to use for custom URLs only,
+	// and Client is used instead if it is nil.
+	CustomURLsClient *http.Client
+	Options          []iphttp.Option
 }
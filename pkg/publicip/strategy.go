@@ -0,0 +1,39 @@
+package publicip
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Strategy is how the sub fetchers are queried to obtain
+// the public IP address.
+type Strategy string
+
+const (
+	// StrategyCycle starts from the sub fetcher after the one used
+	// last and tries the next ones on failure.
+	StrategyCycle Strategy = "cycle"
+	// StrategyFirst tries the sub fetchers in order and the first
+	// success wins.
+	StrategyFirst Strategy = "first"
+	// StrategyAllAgree queries all the sub fetchers and requires all
+	// the IP addresses obtained to be the same.
+	StrategyAllAgree Strategy = "all-agree"
+	// StrategyRandom tries the sub fetchers in a random order.
+	StrategyRandom Strategy = "random"
+)
+
+func ListStrategies() []Strategy {
+	return []Strategy{StrategyCycle, StrategyFirst, StrategyAllAgree, StrategyRandom}
+}
+
+var ErrStrategyNotValid = errors.New("strategy is not valid")
+
+func ValidateStrategy(strategy Strategy) error {
+	for _, possible := range ListStrategies() {
+		if strategy == possible {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrStrategyNotValid, strategy)
+}
@@ -0,0 +1,104 @@
+package stun
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/qdm12/ddns-updater/pkg/publicip/ipversion"
+)
+
+func (f *Fetcher) IP(ctx context.Context) (publicIP netip.Addr, err error) {
+	return f.ip(ctx, ipversion.IP4or6)
+}
+
+func (f *Fetcher) IP4(ctx context.Context) (publicIP netip.Addr, err error) {
+	return f.ip(ctx, ipversion.IP4)
+}
+
+func (f *Fetcher) IP6(ctx context.Context) (publicIP netip.Addr, err error) {
+	return f.ip(ctx, ipversion.IP6)
+}
+
+var ErrIPVersionMismatch = errors.New("IP address version mismatch")
+
+// ip tries each STUN server in order and only fails if all of them fail.
+func (f *Fetcher) ip(ctx context.Context, version ipversion.IPVersion) (
+	publicIP netip.Addr, err error) {
+	network := "udp"
+	switch version {
+	case ipversion.IP4:
+		network = "udp4"
+	case ipversion.IP6:
+		network = "udp6"
+	}
+
+	errs := make([]string, 0, len(f.servers))
+	for _, server := range f.servers {
+		publicIP, err = f.fetch(ctx, network, server)
+		if err == nil {
+			switch {
+			case version == ipversion.IP4 && !publicIP.Is4(),
+				version == ipversion.IP6 && !publicIP.Is6():
+				err = fmt.Errorf("%w: %s is not %s", ErrIPVersionMismatch, publicIP, version)
+			default:
+				return publicIP, nil
+			}
+		}
+
+		if ctx.Err() != nil {
+			return netip.Addr{}, err
+		}
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) == 1 {
+		return netip.Addr{}, err
+	}
+	return netip.Addr{}, fmt.Errorf("all %d STUN servers failed: %s",
+		len(errs), strings.Join(errs, "; "))
+}
+
+func (f *Fetcher) fetch(ctx context.Context, network, server string) (
+	publicIP netip.Addr, err error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	connection, err := dialer.DialContext(ctx, network, server)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer connection.Close()
+
+	deadline, _ := ctx.Deadline()
+	err = connection.SetDeadline(deadline)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("setting connection deadline: %w", err)
+	}
+
+	var id transactionID
+	_, _ = rand.Read(id[:])
+
+	_, err = connection.Write(newBindingRequest(id))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("sending binding request to %s: %w", server, err)
+	}
+
+	const maxResponseSize = 1500
+	response := make([]byte, maxResponseSize)
+	n, err := connection.Read(response)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("reading binding response from %s: %w", server, err)
+	}
+
+	publicIP, err = parseBindingResponse(response[:n], id)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("parsing binding response from %s: %w", server, err)
+	}
+	return publicIP.Unmap(), nil
+}
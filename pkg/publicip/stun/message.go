@@ -0,0 +1,143 @@
+package stun
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// See RFC 5389 for the message format.
+const (
+	headerLength             = 20
+	magicCookie       uint32 = 0x2112A442
+	bindingRequest    uint16 = 0x0001
+	bindingSuccess    uint16 = 0x0101
+	attrMappedAddress uint16 = 0x0001
+	attrXORMapped     uint16 = 0x0020
+	familyIPv4        byte   = 0x01
+	familyIPv6        byte   = 0x02
+)
+
+type transactionID [12]byte
+
+func newBindingRequest(id transactionID) (request []byte) {
+	request = make([]byte, headerLength)
+	binary.BigEndian.PutUint16(request[0:2], bindingRequest)
+	binary.BigEndian.PutUint16(request[2:4], 0) // no attribute
+	binary.BigEndian.PutUint32(request[4:8], magicCookie)
+	copy(request[8:20], id[:])
+	return request
+}
+
+var (
+	ErrResponseTooShort       = errors.New("response is too short")
+	ErrResponseTypeNotValid   = errors.New("response type is not valid")
+	ErrMagicCookieMismatch    = errors.New("magic cookie mismatch")
+	ErrTransactionIDMismatch  = errors.New("transaction ID mismatch")
+	ErrAttributeTooShort      = errors.New("attribute is too short")
+	ErrAddressFamilyNotValid  = errors.New("address family is not valid")
+	ErrMappedAddressNotFound  = errors.New("mapped address not found in response")
+	ErrResponseLengthNotValid = errors.New("response length is not valid")
+)
+
+// parseBindingResponse parses a binding success response and returns the
+// IP address from its XOR-MAPPED-ADDRESS attribute, or from its
+// MAPPED-ADDRESS attribute for old servers.
+func parseBindingResponse(response []byte, id transactionID) (ip netip.Addr, err error) {
+	if len(response) < headerLength {
+		return netip.Addr{}, fmt.Errorf("%w: %d bytes", ErrResponseTooShort, len(response))
+	}
+
+	messageType := binary.BigEndian.Uint16(response[0:2])
+	if messageType != bindingSuccess {
+		return netip.Addr{}, fmt.Errorf("%w: 0x%04x", ErrResponseTypeNotValid, messageType)
+	}
+
+	messageLength := int(binary.BigEndian.Uint16(response[2:4]))
+	if headerLength+messageLength > len(response) {
+		return netip.Addr{}, fmt.Errorf("%w: %d bytes announced for %d bytes received",
+			ErrResponseLengthNotValid, messageLength, len(response)-headerLength)
+	}
+
+	if binary.BigEndian.Uint32(response[4:8]) != magicCookie {
+		return netip.Addr{}, fmt.Errorf("%w", ErrMagicCookieMismatch)
+	}
+
+	if !bytes.Equal(response[8:20], id[:]) {
+		return netip.Addr{}, fmt.Errorf("%w", ErrTransactionIDMismatch)
+	}
+
+	var mappedIP netip.Addr
+	attributes := response[headerLength : headerLength+messageLength]
+	for len(attributes) >= 4 {
+		attrType := binary.BigEndian.Uint16(attributes[0:2])
+		attrLength := int(binary.BigEndian.Uint16(attributes[2:4]))
+		if 4+attrLength > len(attributes) {
+			return netip.Addr{}, fmt.Errorf("%w: %d bytes announced for %d bytes left",
+				ErrAttributeTooShort, attrLength, len(attributes)-4)
+		}
+		value := attributes[4 : 4+attrLength]
+
+		switch attrType {
+		case attrXORMapped:
+			return parseAddress(value, response[4:20])
+		case attrMappedAddress:
+			mappedIP, err = parseAddress(value, nil)
+			if err != nil {
+				return netip.Addr{}, err
+			}
+		}
+
+		// Attributes are padded to a multiple of 4 bytes
+		const alignment = 4
+		paddedLength := (attrLength + alignment - 1) / alignment * alignment
+		if 4+paddedLength > len(attributes) {
+			break
+		}
+		attributes = attributes[4+paddedLength:]
+	}
+
+	if mappedIP.IsValid() {
+		return mappedIP, nil
+	}
+	return netip.Addr{}, fmt.Errorf("%w", ErrMappedAddressNotFound)
+}
+
+// parseAddress parses a (XOR-)MAPPED-ADDRESS attribute value.
+// xorKey is the magic cookie followed by the transaction ID for
+// XOR-MAPPED-ADDRESS, and nil for MAPPED-ADDRESS.
+func parseAddress(value, xorKey []byte) (ip netip.Addr, err error) {
+	// 1 byte reserved, 1 byte family, 2 bytes port, then the address
+	const addressOffset = 4
+	if len(value) < addressOffset {
+		return netip.Addr{}, fmt.Errorf("%w: %d bytes", ErrAttributeTooShort, len(value))
+	}
+
+	var addressLength int
+	switch value[1] {
+	case familyIPv4:
+		addressLength = 4
+	case familyIPv6:
+		addressLength = 16
+	default:
+		return netip.Addr{}, fmt.Errorf("%w: 0x%02x", ErrAddressFamilyNotValid, value[1])
+	}
+
+	if len(value) < addressOffset+addressLength {
+		return netip.Addr{}, fmt.Errorf("%w: %d bytes for address family 0x%02x",
+			ErrAttributeTooShort, len(value), value[1])
+	}
+
+	address := make([]byte, addressLength)
+	copy(address, value[addressOffset:addressOffset+addressLength])
+	if xorKey != nil {
+		for i := range address {
+			address[i] ^= xorKey[i]
+		}
+	}
+
+	ip, _ = netip.AddrFromSlice(address)
+	return ip, nil
+}
@@ -0,0 +1,129 @@
+package stun
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newBindingRequest(t *testing.T) {
+	t.Parallel()
+
+	id := transactionID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+
+	request := newBindingRequest(id)
+
+	expected := []byte{
+		0x00, 0x01, 0x00, 0x00, 0x21, 0x12, 0xA4, 0x42,
+		1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+	}
+	assert.Equal(t, expected, request)
+}
+
+func Test_parseBindingResponse(t *testing.T) {
+	t.Parallel()
+
+	id := transactionID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+
+	makeResponse := func(attributes ...[]byte) []byte {
+		response := newBindingRequest(id)
+		binary.BigEndian.PutUint16(response[0:2], bindingSuccess)
+		for _, attribute := range attributes {
+			response = append(response, attribute...)
+		}
+		binary.BigEndian.PutUint16(response[2:4], uint16(len(response)-headerLength))
+		return response
+	}
+
+	xorIPv4Attribute := []byte{
+		0x00, 0x20, 0x00, 0x08, // XOR-MAPPED-ADDRESS, length 8
+		0x00, 0x01, 0x00, 0x00, // IPv4, port ignored
+		1 ^ 0x21, 2 ^ 0x12, 3 ^ 0xA4, 4 ^ 0x42,
+	}
+	xorIPv6Attribute := append([]byte{
+		0x00, 0x20, 0x00, 0x14, // XOR-MAPPED-ADDRESS, length 20
+		0x00, 0x02, 0x00, 0x00, // IPv6, port ignored
+	}, xorBytes(netip.MustParseAddr("2001:db8::1").AsSlice(),
+		[]byte{0x21, 0x12, 0xA4, 0x42, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})...)
+	mappedAttribute := []byte{
+		0x00, 0x01, 0x00, 0x08, // MAPPED-ADDRESS, length 8
+		0x00, 0x01, 0x00, 0x00, // IPv4, port ignored
+		5, 6, 7, 8,
+	}
+	softwareAttribute := []byte{
+		0x80, 0x22, 0x00, 0x03, // SOFTWARE, length 3
+		'a', 'b', 'c', 0x00, // padded to 4 bytes
+	}
+
+	testCases := map[string]struct {
+		response   []byte
+		id         transactionID
+		ip         netip.Addr
+		err        error
+		errMessage string
+	}{
+		"too short": {
+			response:   []byte{1},
+			id:         id,
+			err:        ErrResponseTooShort,
+			errMessage: "response is too short: 1 bytes",
+		},
+		"transaction id mismatch": {
+			response:   makeResponse(xorIPv4Attribute),
+			id:         transactionID{},
+			err:        ErrTransactionIDMismatch,
+			errMessage: "transaction ID mismatch",
+		},
+		"xor mapped IPv4": {
+			response: makeResponse(softwareAttribute, xorIPv4Attribute),
+			id:       id,
+			ip:       netip.AddrFrom4([4]byte{1, 2, 3, 4}),
+		},
+		"xor mapped IPv6": {
+			response: makeResponse(xorIPv6Attribute),
+			id:       id,
+			ip:       netip.MustParseAddr("2001:db8::1"),
+		},
+		"xor mapped preferred over mapped": {
+			response: makeResponse(mappedAttribute, xorIPv4Attribute),
+			id:       id,
+			ip:       netip.AddrFrom4([4]byte{1, 2, 3, 4}),
+		},
+		"mapped only": {
+			response: makeResponse(mappedAttribute),
+			id:       id,
+			ip:       netip.AddrFrom4([4]byte{5, 6, 7, 8}),
+		},
+		"no address": {
+			response:   makeResponse(softwareAttribute),
+			id:         id,
+			err:        ErrMappedAddressNotFound,
+			errMessage: "mapped address not found in response",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ip, err := parseBindingResponse(testCase.response, testCase.id)
+
+			assert.ErrorIs(t, err, testCase.err)
+			if testCase.err != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.ip, ip)
+		})
+	}
+}
+
+func xorBytes(a, b []byte) (result []byte) {
+	result = make([]byte, len(a))
+	for i := range a {
+		result[i] = a[i] ^ b[i]
+	}
+	return result
+}
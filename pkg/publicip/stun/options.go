@@ -0,0 +1,48 @@
+package stun
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+type settings struct {
+	servers []string
+	timeout time.Duration
+}
+
+func newDefaultSettings() settings {
+	const defaultTimeout = 3 * time.Second
+	return settings{
+		servers: []string{"stun.l.google.com:19302"},
+		timeout: defaultTimeout,
+	}
+}
+
+type Option func(s *settings) error
+
+var ErrServerAddressNotValid = errors.New("STUN server address is not valid")
+
+// SetServers sets the STUN servers addresses in the form host:port,
+// tried in order until one of them succeeds.
+func SetServers(first string, servers ...string) Option {
+	servers = append([]string{first}, servers...)
+	return func(s *settings) (err error) {
+		for _, server := range servers {
+			_, _, err = net.SplitHostPort(server)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrServerAddressNotValid, err)
+			}
+		}
+		s.servers = servers
+		return nil
+	}
+}
+
+func SetTimeout(timeout time.Duration) Option {
+	return func(s *settings) (err error) {
+		s.timeout = timeout
+		return nil
+	}
+}
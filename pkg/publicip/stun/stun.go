@@ -0,0 +1,27 @@
+// Package stun obtains the public IP address presented by the NAT
+// for UDP traffic, using STUN binding requests as defined in RFC 5389.
+package stun
+
+import (
+	"time"
+)
+
+type Fetcher struct {
+	servers []string
+	timeout time.Duration
+}
+
+func New(options ...Option) (f *Fetcher, err error) {
+	settings := newDefaultSettings()
+	for _, option := range options {
+		err = option(&settings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Fetcher{
+		servers: settings.servers,
+		timeout: settings.timeout,
+	}, nil
+}
@@ -1,14 +1,100 @@
 package publicip
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/netip"
+	"strings"
+	"sync"
 	"sync/atomic"
 )
 
-func (f *Fetcher) getSubFetcher() ipFetcher { //nolint:ireturn
-	fetcher := f.fetchers[0]
-	if len(f.fetchers) > 1 { // cycling effect
-		index := int(atomic.AddUint32(f.counter, 1)) % len(f.fetchers)
-		fetcher = f.fetchers[index]
+type fetchFunc func(ctx context.Context, fetcher ipFetcher) (ip netip.Addr, err error)
+
+func (f *Fetcher) fetch(ctx context.Context, fetchIP fetchFunc) (ip netip.Addr, err error) {
+	if f.settings.Strategy == StrategyAllAgree {
+		return f.fetchAllAgree(ctx, fetchIP)
+	}
+
+	var errs []string
+	for _, fetcher := range f.orderedSubFetchers() {
+		ip, err = fetchIP(ctx, fetcher)
+		if err == nil {
+			return ip, nil
+		} else if ctx.Err() != nil {
+			return netip.Addr{}, err
+		}
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) == 1 {
+		return netip.Addr{}, err
+	}
+	return netip.Addr{}, fmt.Errorf("%w: %s", ErrAllFetchersFailed, strings.Join(errs, "; "))
+}
+
+func (f *Fetcher) orderedSubFetchers() (fetchers []ipFetcher) {
+	switch f.settings.Strategy {
+	case StrategyFirst:
+		return f.fetchers
+	case StrategyRandom:
+		fetchers = make([]ipFetcher, len(f.fetchers))
+		for i, j := range rand.Perm(len(f.fetchers)) { //nolint:gosec
+			fetchers[i] = f.fetchers[j]
+		}
+		return fetchers
+	default: // StrategyCycle
+		start := 0
+		if len(f.fetchers) > 1 { // cycling effect
+			start = int(atomic.AddUint32(f.counter, 1)) % len(f.fetchers)
+		}
+		fetchers = make([]ipFetcher, len(f.fetchers))
+		for i := range fetchers {
+			fetchers[i] = f.fetchers[(start+i)%len(f.fetchers)]
+		}
+		return fetchers
+	}
+}
+
+var (
+	ErrAllFetchersFailed = errors.New("all public IP fetchers failed")
+	ErrFetchersDisagree  = errors.New("public IP fetchers disagree")
+)
+
+// fetchAllAgree queries all the sub fetchers in parallel and returns
+// the IP address only if all of them succeed and return the same address.
+// This catches a source returning a stale or wrong IP address.
+func (f *Fetcher) fetchAllAgree(ctx context.Context, fetchIP fetchFunc) (ip netip.Addr, err error) {
+	ips := make([]netip.Addr, len(f.fetchers))
+	errs := make([]error, len(f.fetchers))
+	var wg sync.WaitGroup
+	for i, fetcher := range f.fetchers {
+		wg.Add(1)
+		go func(i int, fetcher ipFetcher) {
+			defer wg.Done()
+			ips[i], errs[i] = fetchIP(ctx, fetcher)
+		}(i, fetcher)
 	}
-	return fetcher
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return netip.Addr{}, err
+		}
+	}
+
+	for _, otherIP := range ips[1:] {
+		if otherIP != ips[0] {
+			ipStrings := make([]string, len(ips))
+			for i, ip := range ips {
+				ipStrings[i] = ip.String()
+			}
+			return netip.Addr{}, fmt.Errorf("%w: %s",
+				ErrFetchersDisagree, strings.Join(ipStrings, ", "))
+		}
+	}
+
+	return ips[0], nil
 }
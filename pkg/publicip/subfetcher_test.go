@@ -0,0 +1,118 @@
+package publicip
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeFetcher struct {
+	ip  netip.Addr
+	err error
+}
+
+func (f *fakeFetcher) IP(context.Context) (netip.Addr, error)  { return f.ip, f.err }
+func (f *fakeFetcher) IP4(context.Context) (netip.Addr, error) { return f.ip, f.err }
+func (f *fakeFetcher) IP6(context.Context) (netip.Addr, error) { return f.ip, f.err }
+
+func Test_Fetcher_IP(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	ipA := netip.AddrFrom4([4]byte{1, 1, 1, 1})
+	ipB := netip.AddrFrom4([4]byte{2, 2, 2, 2})
+
+	testCases := map[string]struct {
+		strategy   Strategy
+		fetchers   []ipFetcher
+		ip         netip.Addr
+		err        error
+		errMessage string
+	}{
+		"first success wins": {
+			strategy: StrategyFirst,
+			fetchers: []ipFetcher{&fakeFetcher{ip: ipA}, &fakeFetcher{ip: ipB}},
+			ip:       ipA,
+		},
+		"first falls back on failure": {
+			strategy: StrategyFirst,
+			fetchers: []ipFetcher{&fakeFetcher{err: errTest}, &fakeFetcher{ip: ipB}},
+			ip:       ipB,
+		},
+		"cycle all failed": {
+			strategy:   StrategyCycle,
+			fetchers:   []ipFetcher{&fakeFetcher{err: errTest}, &fakeFetcher{err: errTest}},
+			err:        ErrAllFetchersFailed,
+			errMessage: "all public IP fetchers failed: test error; test error",
+		},
+		"random single fetcher failed": {
+			strategy:   StrategyRandom,
+			fetchers:   []ipFetcher{&fakeFetcher{err: errTest}},
+			err:        errTest,
+			errMessage: "test error",
+		},
+		"all agree": {
+			strategy: StrategyAllAgree,
+			fetchers: []ipFetcher{&fakeFetcher{ip: ipA}, &fakeFetcher{ip: ipA}},
+			ip:       ipA,
+		},
+		"all agree with disagreement": {
+			strategy:   StrategyAllAgree,
+			fetchers:   []ipFetcher{&fakeFetcher{ip: ipA}, &fakeFetcher{ip: ipB}},
+			err:        ErrFetchersDisagree,
+			errMessage: "public IP fetchers disagree: 1.1.1.1, 2.2.2.2",
+		},
+		"all agree with one failure": {
+			strategy:   StrategyAllAgree,
+			fetchers:   []ipFetcher{&fakeFetcher{ip: ipA}, &fakeFetcher{err: errTest}},
+			err:        errTest,
+			errMessage: "test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fetcher := &Fetcher{
+				settings: Settings{Strategy: testCase.strategy},
+				fetchers: testCase.fetchers,
+				counter:  new(uint32),
+			}
+
+			ip, err := fetcher.IP(context.Background())
+
+			assert.ErrorIs(t, err, testCase.err)
+			if testCase.err != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.ip, ip)
+		})
+	}
+}
+
+func Test_Fetcher_IP6_prefixInterface(t *testing.T) {
+	t.Parallel()
+
+	ipv4 := netip.AddrFrom4([4]byte{1, 1, 1, 1})
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+
+	fetcher := &Fetcher{
+		settings:   Settings{Strategy: StrategyFirst},
+		fetchers:   []ipFetcher{&fakeFetcher{ip: ipv4}},
+		ipv6Prefix: &fakeFetcher{ip: ipv6},
+		counter:    new(uint32),
+	}
+
+	ip, err := fetcher.IP6(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ipv6, ip)
+
+	ip, err = fetcher.IP4(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ipv4, ip)
+}